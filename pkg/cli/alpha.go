@@ -56,6 +56,9 @@ Alpha subcommands are for unstable features.
 	for i := range alphaCommands {
 		alpha.AddCommand(alphaCommands[i])
 	}
+	alpha.AddCommand(c.newDumpTemplatesCmd())
+	alpha.AddCommand(c.newMarkersCmd())
+	alpha.AddCommand(c.newMigrateCmd())
 	return alpha
 }
 
@@ -61,7 +61,7 @@ func (c CLI) newCreateAPICmd() *cobra.Command {
 		return cmd
 	}
 
-	c.applySubcommandHooks(cmd, subcommands, apiErrorMsg, false)
+	c.applySubcommandHooks(cmd, subcommands, apiErrorMsg, false, true)
 
 	return cmd
 }
@@ -22,6 +22,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -31,6 +32,7 @@ import (
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
 	"sigs.k8s.io/kubebuilder/v4/pkg/model/stage"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/external"
 )
 
 const (
@@ -39,6 +41,11 @@ const (
 
 	pluginsFlag        = "plugins"
 	projectVersionFlag = "project-version"
+	dryRunFlag         = "dry-run"
+	outputFlag         = "output"
+
+	textOutput = "text"
+	jsonOutput = "json"
 )
 
 // CLI is the command line utility that is used to scaffold kubebuilder project files.
@@ -68,6 +75,8 @@ type CLI struct { //nolint:maligned
 
 	// Plugin keys to scaffold with.
 	pluginKeys []string
+	// Plugin keys of the project configuration file, which may be overridden by the plugins flag.
+	projectPluginKeys []string
 	// Project version to scaffold.
 	projectVersion config.Version
 
@@ -218,6 +227,7 @@ func (c *CLI) getInfoFromConfigFile() error {
 // It is extracted from getInfoFromConfigFile for testing purposes.
 func (c *CLI) getInfoFromConfig(projectConfig config.Config) error {
 	c.pluginKeys = projectConfig.GetPluginChain()
+	c.projectPluginKeys = c.pluginKeys
 	c.projectVersion = projectConfig.GetVersion()
 
 	for _, pluginKey := range c.pluginKeys {
@@ -362,6 +372,16 @@ func (c *CLI) resolvePlugins() error {
 		}
 	}
 
+	// Plugins may require, conflict with or have to run after other plugins, so check and order the chain
+	if err := plugin.ValidateChain(c.resolvedPlugins, c.projectPlugins()...); err != nil {
+		return fmt.Errorf("invalid plugin chain: %w", err)
+	}
+	sortedPlugins, err := plugin.SortChain(c.resolvedPlugins)
+	if err != nil {
+		return fmt.Errorf("invalid plugin chain: %w", err)
+	}
+	c.resolvedPlugins = sortedPlugins
+
 	// Now we can try to resolve the project version if not known by this point
 	if !knownProjectVersion && len(c.resolvedPlugins) > 0 {
 		// Extract the common supported project versions
@@ -393,6 +413,25 @@ func (c *CLI) resolvePlugins() error {
 	return nil
 }
 
+// projectPlugins returns the available plugins that match the plugin keys of the project configuration file.
+// They satisfy the requirements of the resolved plugins when the plugins flag overrides the project plugins,
+// e.g. when creating an API with an optional plugin.
+func (c *CLI) projectPlugins() []plugin.Plugin {
+	available := make([]plugin.Plugin, 0, len(c.plugins))
+	for _, p := range c.plugins {
+		available = append(available, p)
+	}
+
+	plugins := make([]plugin.Plugin, 0, len(c.projectPluginKeys))
+	for _, pluginKey := range c.projectPluginKeys {
+		// Plugins that cannot be resolved unambiguously are ignored as they are not needed to run the command
+		if matching, err := plugin.FilterPluginsByKey(available, pluginKey); err == nil && len(matching) == 1 {
+			plugins = append(plugins, matching[0])
+		}
+	}
+	return plugins
+}
+
 // addSubcommands returns a root command with a subcommand tree reflecting the
 // current project's state.
 func (c *CLI) addSubcommands() {
@@ -410,10 +449,22 @@ func (c *CLI) addSubcommands() {
 	// kubebuilder create api
 	createCmd.AddCommand(c.newCreateAPICmd())
 	createCmd.AddCommand(c.newCreateWebhookCmd())
+	// kubebuilder create <custom>
+	c.addCustomSubcommands(createCmd, plugin.CreateCommand)
 	if createCmd.HasSubCommands() {
 		c.cmd.AddCommand(createCmd)
 	}
 
+	// kubebuilder delete, unless none of the resolved plugins can delete what it scaffolds
+	if len(c.resolvedPlugins) == 0 || c.canDelete() {
+		deleteCmd := c.newDeleteCmd()
+		// kubebuilder delete api
+		deleteCmd.AddCommand(c.newDeleteAPICmd())
+		// kubebuilder delete webhook
+		deleteCmd.AddCommand(c.newDeleteWebhookCmd())
+		c.cmd.AddCommand(deleteCmd)
+	}
+
 	// kubebuilder edit
 	c.cmd.AddCommand(c.newEditCmd())
 
@@ -425,6 +476,9 @@ func (c *CLI) addSubcommands() {
 	if c.version != "" {
 		c.cmd.AddCommand(c.newVersionCmd())
 	}
+
+	// kubebuilder <custom>
+	c.addCustomSubcommands(c.cmd, plugin.RootCommand)
 }
 
 // addExtraCommands adds the additional commands.
@@ -460,6 +514,13 @@ func (c CLI) metadata() plugin.CLIMetadata {
 //
 // If an error is found, command help and examples will be printed.
 func (c CLI) Run() error {
+	// External plugins running in session mode are started once and stopped when the command is done
+	defer func() {
+		if err := external.CloseSessions(); err != nil {
+			logrus.Warn(err)
+		}
+	}()
+
 	return c.cmd.Execute()
 }
 
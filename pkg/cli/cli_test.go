@@ -17,6 +17,7 @@ limitations under the License.
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -424,6 +425,55 @@ plugins:
 			Expect(c.resolvePlugins()).To(Succeed())
 			Expect(c.projectVersion.Compare(projectVersion)).To(Equal(0))
 		})
+
+		Context("with plugin dependencies", func() {
+			var extension, layout plugin.Plugin
+
+			BeforeEach(func() {
+				extension = mockDependentPlugin{
+					mockPlugin: newMockPlugin("extension.kubebuilder.io", "v1", projectVersion).(mockPlugin),
+					required:   []plugin.Constraint{plugin.MustParseConstraint("foo.kubebuilder.io/>=v2")},
+				}
+				layout = mockDependentPlugin{
+					mockPlugin:  newMockPlugin("layout.kubebuilder.io", "v1", projectVersion).(mockPlugin),
+					conflicting: []plugin.Constraint{plugin.MustParseConstraint("foo.kubebuilder.io")},
+				}
+				c.plugins = makeMapFor(append(plugins, extension, layout)...)
+				c.projectVersion = projectVersion
+			})
+
+			It("should order the resolved plugins after the plugins they require", func() {
+				c.pluginKeys = []string{"extension", "foo.kubebuilder.io/v2"}
+
+				Expect(c.resolvePlugins()).To(Succeed())
+				Expect(c.resolvedPlugins).To(HaveLen(2))
+				Expect(plugin.KeyFor(c.resolvedPlugins[0])).To(Equal("foo.kubebuilder.io/v2"))
+				Expect(plugin.KeyFor(c.resolvedPlugins[1])).To(Equal("extension.kubebuilder.io/v1"))
+			})
+
+			It("should succeed if a required plugin is one of the project plugins", func() {
+				c.projectPluginKeys = []string{"foo.kubebuilder.io/v2"}
+				c.pluginKeys = []string{"extension"}
+
+				Expect(c.resolvePlugins()).To(Succeed())
+			})
+
+			It("should fail if a required plugin is missing", func() {
+				c.pluginKeys = []string{"extension", "foo.kubebuilder.io/v1"}
+
+				err := c.resolvePlugins()
+				Expect(err).To(HaveOccurred())
+				Expect(errors.As(err, &plugin.MissingPluginError{})).To(BeTrue())
+			})
+
+			It("should fail if two plugins conflict", func() {
+				c.pluginKeys = []string{"foo.kubebuilder.io/v1", "layout"}
+
+				err := c.resolvePlugins()
+				Expect(err).To(HaveOccurred())
+				Expect(errors.As(err, &plugin.ConflictingPluginError{})).To(BeTrue())
+			})
+		})
 	})
 
 	Context("New", func() {
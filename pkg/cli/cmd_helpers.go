@@ -17,8 +17,10 @@ limitations under the License.
 package cli
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -66,6 +68,7 @@ func errCmdFunc(err error) func(*cobra.Command, []string) error {
 // keySubcommandTuple represents a pairing of the key of a plugin with a plugin.Subcommand.
 type keySubcommandTuple struct {
 	key        string
+	plugin     plugin.Plugin
 	subcommand plugin.Subcommand
 
 	// skip will be used to flag subcommands that should be skipped after any hook returned a plugin.ExitError.
@@ -89,23 +92,33 @@ func (c *CLI) filterSubcommands(
 
 	tuples := make([]keySubcommandTuple, 0, len(plugins))
 	for _, p := range plugins {
-		if filter(p) {
-			tuples = append(tuples, keySubcommandTuple{
-				key:        plugin.KeyFor(p),
-				subcommand: extract(p),
-			})
+		if !filter(p) {
+			continue
+		}
+		// Plugins return a nil subcommand for the subcommands they do not implement, e.g. external plugins
+		// whose manifest only declares some of them.
+		subcommand := extract(p)
+		if subcommand == nil {
+			continue
 		}
+		tuples = append(tuples, keySubcommandTuple{
+			key:        plugin.KeyFor(p),
+			plugin:     p,
+			subcommand: subcommand,
+		})
 	}
 	return tuples
 }
 
 // applySubcommandHooks runs the initialization hooks and configures the commands pre-run,
-// run, and post-run hooks with the appropriate execution hooks.
+// run, and post-run hooks with the appropriate execution hooks. If verifyMarkers is set, the markers
+// required by the plugins are checked before creating the resource.
 func (c *CLI) applySubcommandHooks(
 	cmd *cobra.Command,
 	subcommands []keySubcommandTuple,
 	errorMessage string,
 	createConfig bool,
+	verifyMarkers bool,
 ) {
 	// In case we create a new project configuration we need to compute the plugin chain.
 	pluginChain := make([]string, 0, len(c.resolvedPlugins))
@@ -121,11 +134,11 @@ func (c *CLI) applySubcommandHooks(
 
 	factory := executionHooksFactory{
 		fs:             c.fs,
-		store:          yamlstore.New(c.fs),
 		subcommands:    subcommands,
 		errorMessage:   errorMessage,
 		projectVersion: c.projectVersion,
 		pluginChain:    pluginChain,
+		verifyMarkers:  verifyMarkers,
 	}
 	cmd.PreRunE = factory.preRunEFunc(options, createConfig)
 	cmd.RunE = factory.runEFunc()
@@ -187,6 +200,51 @@ type executionHooksFactory struct {
 	projectVersion config.Version
 	// pluginChain is the plugin chain configured for this project.
 	pluginChain []string
+	// verifyMarkers checks that the files of the project contain the markers required by the plugins
+	// before creating the resource.
+	verifyMarkers bool
+	// overlay stages the changes in memory so that they are written all at once after every plugin succeeded.
+	overlay *machinery.Overlay
+	// dryRun reports the staged changes instead of writing them.
+	dryRun bool
+	// output is the format of the report of the scaffolded files.
+	output string
+	// out is where plugins print their messages and the output of the commands they run.
+	out io.Writer
+	// report aggregates what was done to the files scaffolded by every plugin.
+	report *machinery.ScaffoldReport
+}
+
+// filesystem returns the Filesystem where plugins stage their changes and report the scaffolded files.
+func (factory *executionHooksFactory) filesystem() machinery.Filesystem {
+	fs := factory.overlay.Filesystem()
+	fs.Report = factory.report
+	return fs
+}
+
+// writeReport writes the report of the scaffolded files in the requested output format.
+func (factory *executionHooksFactory) writeReport(cmd *cobra.Command) error {
+	if factory.output != jsonOutput {
+		return factory.report.WriteSummary(cmd.OutOrStdout())
+	}
+
+	encoder := json.NewEncoder(cmd.OutOrStdout())
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(factory.report)
+}
+
+// checkMarkers checks that the files of the project contain the markers required by the plugins
+func (factory *executionHooksFactory) checkMarkers(cfg config.Config) error {
+	plugins := make([]plugin.Plugin, 0, len(factory.subcommands))
+	for _, tuple := range factory.subcommands {
+		plugins = append(plugins, tuple.plugin)
+	}
+
+	markers, err := requiredMarkers(plugins, cfg)
+	if err != nil {
+		return err
+	}
+	return machinery.CheckMarkers(factory.fs, markers...)
 }
 
 func (factory *executionHooksFactory) forEach(cb func(subcommand plugin.Subcommand) error, errorMessage string) error {
@@ -204,7 +262,7 @@ func (factory *executionHooksFactory) forEach(cb func(subcommand plugin.Subcomma
 		case errors.As(err, &exitError):
 			// Exit errors imply that no further hooks of this subcommand should be called, so we flag it to be skipped
 			factory.subcommands[i].skip = true
-			fmt.Printf("skipping remaining hooks of %q: %s\n", tuple.key, exitError.Reason)
+			_, _ = fmt.Fprintf(factory.out, "skipping remaining hooks of %q: %s\n", tuple.key, exitError.Reason)
 		default:
 			// Any other error, wrap it
 			return fmt.Errorf("%s: %s %q: %w", factory.errorMessage, errorMessage, tuple.key, err)
@@ -215,12 +273,30 @@ func (factory *executionHooksFactory) forEach(cb func(subcommand plugin.Subcomma
 }
 
 // preRunEFunc returns a cobra RunE function that loads the configuration, creates the resource,
-// and executes inject config, inject resource, and pre-scaffold hooks.
+// and executes inject config, inject output, inject resource, and pre-scaffold hooks.
 func (factory *executionHooksFactory) preRunEFunc(
 	options *resourceOptions,
 	createConfig bool,
 ) func(*cobra.Command, []string) error {
-	return func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, _ []string) error {
+		// Stage every change so that a failure in any plugin leaves the project unchanged,
+		// and so that the changes can be reported instead of written in dry-run mode.
+		// NOTE: changes done by plugins without using the provided machinery.Filesystem are not staged.
+		factory.overlay = machinery.NewOverlay(factory.fs)
+		factory.store = yamlstore.New(factory.overlay.Filesystem())
+		factory.dryRun, _ = cmd.Flags().GetBool(dryRunFlag)
+		factory.report = &machinery.ScaffoldReport{Files: []machinery.FileReport{}}
+		factory.output, _ = cmd.Flags().GetString(outputFlag)
+		if factory.output != textOutput && factory.output != jsonOutput {
+			return fmt.Errorf("%s: invalid output format %q, expected one of: %s, %s",
+				factory.errorMessage, factory.output, textOutput, jsonOutput)
+		}
+		// The JSON report is the only output written to stdout, so that it can be parsed
+		factory.out = cmd.OutOrStdout()
+		if factory.output == jsonOutput {
+			factory.out = cmd.ErrOrStderr()
+		}
+
 		if createConfig {
 			// Check if a project configuration is already present.
 			if err := factory.store.Load(); err == nil || !errors.Is(err, os.ErrNotExist) {
@@ -250,6 +326,13 @@ func (factory *executionHooksFactory) preRunEFunc(
 		// Create the resource if non-nil options provided
 		var res *resource.Resource
 		if options != nil {
+			// Fail fast if code can not be inserted into the files updated when creating the resource
+			if factory.verifyMarkers {
+				if err := factory.checkMarkers(cfg); err != nil {
+					return fmt.Errorf("%s: %w", factory.errorMessage, err)
+				}
+			}
+
 			// TODO: offer a flag instead of hard-coding project-wide domain
 			options.Domain = cfg.GetDomain()
 			if err := options.validate(); err != nil {
@@ -268,6 +351,16 @@ func (factory *executionHooksFactory) preRunEFunc(
 			return err
 		}
 
+		// Inject output hook.
+		if err := factory.forEach(func(subcommand plugin.Subcommand) error {
+			if subcommand, requiresOutput := subcommand.(plugin.RequiresOutput); requiresOutput {
+				return subcommand.InjectOutput(factory.out)
+			}
+			return nil
+		}, "unable to inject the output to"); err != nil {
+			return err
+		}
+
 		if res != nil {
 			// Inject resource hook.
 			if err := factory.forEach(func(subcommand plugin.Subcommand) error {
@@ -288,7 +381,7 @@ func (factory *executionHooksFactory) preRunEFunc(
 		// nolint:revive
 		if err := factory.forEach(func(subcommand plugin.Subcommand) error {
 			if subcommand, hasPreScaffold := subcommand.(plugin.HasPreScaffold); hasPreScaffold {
-				return subcommand.PreScaffold(factory.fs)
+				return subcommand.PreScaffold(factory.filesystem())
 			}
 			return nil
 		}, "unable to run pre-scaffold tasks of"); err != nil {
@@ -305,7 +398,7 @@ func (factory *executionHooksFactory) runEFunc() func(*cobra.Command, []string)
 		// Scaffold hook.
 		// nolint:revive
 		if err := factory.forEach(func(subcommand plugin.Subcommand) error {
-			return subcommand.Scaffold(factory.fs)
+			return subcommand.Scaffold(factory.filesystem())
 		}, "unable to scaffold with"); err != nil {
 			return err
 		}
@@ -314,14 +407,39 @@ func (factory *executionHooksFactory) runEFunc() func(*cobra.Command, []string)
 	}
 }
 
-// postRunEFunc returns a cobra RunE function that saves the configuration
-// and executes the post-scaffold hook.
+// postRunEFunc returns a cobra RunE function that saves the configuration, writes the staged
+// changes and executes the post-scaffold hook.
 func (factory *executionHooksFactory) postRunEFunc() func(*cobra.Command, []string) error {
-	return func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, _ []string) error {
 		if err := factory.store.Save(); err != nil {
 			return fmt.Errorf("%s: unable to save configuration file: %w", factory.errorMessage, err)
 		}
 
+		// In dry-run mode, report the staged changes and skip the post-scaffold hook.
+		// The JSON report replaces the diff so that the output can be parsed.
+		if factory.dryRun {
+			if factory.output == jsonOutput {
+				if err := factory.writeReport(cmd); err != nil {
+					return fmt.Errorf("%s: unable to report scaffolded files: %w", factory.errorMessage, err)
+				}
+				return nil
+			}
+			if err := factory.overlay.Diff(cmd.OutOrStdout()); err != nil {
+				return fmt.Errorf("%s: unable to report changes: %w", factory.errorMessage, err)
+			}
+			return nil
+		}
+
+		// Write the staged changes.
+		if err := factory.overlay.Commit(); err != nil {
+			return fmt.Errorf("%s: unable to write scaffolded files: %w", factory.errorMessage, err)
+		}
+
+		// Report the scaffolded files.
+		if err := factory.writeReport(cmd); err != nil {
+			return fmt.Errorf("%s: unable to report scaffolded files: %w", factory.errorMessage, err)
+		}
+
 		// Post-scaffold hook.
 		// nolint:revive
 		if err := factory.forEach(func(subcommand plugin.Subcommand) error {
@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+)
+
+// addCustomSubcommands adds the subcommands provided by the resolved plugins under parent to parentCmd.
+// Subcommands whose name is already used by another subcommand of parentCmd are skipped.
+func (c *CLI) addCustomSubcommands(parentCmd *cobra.Command, parent plugin.CommandParent) {
+	// Collect the subcommands in the order of the plugin chain, keeping the first declaration of each name
+	var customs []plugin.CustomSubcommand
+	for _, resolved := range c.resolvedPlugins {
+		plugins := []plugin.Plugin{resolved}
+		if bundle, isBundle := resolved.(plugin.Bundle); isBundle {
+			plugins = bundle.Plugins()
+		}
+		for _, p := range plugins {
+			withCustoms, hasCustoms := p.(plugin.HasCustomSubcommands)
+			if !hasCustoms {
+				continue
+			}
+			for _, custom := range withCustoms.GetCustomSubcommands() {
+				if custom.Parent == parent && findCustomSubcommand(customs, parent, custom.Name) == nil {
+					customs = append(customs, custom)
+				}
+			}
+		}
+	}
+
+CustomSubcommandsLoop:
+	for _, custom := range customs {
+		for _, subCmd := range parentCmd.Commands() {
+			if subCmd.Name() == custom.Name || subCmd.HasAlias(custom.Name) {
+				log.Warnf("skipping subcommand %q provided by plugins as %q already exists",
+					custom.Name, subCmd.CommandPath())
+				continue CustomSubcommandsLoop
+			}
+		}
+		parentCmd.AddCommand(c.newCustomCmd(custom))
+	}
+}
+
+// newCustomCmd returns the command that runs the subcommands provided by the resolved plugins
+// with the parent and name of custom.
+func (c *CLI) newCustomCmd(custom plugin.CustomSubcommand) *cobra.Command {
+	long := custom.Long
+	if long == "" {
+		long = custom.Short
+	}
+	cmd := &cobra.Command{
+		Use:   custom.Name,
+		Short: custom.Short,
+		Long:  long,
+	}
+
+	subcommands := c.filterSubcommands(
+		func(p plugin.Plugin) bool {
+			return customSubcommandOf(p, custom.Parent, custom.Name) != nil
+		},
+		func(p plugin.Plugin) plugin.Subcommand {
+			return customSubcommandOf(p, custom.Parent, custom.Name).Subcommand
+		},
+	)
+
+	// The parent is the name of the command under which the subcommand is added, if any
+	path := strings.TrimSpace(fmt.Sprintf("%s %s", custom.Parent, custom.Name))
+	c.applySubcommandHooks(cmd, subcommands, fmt.Sprintf("failed to run %q", path), false, true)
+
+	return cmd
+}
+
+// customSubcommandOf returns the subcommand with the parent and name provided by the plugin, or nil if there is none
+func customSubcommandOf(p plugin.Plugin, parent plugin.CommandParent, name string) *plugin.CustomSubcommand {
+	withCustoms, hasCustoms := p.(plugin.HasCustomSubcommands)
+	if !hasCustoms {
+		return nil
+	}
+	return findCustomSubcommand(withCustoms.GetCustomSubcommands(), parent, name)
+}
+
+// findCustomSubcommand returns the first subcommand with the parent and name, or nil if there is none
+func findCustomSubcommand(
+	customs []plugin.CustomSubcommand,
+	parent plugin.CommandParent,
+	name string,
+) *plugin.CustomSubcommand {
+	for i := range customs {
+		if customs[i].Parent == parent && customs[i].Name == name {
+			return &customs[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	yamlstore "sigs.k8s.io/kubebuilder/v4/pkg/config/store/yaml"
+	cfgv3 "sigs.k8s.io/kubebuilder/v4/pkg/config/v3"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+)
+
+type mockCustomSubcommand struct {
+	hooks *[]string
+	path  string
+	cfg   config.Config
+	res   *resource.Resource
+	// next is printed to the injected output by the post-scaffold hook, like plugins do with their next steps
+	next string
+	out  io.Writer
+}
+
+func (s *mockCustomSubcommand) InjectConfig(cfg config.Config) error {
+	s.cfg = cfg
+	*s.hooks = append(*s.hooks, "config")
+	return nil
+}
+
+func (s *mockCustomSubcommand) InjectOutput(out io.Writer) error {
+	s.out = out
+	return nil
+}
+
+func (s *mockCustomSubcommand) PreScaffold(machinery.Filesystem) error {
+	*s.hooks = append(*s.hooks, "pre-scaffold")
+	return nil
+}
+
+func (s *mockCustomSubcommand) Scaffold(fs machinery.Filesystem) error {
+	*s.hooks = append(*s.hooks, "scaffold")
+	return afero.WriteFile(fs.FS, s.path, []byte("scaffolded\n"), 0o600)
+}
+
+func (s *mockCustomSubcommand) PostScaffold() error {
+	*s.hooks = append(*s.hooks, "post-scaffold")
+	_, _ = fmt.Fprint(s.out, s.next)
+	return nil
+}
+
+type mockCustomResourceSubcommand struct {
+	mockCustomSubcommand
+}
+
+func (s *mockCustomResourceSubcommand) InjectResource(res *resource.Resource) error {
+	s.res = res
+	*s.hooks = append(*s.hooks, "resource")
+	return nil
+}
+
+type mockCustomPlugin struct {
+	mockPlugin
+	customs []plugin.CustomSubcommand
+}
+
+func (p mockCustomPlugin) GetCustomSubcommands() []plugin.CustomSubcommand { return p.customs }
+
+var _ = Describe("Custom subcommands", func() {
+	var (
+		c          *CLI
+		hooks      []string
+		controller *mockCustomResourceSubcommand
+		job        *mockCustomSubcommand
+	)
+
+	findCmd := func(args ...string) *cobra.Command {
+		cmd, _, err := c.cmd.Find(args)
+		Expect(err).NotTo(HaveOccurred())
+		return cmd
+	}
+
+	BeforeEach(func() {
+		hooks = nil
+		controller = &mockCustomResourceSubcommand{mockCustomSubcommand{hooks: &hooks, path: "controller.go"}}
+		job = &mockCustomSubcommand{hooks: &hooks, path: "job.go"}
+
+		c = &CLI{
+			commandName:    "kubebuilder",
+			fs:             machinery.Filesystem{FS: afero.NewMemMapFs()},
+			projectVersion: cfgv3.Version,
+			resolvedPlugins: []plugin.Plugin{mockCustomPlugin{
+				mockPlugin: newMockPlugin("custom.kubebuilder.io", "v1", cfgv3.Version).(mockPlugin),
+				customs: []plugin.CustomSubcommand{
+					{Parent: plugin.CreateCommand, Name: "controller", Short: "Scaffold a controller", Subcommand: controller},
+					{Parent: plugin.RootCommand, Name: "job", Short: "Scaffold a job", Subcommand: job},
+					{Parent: plugin.CreateCommand, Name: "api", Short: "Scaffold an API", Subcommand: job},
+				},
+			}},
+		}
+		c.cmd = c.newRootCmd()
+		c.addSubcommands()
+
+		store := yamlstore.New(c.fs)
+		Expect(store.New(cfgv3.Version)).To(Succeed())
+		Expect(store.Config().SetDomain("example.com")).To(Succeed())
+		Expect(store.Save()).To(Succeed())
+	})
+
+	It("should add the subcommands under their parent command", func() {
+		Expect(findCmd("create", "controller").Short).To(Equal("Scaffold a controller"))
+		Expect(findCmd("create", "controller").Long).To(Equal("Scaffold a controller"))
+		Expect(findCmd("job").Short).To(Equal("Scaffold a job"))
+	})
+
+	It("should not replace existing subcommands", func() {
+		Expect(findCmd("create", "api").Short).To(Equal("Scaffold a Kubernetes API"))
+	})
+
+	It("should bind the resource flags only to subcommands that require a resource", func() {
+		Expect(findCmd("create", "controller").Flags().Lookup("kind")).NotTo(BeNil())
+		Expect(findCmd("job").Flags().Lookup("kind")).To(BeNil())
+	})
+
+	It("should run the subcommands through the execution hooks", func() {
+		out := &bytes.Buffer{}
+		c.cmd.SetOut(out)
+		c.cmd.SetArgs([]string{"create", "controller", "--group", "crew", "--version", "v1", "--kind", "Captain"})
+		Expect(c.cmd.Execute()).To(Succeed())
+
+		Expect(hooks).To(Equal([]string{"config", "resource", "pre-scaffold", "scaffold", "post-scaffold"}))
+		Expect(controller.cfg.GetDomain()).To(Equal("example.com"))
+		Expect(controller.res.Kind).To(Equal("Captain"))
+		Expect(controller.res.Domain).To(Equal("example.com"))
+		Expect(afero.ReadFile(c.fs.FS, "controller.go")).To(Equal([]byte("scaffolded\n")))
+	})
+
+	It("should only write the JSON report to stdout", func() {
+		job.next = "Next: run the job with:\n$ make run\n"
+
+		out, errOut := &bytes.Buffer{}, &bytes.Buffer{}
+		c.cmd.SetOut(out)
+		c.cmd.SetErr(errOut)
+		c.cmd.SetArgs([]string{"job", "--output", "json"})
+		Expect(c.cmd.Execute()).To(Succeed())
+
+		var report machinery.ScaffoldReport
+		Expect(json.Unmarshal(out.Bytes(), &report)).To(Succeed())
+		Expect(hooks).To(ContainElement("post-scaffold"))
+		Expect(errOut.String()).To(Equal(job.next))
+	})
+
+	It("should require an initialized project", func() {
+		Expect(c.fs.FS.Remove("PROJECT")).To(Succeed())
+
+		c.cmd.SetOut(&bytes.Buffer{})
+		c.cmd.SetErr(&bytes.Buffer{})
+		c.cmd.SetArgs([]string{"job"})
+		err := c.cmd.Execute()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(HavePrefix(`failed to run "job": `))
+		Expect(hooks).To(BeEmpty())
+	})
+})
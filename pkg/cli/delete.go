@@ -0,0 +1,143 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+)
+
+const (
+	deleteAPIErrorMsg     = "failed to delete API"
+	deleteWebhookErrorMsg = "failed to delete webhook"
+)
+
+func (CLI) newDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete",
+		Short: "Remove a Kubernetes API or webhook",
+		Long:  `Remove a Kubernetes API or webhook, reverting what was scaffolded when creating it.`,
+	}
+}
+
+// canDelete checks if any of the resolved plugins implements plugin.DeleteAPI or plugin.DeleteWebhook
+func (c *CLI) canDelete() bool {
+	apis := c.filterSubcommands(
+		func(p plugin.Plugin) bool {
+			_, isValid := p.(plugin.DeleteAPI)
+			return isValid
+		},
+		func(p plugin.Plugin) plugin.Subcommand {
+			return p.(plugin.DeleteAPI).GetDeleteAPISubcommand()
+		},
+	)
+	webhooks := c.filterSubcommands(
+		func(p plugin.Plugin) bool {
+			_, isValid := p.(plugin.DeleteWebhook)
+			return isValid
+		},
+		func(p plugin.Plugin) plugin.Subcommand {
+			return p.(plugin.DeleteWebhook).GetDeleteWebhookSubcommand()
+		},
+	)
+	return len(apis) != 0 || len(webhooks) != 0
+}
+
+func (c CLI) newDeleteAPICmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "api",
+		Short: "Remove a Kubernetes API",
+		Long: `Remove a Kubernetes API, reverting what was scaffolded when creating it.
+`,
+		RunE: errCmdFunc(
+			fmt.Errorf("api subcommand requires an existing project"),
+		),
+	}
+
+	// In case no plugin was resolved, instead of failing the construction of the CLI, fail the execution of
+	// this subcommand. This allows the use of subcommands that do not require resolved plugins like help.
+	if len(c.resolvedPlugins) == 0 {
+		cmdErr(cmd, noResolvedPluginError{})
+		return cmd
+	}
+
+	// Obtain the plugin keys and subcommands from the plugins that implement plugin.DeleteAPI.
+	subcommands := c.filterSubcommands(
+		func(p plugin.Plugin) bool {
+			_, isValid := p.(plugin.DeleteAPI)
+			return isValid
+		},
+		func(p plugin.Plugin) plugin.Subcommand {
+			return p.(plugin.DeleteAPI).GetDeleteAPISubcommand()
+		},
+	)
+
+	// Verify that there is at least one remaining plugin.
+	if len(subcommands) == 0 {
+		cmdErr(cmd, noAvailablePluginError{"API deletion"})
+		return cmd
+	}
+
+	// Deleting only removes code, so the markers are not required
+	c.applySubcommandHooks(cmd, subcommands, deleteAPIErrorMsg, false, false)
+
+	return cmd
+}
+
+func (c CLI) newDeleteWebhookCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "webhook",
+		Short: "Remove the webhooks of an API resource",
+		Long: `Remove the webhooks of an API resource, reverting what was scaffolded when creating them.
+`,
+		RunE: errCmdFunc(
+			fmt.Errorf("webhook subcommand requires an existing project"),
+		),
+	}
+
+	// In case no plugin was resolved, instead of failing the construction of the CLI, fail the execution of
+	// this subcommand. This allows the use of subcommands that do not require resolved plugins like help.
+	if len(c.resolvedPlugins) == 0 {
+		cmdErr(cmd, noResolvedPluginError{})
+		return cmd
+	}
+
+	// Obtain the plugin keys and subcommands from the plugins that implement plugin.DeleteWebhook.
+	subcommands := c.filterSubcommands(
+		func(p plugin.Plugin) bool {
+			_, isValid := p.(plugin.DeleteWebhook)
+			return isValid
+		},
+		func(p plugin.Plugin) plugin.Subcommand {
+			return p.(plugin.DeleteWebhook).GetDeleteWebhookSubcommand()
+		},
+	)
+
+	// Verify that there is at least one remaining plugin.
+	if len(subcommands) == 0 {
+		cmdErr(cmd, noAvailablePluginError{"webhook deletion"})
+		return cmd
+	}
+
+	// Deleting only removes code, so the markers are not required
+	c.applySubcommandHooks(cmd, subcommands, deleteWebhookErrorMsg, false, false)
+
+	return cmd
+}
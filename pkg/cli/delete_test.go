@@ -0,0 +1,125 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	yamlstore "sigs.k8s.io/kubebuilder/v4/pkg/config/store/yaml"
+	cfgv3 "sigs.k8s.io/kubebuilder/v4/pkg/config/v3"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+)
+
+type mockDeletePlugin struct {
+	mockPlugin
+	api     plugin.DeleteAPISubcommand
+	webhook plugin.DeleteWebhookSubcommand
+}
+
+func (p mockDeletePlugin) GetDeleteAPISubcommand() plugin.DeleteAPISubcommand { return p.api }
+func (p mockDeletePlugin) GetDeleteWebhookSubcommand() plugin.DeleteWebhookSubcommand {
+	return p.webhook
+}
+
+var _ = Describe("Delete subcommands", func() {
+	var (
+		c       *CLI
+		hooks   []string
+		api     *mockCustomResourceSubcommand
+		webhook *mockCustomResourceSubcommand
+	)
+
+	findCmd := func(args ...string) *cobra.Command {
+		cmd, _, err := c.cmd.Find(args)
+		Expect(err).NotTo(HaveOccurred())
+		return cmd
+	}
+
+	newCLI := func(plugins ...plugin.Plugin) {
+		c = &CLI{
+			commandName:     "kubebuilder",
+			fs:              machinery.Filesystem{FS: afero.NewMemMapFs()},
+			projectVersion:  cfgv3.Version,
+			resolvedPlugins: plugins,
+		}
+		c.cmd = c.newRootCmd()
+		c.addSubcommands()
+
+		store := yamlstore.New(c.fs)
+		Expect(store.New(cfgv3.Version)).To(Succeed())
+		Expect(store.Config().SetDomain("example.com")).To(Succeed())
+		Expect(store.Save()).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		hooks = nil
+		api = &mockCustomResourceSubcommand{mockCustomSubcommand{hooks: &hooks, path: "api.go"}}
+		webhook = &mockCustomResourceSubcommand{mockCustomSubcommand{hooks: &hooks, path: "webhook.go"}}
+
+		newCLI(mockDeletePlugin{
+			mockPlugin: newMockPlugin("delete.kubebuilder.io", "v1", cfgv3.Version).(mockPlugin),
+			api:        api,
+			webhook:    webhook,
+		})
+	})
+
+	It("should bind the resource flags", func() {
+		Expect(findCmd("delete", "api").Flags().Lookup("kind")).NotTo(BeNil())
+		Expect(findCmd("delete", "webhook").Flags().Lookup("kind")).NotTo(BeNil())
+	})
+
+	DescribeTable("should run the subcommands through the execution hooks",
+		func(subcommand string) {
+			c.cmd.SetOut(&bytes.Buffer{})
+			c.cmd.SetArgs([]string{"delete", subcommand, "--group", "crew", "--version", "v1", "--kind", "Captain"})
+			Expect(c.cmd.Execute()).To(Succeed())
+
+			Expect(hooks).To(Equal([]string{"config", "resource", "pre-scaffold", "scaffold", "post-scaffold"}))
+			Expect(afero.Exists(c.fs.FS, subcommand+".go")).To(BeTrue())
+		},
+		Entry("for delete api", "api"),
+		Entry("for delete webhook", "webhook"),
+	)
+
+	It("should fail if no plugin provides the subcommand", func() {
+		newCLI(mockDeletePlugin{
+			mockPlugin: newMockPlugin("delete.kubebuilder.io", "v1", cfgv3.Version).(mockPlugin),
+			webhook:    webhook,
+		})
+
+		c.cmd.SetOut(&bytes.Buffer{})
+		c.cmd.SetErr(&bytes.Buffer{})
+		c.cmd.SetArgs([]string{"delete", "api"})
+		err := c.cmd.Execute()
+		Expect(err).To(HaveOccurred())
+		Expect(err).To(MatchError(noAvailablePluginError{"API deletion"}))
+	})
+
+	It("should not be available if no plugin provides them", func() {
+		newCLI(newMockPlugin("other.kubebuilder.io", "v1", cfgv3.Version))
+
+		for _, cmd := range c.cmd.Commands() {
+			Expect(cmd.Name()).NotTo(Equal("delete"))
+		}
+	})
+})
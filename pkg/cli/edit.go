@@ -61,7 +61,7 @@ func (c CLI) newEditCmd() *cobra.Command {
 		return cmd
 	}
 
-	c.applySubcommandHooks(cmd, subcommands, editErrorMsg, false)
+	c.applySubcommandHooks(cmd, subcommands, editErrorMsg, false, false)
 
 	return cmd
 }
@@ -70,7 +70,7 @@ For further help about a specific plugin, set --plugins.
 		return cmd
 	}
 
-	c.applySubcommandHooks(cmd, subcommands, initErrorMsg, true)
+	c.applySubcommandHooks(cmd, subcommands, initErrorMsg, true, false)
 
 	return cmd
 }
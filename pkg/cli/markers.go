@@ -0,0 +1,136 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	yamlstore "sigs.k8s.io/kubebuilder/v4/pkg/config/store/yaml"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+)
+
+const verifyMarkersErrorMsg = "failed to verify markers"
+
+func (c *CLI) newMarkersCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "markers",
+		Short: "Manage the scaffold markers of the project",
+	}
+	cmd.AddCommand(c.newVerifyMarkersCmd())
+	return cmd
+}
+
+func (c *CLI) newVerifyMarkersCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "verify",
+		Short: "Verify the scaffold markers required by the resolved plugins",
+		Long: `Verify the scaffold markers required by the resolved plugins.
+
+Plugins insert code at the scaffold markers of existing files, e.g. "// +kubebuilder:scaffold:imports" in
+cmd/main.go, when creating APIs and webhooks. Every required marker has to be found exactly once, so missing,
+duplicated and malformed markers are reported with their file and line. Files that don't exist are not checked.
+`,
+		Example: fmt.Sprintf(`  # Verify the scaffold markers of the project
+  %[1]s alpha markers verify`, c.commandName),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			output, _ := cmd.Flags().GetString(outputFlag)
+			if err := c.verifyMarkers(cmd.OutOrStdout(), output); err != nil {
+				return fmt.Errorf("%s: %w", verifyMarkersErrorMsg, err)
+			}
+			return nil
+		},
+	}
+}
+
+// verifyMarkers writes the issues found with the markers required by the resolved plugins to w
+func (c *CLI) verifyMarkers(w io.Writer, output string) error {
+	if output != textOutput && output != jsonOutput {
+		return fmt.Errorf("invalid output format %q, expected one of: %s, %s", output, textOutput, jsonOutput)
+	}
+	if len(c.resolvedPlugins) == 0 {
+		return noResolvedPluginError{}
+	}
+
+	store := yamlstore.New(c.fs)
+	if err := store.Load(); err != nil {
+		return fmt.Errorf("unable to load configuration file: %w", err)
+	}
+
+	plugins := make([]plugin.Plugin, 0, len(c.resolvedPlugins))
+	for _, p := range c.resolvedPlugins {
+		if bundle, isBundle := p.(plugin.Bundle); isBundle {
+			plugins = append(plugins, bundle.Plugins()...)
+		} else {
+			plugins = append(plugins, p)
+		}
+	}
+
+	markers, err := requiredMarkers(plugins, store.Config())
+	if err != nil {
+		return err
+	}
+	issues, err := machinery.VerifyMarkers(c.fs, markers...)
+	if err != nil {
+		return err
+	}
+
+	if output == jsonOutput {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(struct {
+			Issues []machinery.MarkerIssue `json:"issues"`
+		}{issues}); err != nil {
+			return err
+		}
+	} else {
+		for _, issue := range issues {
+			if _, err := fmt.Fprintln(w, issue); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(issues) != 0 {
+		return fmt.Errorf("found %d issue(s) with the scaffold markers", len(issues))
+	}
+	return nil
+}
+
+// requiredMarkers returns the markers required by the plugins to update the files of the project
+func requiredMarkers(plugins []plugin.Plugin, cfg config.Config) ([]machinery.RequiredMarker, error) {
+	var markers []machinery.RequiredMarker
+	for _, p := range plugins {
+		withMarkers, hasMarkers := p.(plugin.HasRequiredMarkers)
+		if !hasMarkers {
+			continue
+		}
+
+		pluginMarkers, err := withMarkers.GetRequiredMarkers(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get the markers required by %q: %w", plugin.KeyFor(p), err)
+		}
+		markers = append(markers, pluginMarkers...)
+	}
+	return markers, nil
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+
+	yamlstore "sigs.k8s.io/kubebuilder/v4/pkg/config/store/yaml"
+	cfgv3 "sigs.k8s.io/kubebuilder/v4/pkg/config/v3"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+	goPluginV4 "sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4"
+)
+
+var _ = Describe("Markers", func() {
+	var (
+		c        *CLI
+		mainPath = filepath.Join("cmd", "main.go")
+	)
+
+	BeforeEach(func() {
+		c = &CLI{
+			fs: machinery.Filesystem{FS: afero.NewMemMapFs()},
+			resolvedPlugins: []plugin.Plugin{
+				goPluginV4.Plugin{},
+				newMockPlugin("mock.kubebuilder.io", "v1", cfgv3.Version),
+			},
+		}
+
+		store := yamlstore.New(c.fs)
+		Expect(store.New(cfgv3.Version)).To(Succeed())
+		Expect(store.Save()).To(Succeed())
+	})
+
+	It("should not report any issue for well-formed markers", func() {
+		Expect(afero.WriteFile(c.fs.FS, mainPath, []byte(`package main
+// +kubebuilder:scaffold:imports
+// +kubebuilder:scaffold:scheme
+// +kubebuilder:scaffold:builder
+`), 0o600)).To(Succeed())
+
+		out := &bytes.Buffer{}
+		Expect(c.verifyMarkers(out, textOutput)).To(Succeed())
+		Expect(out.String()).To(BeEmpty())
+	})
+
+	It("should report the issues found with the required markers", func() {
+		Expect(afero.WriteFile(c.fs.FS, mainPath, []byte(`package main
+// +kubebuilder:scaffold:imports
+// +kubebuilder:scaffold:imports
+// +kubebuilder:scaffold:builder
+`), 0o600)).To(Succeed())
+
+		out := &bytes.Buffer{}
+		Expect(c.verifyMarkers(out, textOutput)).NotTo(Succeed())
+		Expect(out.String()).To(Equal(mainPath + `: missing marker "// +kubebuilder:scaffold:scheme"
+` + mainPath + `:3: duplicated marker "// +kubebuilder:scaffold:imports"
+`))
+
+		out.Reset()
+		Expect(c.verifyMarkers(out, jsonOutput)).NotTo(Succeed())
+		var report struct {
+			Issues []machinery.MarkerIssue `json:"issues"`
+		}
+		Expect(json.Unmarshal(out.Bytes(), &report)).To(Succeed())
+		Expect(report.Issues).To(HaveLen(2))
+	})
+
+	It("should fail for invalid output formats", func() {
+		Expect(c.verifyMarkers(&bytes.Buffer{}, "xml")).NotTo(Succeed())
+	})
+
+	It("should fail without resolved plugins", func() {
+		c.resolvedPlugins = nil
+		Expect(c.verifyMarkers(&bytes.Buffer{}, textOutput)).To(MatchError(noResolvedPluginError{}))
+	})
+})
@@ -0,0 +1,193 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	yamlstore "sigs.k8s.io/kubebuilder/v4/pkg/config/store/yaml"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+)
+
+const migrateErrorMsg = "failed to migrate"
+
+func (c *CLI) newMigrateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrate the project to new versions of its plugins",
+		Long: `Migrate the project to new versions of its plugins.
+
+Every plugin set with --plugins replaces the plugin of the project with the same name, which is looked up in
+the layout and then in the plugin configurations of the PROJECT file, as plugins like deploy-image are only
+found there. Plugins have to implement migrations from the version used by the project, and they rewrite the
+files and the configuration stored in the PROJECT file by the previous version. The layout of the PROJECT file
+is updated once every plugin was migrated, and no file is written if any of them fails.
+`,
+		Example: fmt.Sprintf(`  # Migrate a project to a new version of the deploy-image plugin
+  %[1]s alpha migrate --plugins deploy-image.go.kubebuilder.io/v1-beta
+
+  # Report the changes instead of writing them
+  %[1]s alpha migrate --plugins deploy-image.go.kubebuilder.io/v1-beta --dry-run`, c.commandName),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			dryRun, _ := cmd.Flags().GetBool(dryRunFlag)
+			if err := c.migrate(cmd.OutOrStdout(), dryRun); err != nil {
+				return fmt.Errorf("%s: %w", migrateErrorMsg, err)
+			}
+			return nil
+		},
+	}
+}
+
+// migrate replaces the plugins of the project layout by the resolved plugins with the same name, running their
+// migrations. In dry-run mode, the diff of the changes is written to w instead of writing them.
+func (c *CLI) migrate(w io.Writer, dryRun bool) error {
+	if len(c.resolvedPlugins) == 0 {
+		return noResolvedPluginError{}
+	}
+
+	// Stage every change so that a failure in any migration leaves the project unchanged
+	overlay := machinery.NewOverlay(c.fs)
+	store := yamlstore.New(overlay.Filesystem())
+	if err := store.Load(); errors.Is(err, os.ErrNotExist) {
+		return errors.New("unable to find configuration file, project must be initialized")
+	} else if err != nil {
+		return fmt.Errorf("unable to load configuration file: %w", err)
+	}
+	cfg := store.Config()
+
+	pluginChain := append([]string(nil), cfg.GetPluginChain()...)
+	configKeys, err := pluginConfigKeys(cfg)
+	if err != nil {
+		return err
+	}
+	var migrated bool
+	for _, p := range c.resolvedPlugins {
+		key := plugin.KeyFor(p)
+		oldKey, from, err := previousVersion(slices.Concat(pluginChain, configKeys), p)
+		if err != nil {
+			return err
+		}
+
+		switch cmp := from.Compare(p.Version()); {
+		case cmp == 0:
+			continue
+		case cmp > 0:
+			return fmt.Errorf("unable to migrate %q to the previous version %q", oldKey, key)
+		}
+
+		migrator, isMigrator := p.(plugin.Migrator)
+		if !isMigrator {
+			return fmt.Errorf("plugin %q does not support migrations", key)
+		}
+
+		if err := movePluginConfig(cfg, oldKey, key); err != nil {
+			return err
+		}
+		if err := migrator.Migrate(from, overlay.Filesystem(), cfg); err != nil {
+			return fmt.Errorf("unable to migrate %q to %q: %w", oldKey, key, err)
+		}
+
+		// Plugins that are not part of the layout, such as deploy-image, are only found in the plugin configurations
+		if i := slices.Index(pluginChain, oldKey); i != -1 {
+			pluginChain[i] = key
+		}
+		migrated = true
+	}
+	if !migrated {
+		return errors.New("the project already uses the provided plugins, set --plugins with their new versions")
+	}
+
+	if err := cfg.SetPluginChain(pluginChain); err != nil {
+		return fmt.Errorf("unable to update the plugin chain: %w", err)
+	}
+	if err := store.Save(); err != nil {
+		return fmt.Errorf("unable to save configuration file: %w", err)
+	}
+
+	if dryRun {
+		return overlay.Diff(w)
+	}
+	return overlay.Commit()
+}
+
+// previousVersion returns the first of the plugin keys of the project with the same name as p and its version
+func previousVersion(keys []string, p plugin.Plugin) (string, plugin.Version, error) {
+	for _, key := range keys {
+		name, version := plugin.SplitKey(key)
+		if name != p.Name() {
+			continue
+		}
+
+		var from plugin.Version
+		if err := from.Parse(version); err != nil {
+			return "", plugin.Version{}, fmt.Errorf("invalid plugin key %q found in the project: %w", key, err)
+		}
+		return key, from, nil
+	}
+
+	return "", plugin.Version{}, fmt.Errorf("plugin %q is not a new version of any plugin of the project",
+		plugin.KeyFor(p))
+}
+
+// pluginConfigKeys returns the sorted keys of the plugin configurations stored in the PROJECT file
+func pluginConfigKeys(cfg config.Config) ([]string, error) {
+	content, err := cfg.MarshalYAML()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the plugin configurations: %w", err)
+	}
+	var stored struct {
+		Plugins map[string]interface{} `json:"plugins,omitempty"`
+	}
+	if err := yaml.Unmarshal(content, &stored); err != nil {
+		return nil, fmt.Errorf("unable to read the plugin configurations: %w", err)
+	}
+
+	keys := make([]string, 0, len(stored.Plugins))
+	for key := range stored.Plugins {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys, nil
+}
+
+// movePluginConfig stores the plugin configuration found under the old key under the new one
+func movePluginConfig(cfg config.Config, oldKey, newKey string) error {
+	var pluginConfig map[string]interface{}
+	if err := cfg.DecodePluginConfig(oldKey, &pluginConfig); errors.As(err, &config.PluginKeyNotFoundError{}) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("unable to read the configuration of %q: %w", oldKey, err)
+	}
+
+	if err := cfg.EncodePluginConfig(newKey, pluginConfig); err != nil {
+		return fmt.Errorf("unable to store the configuration of %q: %w", newKey, err)
+	}
+	if err := cfg.RemovePluginConfig(oldKey); err != nil {
+		return fmt.Errorf("unable to remove the configuration of %q: %w", oldKey, err)
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	yamlstore "sigs.k8s.io/kubebuilder/v4/pkg/config/store/yaml"
+	cfgv3 "sigs.k8s.io/kubebuilder/v4/pkg/config/v3"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+)
+
+type mockMigratorPlugin struct {
+	mockPlugin
+	from *plugin.Version
+	err  error
+}
+
+func (p mockMigratorPlugin) Migrate(from plugin.Version, fs machinery.Filesystem, cfg config.Config) error {
+	*p.from = from
+
+	var pluginConfig map[string]string
+	if err := cfg.DecodePluginConfig(plugin.KeyFor(p), &pluginConfig); err != nil {
+		return err
+	}
+	pluginConfig["image"] = "new"
+	if err := cfg.EncodePluginConfig(plugin.KeyFor(p), pluginConfig); err != nil {
+		return err
+	}
+
+	if err := afero.WriteFile(fs.FS, "migrated", []byte("migrated\n"), 0o600); err != nil {
+		return err
+	}
+	return p.err
+}
+
+var _ = Describe("Migrate", func() {
+	const (
+		oldKey = "migrator.kubebuilder.io/v1-alpha"
+		newKey = "migrator.kubebuilder.io/v1-beta"
+	)
+
+	var (
+		c    *CLI
+		from plugin.Version
+		p    mockMigratorPlugin
+	)
+
+	loadConfig := func() config.Config {
+		store := yamlstore.New(c.fs)
+		Expect(store.Load()).To(Succeed())
+		return store.Config()
+	}
+
+	BeforeEach(func() {
+		from = plugin.Version{}
+		p = mockMigratorPlugin{
+			mockPlugin: newMockPlugin("migrator.kubebuilder.io", "v1-beta", cfgv3.Version).(mockPlugin),
+			from:       &from,
+		}
+		c = &CLI{
+			fs:              machinery.Filesystem{FS: afero.NewMemMapFs()},
+			resolvedPlugins: []plugin.Plugin{p},
+		}
+
+		// Like deploy-image, the plugin is only found in the plugin configurations and not in the layout
+		store := yamlstore.New(c.fs)
+		Expect(store.New(cfgv3.Version)).To(Succeed())
+		Expect(store.Config().SetPluginChain([]string{"go.kubebuilder.io/v4"})).To(Succeed())
+		Expect(store.Config().EncodePluginConfig(oldKey, map[string]string{"image": "old"})).To(Succeed())
+		Expect(store.Config().EncodePluginConfig("grafana.kubebuilder.io/v1-alpha", struct{}{})).To(Succeed())
+		Expect(store.Save()).To(Succeed())
+	})
+
+	expectMigratedConfig := func(cfg config.Config) {
+		var pluginConfig map[string]string
+		Expect(cfg.DecodePluginConfig(newKey, &pluginConfig)).To(Succeed())
+		Expect(pluginConfig).To(Equal(map[string]string{"image": "new"}))
+		err := cfg.DecodePluginConfig(oldKey, &pluginConfig)
+		Expect(errors.As(err, &config.PluginKeyNotFoundError{})).To(BeTrue())
+	}
+
+	It("should migrate the plugins found in the plugin configurations", func() {
+		Expect(c.migrate(&bytes.Buffer{}, false)).To(Succeed())
+		Expect(from.String()).To(Equal("v1-alpha"))
+		Expect(afero.Exists(c.fs.FS, "migrated")).To(BeTrue())
+
+		cfg := loadConfig()
+		Expect(cfg.GetPluginChain()).To(Equal([]string{"go.kubebuilder.io/v4"}))
+		expectMigratedConfig(cfg)
+	})
+
+	It("should migrate the plugins of the layout and update the plugin chain", func() {
+		store := yamlstore.New(c.fs)
+		Expect(store.Load()).To(Succeed())
+		Expect(store.Config().SetPluginChain([]string{"go.kubebuilder.io/v4", oldKey})).To(Succeed())
+		Expect(store.Save()).To(Succeed())
+
+		Expect(c.migrate(&bytes.Buffer{}, false)).To(Succeed())
+		Expect(from.String()).To(Equal("v1-alpha"))
+
+		cfg := loadConfig()
+		Expect(cfg.GetPluginChain()).To(Equal([]string{"go.kubebuilder.io/v4", newKey}))
+		expectMigratedConfig(cfg)
+	})
+
+	It("should report the changes without writing them in dry-run mode", func() {
+		out := &bytes.Buffer{}
+		Expect(c.migrate(out, true)).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("+++ b/migrated"))
+		Expect(out.String()).To(ContainSubstring("+  " + newKey))
+		Expect(afero.Exists(c.fs.FS, "migrated")).To(BeFalse())
+		Expect(loadConfig().DecodePluginConfig(oldKey, &map[string]string{})).To(Succeed())
+	})
+
+	It("should leave the project unchanged if the migration fails", func() {
+		p.err = errors.New("migration error")
+		c.resolvedPlugins = []plugin.Plugin{p}
+
+		Expect(c.migrate(&bytes.Buffer{}, false)).NotTo(Succeed())
+		Expect(afero.Exists(c.fs.FS, "migrated")).To(BeFalse())
+		Expect(loadConfig().DecodePluginConfig(oldKey, &map[string]string{})).To(Succeed())
+	})
+
+	It("should fail if the plugin does not support migrations", func() {
+		c.resolvedPlugins = []plugin.Plugin{p.mockPlugin}
+		Expect(c.migrate(&bytes.Buffer{}, false)).To(MatchError(ContainSubstring("does not support migrations")))
+	})
+
+	It("should fail if the project already uses the provided plugins", func() {
+		c.resolvedPlugins = []plugin.Plugin{newMockPlugin("migrator.kubebuilder.io", "v1-alpha", cfgv3.Version)}
+		Expect(c.migrate(&bytes.Buffer{}, false)).NotTo(Succeed())
+	})
+
+	It("should fail to migrate to a previous version", func() {
+		c.resolvedPlugins = []plugin.Plugin{mockMigratorPlugin{
+			mockPlugin: newMockPlugin("migrator.kubebuilder.io", "v1-alpha", cfgv3.Version).(mockPlugin),
+			from:       &from,
+		}}
+		store := yamlstore.New(c.fs)
+		Expect(store.Load()).To(Succeed())
+		Expect(store.Config().RemovePluginConfig(oldKey)).To(Succeed())
+		Expect(store.Config().EncodePluginConfig(newKey, map[string]string{"image": "new"})).To(Succeed())
+		Expect(store.Save()).To(Succeed())
+
+		Expect(c.migrate(&bytes.Buffer{}, false)).To(MatchError(ContainSubstring("previous version")))
+	})
+
+	It("should fail for plugins that are not in the project", func() {
+		c.resolvedPlugins = []plugin.Plugin{newMockPlugin("other.kubebuilder.io", "v1", cfgv3.Version)}
+		Expect(c.migrate(&bytes.Buffer{}, false)).To(MatchError(ContainSubstring("not a new version")))
+	})
+
+	It("should fail without resolved plugins", func() {
+		c.resolvedPlugins = nil
+		Expect(c.migrate(&bytes.Buffer{}, false)).To(MatchError(noResolvedPluginError{}))
+	})
+})
@@ -33,6 +33,7 @@ import (
 	cfgv3 "sigs.k8s.io/kubebuilder/v4/pkg/config/v3"
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+	externalplugin "sigs.k8s.io/kubebuilder/v4/pkg/plugin/external"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/external"
 )
 
@@ -282,12 +283,18 @@ func DiscoverExternalPlugins(fs afero.Fs) (ps []plugin.Plugin, err error) {
 				continue
 			}
 
-			pluginFiles, err := afero.ReadDir(fs, filepath.Join(pluginsRoot, pluginInfo.Name(), version.Name()))
+			versionDir := filepath.Join(pluginsRoot, pluginInfo.Name(), version.Name())
+			pluginFiles, err := afero.ReadDir(fs, versionDir)
 			if err != nil {
 				return nil, err
 			}
 
 			for _, pluginFile := range pluginFiles {
+				// the manifest is not an executable, even if the plugin is named after it.
+				if pluginFile.Name() == externalplugin.ManifestFileName {
+					continue
+				}
+
 				// find the executable that matches the same name as info.Name().
 				// if no match is found, compare the external plugin string name before dot
 				// and match it with info.Name() which is the external plugin root dir.
@@ -305,7 +312,7 @@ func DiscoverExternalPlugins(fs afero.Fs) (ps []plugin.Plugin, err error) {
 
 					ep := external.Plugin{
 						PName:                     pluginInfo.Name(),
-						Path:                      filepath.Join(pluginsRoot, pluginInfo.Name(), version.Name(), pluginFile.Name()),
+						Path:                      filepath.Join(versionDir, pluginFile.Name()),
 						PSupportedProjectVersions: []config.Version{cfgv3.Version},
 						Args:                      parseExternalPluginArgs(),
 					}
@@ -314,6 +321,20 @@ func DiscoverExternalPlugins(fs afero.Fs) (ps []plugin.Plugin, err error) {
 						return nil, err
 					}
 
+					// apply the manifest found next to the executable, if any.
+					manifestPath := filepath.Join(versionDir, externalplugin.ManifestFileName)
+					if exists, err := afero.Exists(fs, manifestPath); err != nil {
+						return nil, err
+					} else if exists {
+						m, err := external.ReadManifest(fs, manifestPath)
+						if err != nil {
+							return nil, err
+						}
+						if err := ep.ApplyManifest(m); err != nil {
+							return nil, fmt.Errorf("invalid manifest of external plugin %q: %w", ep.Name(), err)
+						}
+					}
+
 					logrus.Printf("Adding external plugin: %s", ep.Name())
 
 					ps = append(ps, ep)
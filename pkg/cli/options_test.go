@@ -32,6 +32,7 @@ import (
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
 	"sigs.k8s.io/kubebuilder/v4/pkg/model/stage"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/external"
 )
 
 var _ = Describe("Discover external plugins", func() {
@@ -300,6 +301,47 @@ var _ = Describe("Discover external plugins", func() {
 			Expect(ps[1].Name()).To(Equal("myotherexternalPlugin"))
 		})
 
+		It("should apply the manifest found next to the external plugin executable", func() {
+			err = fs.FS.Chmod(pluginFilePath, filePermissions)
+			Expect(err).To(Not(HaveOccurred()))
+
+			manifest := `name: externalPlugin
+version: v1
+supportedProjectVersions: ["3"]
+subcommands:
+  init:
+    description: Initialize a project
+requires: ["go.kubebuilder.io/>=v4"]
+deprecation: use v2 instead
+session: true
+`
+			err = afero.WriteFile(fs.FS, filepath.Join(filepath.Dir(pluginFilePath), "plugin.yaml"),
+				[]byte(manifest), 0o644)
+			Expect(err).ToNot(HaveOccurred())
+
+			ps, err := DiscoverExternalPlugins(fs.FS)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ps).To(HaveLen(1))
+			Expect(ps[0].Name()).To(Equal("externalPlugin"))
+			Expect(ps[0].(plugin.Deprecated).DeprecationWarning()).To(Equal("use v2 instead"))
+			Expect(ps[0].(plugin.HasDependencies).RequiredPlugins()).To(HaveLen(1))
+			Expect(ps[0].(plugin.Init).GetInitSubcommand()).NotTo(BeNil())
+			Expect(ps[0].(plugin.CreateAPI).GetCreateAPISubcommand()).To(BeNil())
+			Expect(ps[0].(external.Plugin).Session).To(BeTrue())
+		})
+
+		It("should error if the manifest found next to the external plugin executable is invalid", func() {
+			err = fs.FS.Chmod(pluginFilePath, filePermissions)
+			Expect(err).To(Not(HaveOccurred()))
+
+			err = afero.WriteFile(fs.FS, filepath.Join(filepath.Dir(pluginFilePath), "plugin.yaml"),
+				[]byte("name: otherPlugin\n"), 0o644)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = DiscoverExternalPlugins(fs.FS)
+			Expect(err).To(MatchError(ContainSubstring("does not match the plugin name")))
+		})
+
 		Context("that are invalid", func() {
 			BeforeEach(func() {
 				fs = machinery.Filesystem{
@@ -45,6 +45,10 @@ func (c CLI) newRootCmd() *cobra.Command {
 
 	// Global flags for all subcommands.
 	cmd.PersistentFlags().StringSlice(pluginsFlag, nil, "plugin keys to be used for this subcommand execution")
+	cmd.PersistentFlags().Bool(dryRunFlag, false,
+		"print a unified diff of the changes instead of writing them, and skip post-scaffold tasks")
+	cmd.PersistentFlags().String(outputFlag, textOutput,
+		fmt.Sprintf("format of the report of the scaffolded files, one of: %s, %s", textOutput, jsonOutput))
 
 	// Register --project-version on the root command so that it shows up in help.
 	cmd.Flags().String(projectVersionFlag, c.defaultProjectVersion.String(), "project version")
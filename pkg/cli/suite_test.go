@@ -68,3 +68,13 @@ func newMockDeprecatedPlugin(name, version, deprecation string, projVers ...conf
 }
 
 func (p mockDeprecatedPlugin) DeprecationWarning() string { return p.deprecation }
+
+type mockDependentPlugin struct {
+	mockPlugin
+	required    []plugin.Constraint
+	conflicting []plugin.Constraint
+}
+
+func (p mockDependentPlugin) RequiredPlugins() []plugin.Constraint    { return p.required }
+func (p mockDependentPlugin) ConflictingPlugins() []plugin.Constraint { return p.conflicting }
+func (p mockDependentPlugin) RunsAfter() []plugin.Constraint          { return p.required }
@@ -0,0 +1,136 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	yamlstore "sigs.k8s.io/kubebuilder/v4/pkg/config/store/yaml"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+)
+
+const dumpTemplatesErrorMsg = "failed to dump templates"
+
+// templatesResource is the resource injected into dumped templates, so that the paths of the templates
+// scaffolded for every resource keep their placeholders
+var templatesResource = resource.Resource{
+	GVK: resource.GVK{
+		Group:   "%[group]",
+		Version: "%[version]",
+		Kind:    "%[kind]",
+	},
+	Plural:     "%[plural]",
+	API:        &resource.API{CRDVersion: "v1", Namespaced: true},
+	Controller: true,
+	Webhooks: &resource.Webhooks{
+		WebhookVersion: "v1",
+		Defaulting:     true,
+		Validation:     true,
+		Conversion:     true,
+	},
+}
+
+func (c *CLI) newDumpTemplatesCmd() *cobra.Command {
+	var (
+		outputDir string
+		force     bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "dump-templates",
+		Short: "Write the default templates of the resolved plugins to be overridden",
+		Long: fmt.Sprintf(`Write the default templates of the resolved plugins to be overridden.
+
+Templates are written at the path of the file they scaffold with a ".tmpl" suffix. Templates that are
+scaffolded for every resource use the "%%[group]", "%%[version]", "%%[kind]" and "%%[plural]" placeholders
+in their paths. Overrides are looked for in the %q directory of the project and then in the
+user-level %q directory.
+`, machinery.DefaultTemplateOverridesDir, machinery.UserTemplateOverridesDir()),
+		Example: fmt.Sprintf(`  # Write the default templates to the project-local overrides directory
+  %[1]s alpha dump-templates
+
+  # Overwrite previously dumped templates
+  %[1]s alpha dump-templates --force`, c.commandName),
+		Args: cobra.NoArgs,
+		RunE: func(*cobra.Command, []string) error {
+			if err := c.dumpTemplates(outputDir, force); err != nil {
+				return fmt.Errorf("%s: %w", dumpTemplatesErrorMsg, err)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&outputDir, "output-dir", machinery.DefaultTemplateOverridesDir,
+		"directory where the templates are written")
+	cmd.Flags().BoolVar(&force, "force", false, "overwrite existing templates")
+
+	return cmd
+}
+
+// dumpTemplates writes the templates of the resolved plugins to dir
+func (c *CLI) dumpTemplates(dir string, force bool) error {
+	if len(c.resolvedPlugins) == 0 {
+		return noResolvedPluginError{}
+	}
+
+	store := yamlstore.New(c.fs)
+	if err := store.Load(); errors.Is(err, os.ErrNotExist) {
+		if err := store.New(c.projectVersion); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+
+	res := templatesResource
+	res.Domain = store.Config().GetDomain()
+
+	scaffold := machinery.NewScaffold(c.fs,
+		machinery.WithConfig(store.Config()),
+		machinery.WithResource(&res),
+	)
+
+	var found bool
+	for _, p := range c.resolvedPlugins {
+		plugins := []plugin.Plugin{p}
+		if bundle, isBundle := p.(plugin.Bundle); isBundle {
+			plugins = bundle.Plugins()
+		}
+
+		for _, bundled := range plugins {
+			withTemplates, hasTemplates := bundled.(plugin.HasTemplates)
+			if !hasTemplates {
+				continue
+			}
+			found = true
+
+			if err := scaffold.DumpTemplates(dir, force, withTemplates.GetTemplates()...); err != nil {
+				return err
+			}
+		}
+	}
+	if !found {
+		return errors.New("resolved plugins do not provide any template")
+	}
+
+	return nil
+}
@@ -61,7 +61,7 @@ func (c CLI) newCreateWebhookCmd() *cobra.Command {
 		return cmd
 	}
 
-	c.applySubcommandHooks(cmd, subcommands, webhookErrorMsg, false)
+	c.applySubcommandHooks(cmd, subcommands, webhookErrorMsg, false, true)
 
 	return cmd
 }
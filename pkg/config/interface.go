@@ -62,6 +62,14 @@ type Config interface {
 	// ClearMultiGroup disables multi-group.
 	ClearMultiGroup() error
 
+	/* Formatters */
+
+	// GetFormatters returns the names of the formatters of the scaffolded files, by file extension or name.
+	GetFormatters() map[string]string
+	// SetFormatter sets the name of the formatter of the scaffolded files matching a file extension or name.
+	// An empty name removes the formatter, so that the default one is used.
+	SetFormatter(match, name string) error
+
 	/* Resources */
 
 	// ResourcesLength returns the number of tracked resources.
@@ -76,6 +84,10 @@ type Config interface {
 	AddResource(res resource.Resource) error
 	// UpdateResource adds the provided resource if it was not present, modifies it if it was already present.
 	UpdateResource(res resource.Resource) error
+	// SetResource adds the provided resource if it was not present, replaces it if it was already present.
+	SetResource(res resource.Resource) error
+	// RemoveResource removes the stored resource matching the provided GVK.
+	RemoveResource(gvk resource.GVK) error
 
 	// HasGroup checks if the provided group is the same as any of the tracked resources.
 	HasGroup(group string) bool
@@ -92,6 +104,9 @@ type Config interface {
 	// EncodePluginConfig encodes a config object into Config by overwriting the existing object stored under key.
 	// This method is intended to be used for custom configuration objects, which were introduced in project version 3.
 	EncodePluginConfig(key string, configObj interface{}) error
+	// RemovePluginConfig removes the plugin config stored in Config under key.
+	// An error is returned if no plugin config is stored under key.
+	RemovePluginConfig(key string) error
 
 	/* Persistence */
 
@@ -65,6 +65,9 @@ type Cfg struct {
 	// Boolean fields
 	MultiGroup bool `json:"multigroup,omitempty"`
 
+	// Formatters
+	Formatters map[string]string `json:"formatters,omitempty"`
+
 	// Resources
 	Resources []resource.Resource `json:"resources,omitempty"`
 
@@ -153,6 +156,25 @@ func (c *Cfg) ClearMultiGroup() error {
 	return nil
 }
 
+// GetFormatters implements config.Config
+func (c Cfg) GetFormatters() map[string]string {
+	return c.Formatters
+}
+
+// SetFormatter implements config.Config
+func (c *Cfg) SetFormatter(match, name string) error {
+	if name == "" {
+		delete(c.Formatters, match)
+		return nil
+	}
+
+	if c.Formatters == nil {
+		c.Formatters = make(map[string]string)
+	}
+	c.Formatters[match] = name
+	return nil
+}
+
 // ResourcesLength implements config.Config
 func (c Cfg) ResourcesLength() int {
 	return len(c.Resources)
@@ -242,6 +264,39 @@ func (c *Cfg) UpdateResource(res resource.Resource) error {
 	return nil
 }
 
+// SetResource implements config.Config
+func (c *Cfg) SetResource(res resource.Resource) error {
+	// As res is passed by value it is already a shallow copy, but we need to make a deep copy
+	res = res.Copy()
+
+	// Plural is only stored if irregular
+	if res.Plural == resource.RegularPlural(res.Kind) {
+		res.Plural = ""
+	}
+
+	for i, r := range c.Resources {
+		if res.GVK.IsEqualTo(r.GVK) {
+			c.Resources[i] = res
+			return nil
+		}
+	}
+
+	c.Resources = append(c.Resources, res)
+	return nil
+}
+
+// RemoveResource implements config.Config
+func (c *Cfg) RemoveResource(gvk resource.GVK) error {
+	for i, r := range c.Resources {
+		if gvk.IsEqualTo(r.GVK) {
+			c.Resources = append(c.Resources[:i], c.Resources[i+1:]...)
+			return nil
+		}
+	}
+
+	return config.ResourceNotFoundError{GVK: gvk}
+}
+
 // HasGroup implements config.Config
 func (c Cfg) HasGroup(group string) bool {
 	// Return true if the target group is found in the tracked resources
@@ -330,6 +385,16 @@ func (c *Cfg) EncodePluginConfig(key string, configObj interface{}) error {
 	return nil
 }
 
+// RemovePluginConfig implements config.Config
+func (c *Cfg) RemovePluginConfig(key string) error {
+	if _, hasKey := c.Plugins[key]; !hasKey {
+		return config.PluginKeyNotFoundError{Key: key}
+	}
+
+	delete(c.Plugins, key)
+	return nil
+}
+
 // Marshal implements config.Config
 func (c Cfg) MarshalYAML() ([]byte, error) {
 	for i, r := range c.Resources {
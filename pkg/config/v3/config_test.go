@@ -134,6 +134,24 @@ var _ = Describe("Cfg", func() {
 		})
 	})
 
+	Context("Formatters", func() {
+		It("GetFormatters should return the formatters", func() {
+			c.Formatters = map[string]string{".go": "gofumpt"}
+			Expect(c.GetFormatters()).To(Equal(map[string]string{".go": "gofumpt"}))
+		})
+
+		It("SetFormatter should set a formatter", func() {
+			Expect(c.SetFormatter(".go", "gofumpt")).To(Succeed())
+			Expect(c.Formatters).To(Equal(map[string]string{".go": "gofumpt"}))
+		})
+
+		It("SetFormatter should remove a formatter if no name is provided", func() {
+			c.Formatters = map[string]string{".go": "gofumpt", "Makefile": "none"}
+			Expect(c.SetFormatter(".go", "")).To(Succeed())
+			Expect(c.Formatters).To(Equal(map[string]string{"Makefile": "none"}))
+		})
+	})
+
 	Context("Resources", func() {
 		var (
 			res = resource.Resource{
@@ -270,6 +288,37 @@ var _ = Describe("Cfg", func() {
 			checkResource(c.Resources[0], resWithoutPlural)
 		})
 
+		It("SetResource should add the provided resource if non-existent", func() {
+			l := len(c.Resources)
+			Expect(c.SetResource(res)).To(Succeed())
+			Expect(c.Resources).To(HaveLen(l + 1))
+
+			checkResource(c.Resources[0], resWithoutPlural)
+		})
+
+		It("SetResource should replace it if the resource already exists", func() {
+			c.Resources = append(c.Resources, resWithoutPlural)
+			l := len(c.Resources)
+
+			r := resource.Resource{GVK: res.GVK, Path: "api/v1"}
+			Expect(c.SetResource(r)).To(Succeed())
+			Expect(c.Resources).To(HaveLen(l))
+
+			checkResource(c.Resources[0], r)
+		})
+
+		It("RemoveResource should remove the resource if it exists", func() {
+			c.Resources = append(c.Resources, resWithoutPlural)
+			Expect(c.RemoveResource(res.GVK)).To(Succeed())
+			Expect(c.Resources).To(BeEmpty())
+		})
+
+		It("RemoveResource should fail if the resource doesn't exist", func() {
+			err := c.RemoveResource(res.GVK)
+			Expect(err).To(HaveOccurred())
+			Expect(errors.As(err, &config.ResourceNotFoundError{})).To(BeTrue())
+		})
+
 		It("HasGroup should return false with no tracked resources", func() {
 			Expect(c.HasGroup(res.Group)).To(BeFalse())
 		})
@@ -428,6 +477,18 @@ var _ = Describe("Cfg", func() {
 			// TODO (coverage): add cases where yaml.Marshal returns an error
 			// TODO (coverage): add cases where yaml.Unmarshal returns an error
 		)
+
+		It("RemovePluginConfig should remove the plugin data", func() {
+			Expect(c.EncodePluginConfig(key, pluginConfig)).To(Succeed())
+			Expect(c.RemovePluginConfig(key)).To(Succeed())
+			Expect(c.Plugins).To(BeEmpty())
+		})
+
+		It("RemovePluginConfig should fail for a non-existent plugin", func() {
+			err := c.RemovePluginConfig(key)
+			Expect(err).To(HaveOccurred())
+			Expect(errors.As(err, &config.PluginKeyNotFoundError{})).To(BeTrue())
+		})
 	})
 
 	Context("Persistence", func() {
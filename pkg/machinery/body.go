@@ -0,0 +1,31 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinery
+
+import (
+	"io/fs"
+)
+
+// ReadTemplateBody reads the body of a template from a file system, usually an embed.FS with the ".tmpl"
+// files of a plugin, so that template bodies can be kept as real files instead of Go string literals
+func ReadTemplateBody(fsys fs.FS, name string) (string, error) {
+	body, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return "", ReadTemplateBodyError{name, err}
+	}
+	return string(body), nil
+}
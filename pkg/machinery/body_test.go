@@ -0,0 +1,43 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinery
+
+import (
+	"errors"
+	"io/fs"
+	"testing/fstest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ReadTemplateBody", func() {
+	fsys := fstest.MapFS{
+		"file.go.tmpl": &fstest.MapFile{Data: []byte("package {{ .Package }}\n")},
+	}
+
+	It("should read the body of a template", func() {
+		Expect(ReadTemplateBody(fsys, "file.go.tmpl")).To(Equal("package {{ .Package }}\n"))
+	})
+
+	It("should fail for missing templates", func() {
+		_, err := ReadTemplateBody(fsys, "missing.go.tmpl")
+		Expect(err).To(HaveOccurred())
+		Expect(errors.As(err, &ReadTemplateBodyError{})).To(BeTrue())
+		Expect(errors.Is(err, fs.ErrNotExist)).To(BeTrue())
+	})
+})
@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinery
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/spf13/afero"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// DefaultChecksumsPath is the default path of the file that records the checksums of the scaffolded files
+	DefaultChecksumsPath = "PROJECT.lock"
+	// DefaultBasesDir is the suggested directory to record the contents of the scaffolded files with WithBasesDir
+	DefaultBasesDir = ".kubebuilder/bases"
+
+	checksumAlgorithm = "sha256:"
+
+	// Comment for the checksums file
+	checksumsComment = `# Code generated by tool. DO NOT EDIT.
+# This file is used to track the content of the scaffolded files
+# so that the files modified by the user are not overwritten.
+`
+)
+
+// checksums records the content hash of every scaffolded file
+type checksums struct {
+	// Files binds the path of each scaffolded file to the hash of its content
+	Files map[string]string `json:"files,omitempty"`
+}
+
+// checksum returns the hash of the provided content
+func checksum(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return checksumAlgorithm + hex.EncodeToString(sum[:])
+}
+
+// loadChecksums reads the checksums file, returning empty checksums if it doesn't exist
+func loadChecksums(fs afero.Fs, path string) (*checksums, error) {
+	content, exists, err := readIfExists(fs, path)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &checksums{}
+	if exists {
+		if err := yaml.Unmarshal([]byte(content), c); err != nil {
+			return nil, fmt.Errorf("unable to parse %s: %w", path, err)
+		}
+	}
+	if c.Files == nil {
+		c.Files = make(map[string]string)
+	}
+	return c, nil
+}
+
+// save writes the checksums file
+func (c checksums) save(s Scaffold) error {
+	content, err := yaml.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("unable to marshal checksums: %w", err)
+	}
+
+	return s.writeFile(&File{Path: s.checksumsPath, Contents: checksumsComment + string(content)})
+}
+
+// isUnmodified checks if the content of the file matches the recorded checksum. Files without a recorded
+// checksum were not tracked when they were scaffolded, so they may have been modified and are considered so.
+func (c checksums) isUnmodified(path, content string) bool {
+	recorded, found := c.Files[path]
+	return found && recorded == checksum(content)
+}
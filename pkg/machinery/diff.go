@@ -0,0 +1,261 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinery
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// diffContextLines is the number of unchanged lines shown around each change
+	diffContextLines = 3
+
+	devNull = "/dev/null"
+)
+
+// diffOpKind is the kind of a line in a diff
+type diffOpKind byte
+
+const (
+	diffEqual  diffOpKind = ' '
+	diffDelete diffOpKind = '-'
+	diffInsert diffOpKind = '+'
+)
+
+// diffOp is a single line of a diff
+type diffOp struct {
+	kind diffOpKind
+	line string
+}
+
+// UnifiedDiff returns the unified diff that transforms oldContents into newContents for the file at path.
+// If existed is false, the file is reported as created. An empty string is returned if there are no changes.
+func UnifiedDiff(path, oldContents, newContents string, existed bool) string {
+	if existed && oldContents == newContents {
+		return ""
+	}
+
+	oldName := devNull
+	if existed {
+		oldName = "a/" + path
+	}
+	return unifiedDiff(oldName, "b/"+path, oldContents, newContents)
+}
+
+// RemovalDiff returns the unified diff that removes the file at path with the provided contents
+func RemovalDiff(path, oldContents string) string {
+	return unifiedDiff("a/"+path, devNull, oldContents, "")
+}
+
+// unifiedDiff returns the unified diff that transforms oldContents into newContents with the provided file names
+func unifiedDiff(oldName, newName, oldContents, newContents string) string {
+	ops := diffLines(splitLines(oldContents), splitLines(newContents))
+
+	var sb strings.Builder
+	_, _ = fmt.Fprintf(&sb, "--- %s\n", oldName)
+	_, _ = fmt.Fprintf(&sb, "+++ %s\n", newName)
+
+	for _, h := range buildHunks(ops) {
+		_, _ = fmt.Fprintf(&sb, "@@ -%s +%s @@\n",
+			hunkRange(h.oldStart, h.oldLines), hunkRange(h.newStart, h.newLines))
+		for _, op := range h.ops {
+			_ = sb.WriteByte(byte(op.kind))
+			_, _ = sb.WriteString(op.line)
+			if !strings.HasSuffix(op.line, "\n") {
+				_, _ = sb.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+// splitLines splits a string in lines keeping the line terminators
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines computes the shortest edit script between a and b using Myers' algorithm
+func diffLines(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+	maxD := n + m
+	offset := maxD + 1
+	v := make([]int, 2*maxD+3)
+	trace := make([][]int, 0, maxD+1)
+
+	// Forward pass: record the furthest reaching paths for each number of edits
+	for d := 0; d <= maxD; d++ {
+		snapshot := make([]int, len(v))
+		copy(snapshot, v)
+		trace = append(trace, snapshot)
+
+		done := false
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				done = true
+				break
+			}
+		}
+		if done {
+			break
+		}
+	}
+
+	// Backward pass: walk the recorded paths to build the edit script
+	ops := make([]diffOp, 0, n+m)
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			ops = append(ops, diffOp{kind: diffEqual, line: a[x]})
+		}
+		if d > 0 {
+			if x == prevX {
+				y--
+				ops = append(ops, diffOp{kind: diffInsert, line: b[y]})
+			} else {
+				x--
+				ops = append(ops, diffOp{kind: diffDelete, line: a[x]})
+			}
+		}
+	}
+
+	// Reverse the edit script as it was built backwards
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return ops
+}
+
+// hunk is a group of changes with their surrounding context
+type hunk struct {
+	oldStart, oldLines int
+	newStart, newLines int
+	ops                []diffOp
+}
+
+// buildHunks groups an edit script into hunks with diffContextLines lines of context
+func buildHunks(ops []diffOp) []hunk {
+	var hunks []hunk
+
+	oldLine, newLine := 1, 1
+	for i := 0; i < len(ops); {
+		// Skip unchanged lines until the next change
+		if ops[i].kind == diffEqual {
+			oldLine++
+			newLine++
+			i++
+			continue
+		}
+
+		// Include the previous context lines
+		start := i - diffContextLines
+		if start < 0 {
+			start = 0
+		}
+		h := hunk{oldStart: oldLine - (i - start), newStart: newLine - (i - start)}
+
+		// Extend the hunk until the changes are separated by more than twice the context
+		end := i
+		for end < len(ops) {
+			if ops[end].kind != diffEqual {
+				end++
+				continue
+			}
+			equalRun := 0
+			for end+equalRun < len(ops) && ops[end+equalRun].kind == diffEqual {
+				equalRun++
+			}
+			if end+equalRun == len(ops) || equalRun > 2*diffContextLines {
+				if equalRun > diffContextLines {
+					equalRun = diffContextLines
+				}
+				end += equalRun
+				break
+			}
+			end += equalRun
+		}
+
+		h.ops = ops[start:end]
+		for _, op := range h.ops {
+			if op.kind != diffInsert {
+				h.oldLines++
+			}
+			if op.kind != diffDelete {
+				h.newLines++
+			}
+		}
+		for _, op := range ops[i:end] {
+			if op.kind != diffInsert {
+				oldLine++
+			}
+			if op.kind != diffDelete {
+				newLine++
+			}
+		}
+
+		hunks = append(hunks, h)
+		i = end
+	}
+
+	return hunks
+}
+
+// hunkRange formats the range of a hunk as expected by the unified diff format
+func hunkRange(start, lines int) string {
+	if lines == 0 {
+		// Empty ranges point to the line before the change
+		return fmt.Sprintf("%d,0", start-1)
+	}
+	if lines == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, lines)
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinery
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UnifiedDiff", func() {
+	DescribeTable("should return the unified diff",
+		func(oldContents, newContents string, existed bool, expected string) {
+			Expect(UnifiedDiff("file", oldContents, newContents, existed)).To(Equal(expected))
+		},
+		Entry("for unchanged files",
+			"a\nb\n", "a\nb\n", true,
+			"",
+		),
+		Entry("for new files",
+			"", "a\nb\n", false,
+			"--- /dev/null\n+++ b/file\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+		),
+		Entry("for overwritten files",
+			"a\nb\nc\n", "a\nB\nc\n", true,
+			"--- a/file\n+++ b/file\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n",
+		),
+		Entry("for inserted lines",
+			"1\n2\n3\n4\n5\n6\n7\n8\n", "1\n2\n3\n4\nx\n5\n6\n7\n8\n", true,
+			"--- a/file\n+++ b/file\n@@ -2,6 +2,7 @@\n 2\n 3\n 4\n+x\n 5\n 6\n 7\n",
+		),
+		Entry("for distant changes in separate hunks",
+			"1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n", "x\n2\n3\n4\n5\n6\n7\n8\n9\ny\n", true,
+			"--- a/file\n+++ b/file\n@@ -1,4 +1,4 @@\n-1\n+x\n 2\n 3\n 4\n@@ -7,4 +7,4 @@\n 7\n 8\n 9\n-10\n+y\n",
+		),
+		Entry("for files without trailing newline",
+			"a\n", "a\nb", true,
+			"--- a/file\n+++ b/file\n@@ -1 +1,2 @@\n a\n+b\n\\ No newline at end of file\n",
+		),
+	)
+
+	It("should return the diff that removes a file", func() {
+		Expect(RemovalDiff("file", "a\nb\n")).To(Equal("--- a/file\n+++ /dev/null\n@@ -1,2 +0,0 @@\n-a\n-b\n"))
+	})
+})
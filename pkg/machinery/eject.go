@@ -0,0 +1,297 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinery
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+var _ Ejector = ejector{}
+
+// Eject returns an Ejector that reverts the code fragments and YAML values inserted by inserter, except the
+// ones that the kept inserters insert too. Kept inserters are usually built for the resources that remain in
+// the project, so that the fragments they share with the ejected one, such as imports, are preserved.
+// Code fragments that can't be found because the file was modified are an error unless force is set, in which
+// case they are left for the user to remove.
+func Eject(inserter Builder, force bool, kept ...Builder) Ejector {
+	return ejector{inserter: inserter, force: force, kept: kept}
+}
+
+// ejector is the Ejector returned by Eject
+type ejector struct {
+	inserter Builder
+	force    bool
+	kept     []Builder
+}
+
+// GetPath implements Builder
+func (e ejector) GetPath() string {
+	return e.inserter.GetPath()
+}
+
+// GetIfExistsAction implements Builder
+func (e ejector) GetIfExistsAction() IfExistsAction {
+	if e.force {
+		return OverwriteFile
+	}
+	return OverwriteIfUnmodified
+}
+
+// GetInserter implements Ejector
+func (e ejector) GetInserter() Builder {
+	return e.inserter
+}
+
+// GetKeptInserters implements Ejector
+func (e ejector) GetKeptInserters() []Builder {
+	return e.kept
+}
+
+// ejectFromFileModel removes from a single file the code fragments and YAML values of an Ejector that its kept
+// inserters don't insert. Files that don't exist or are going to be removed are ignored, and so are inserters
+// none of whose code fragments are found, as they were never inserted in the file.
+func (s Scaffold) ejectFromFileModel(e Ejector, models map[string]*File, removed map[string]bool) error {
+	path := e.GetPath()
+	if removed[path] {
+		return nil
+	}
+	if _, found := models[path]; !found {
+		exists, err := afero.Exists(s.fs, path)
+		if err != nil {
+			return ExistsFileError{err}
+		}
+		if !exists {
+			return nil
+		}
+	}
+
+	m, err := s.loadPreviousModel(e, models)
+	if err != nil {
+		return err
+	}
+
+	content := m.Contents
+	if i, isInserter := e.GetInserter().(Inserter); isInserter {
+		var kept []string
+		for _, k := range e.GetKeptInserters() {
+			if ki, isKeptInserter := k.(Inserter); isKeptInserter && ki.GetPath() == path {
+				for _, codeFragments := range getValidCodeFragments(ki) {
+					kept = append(kept, codeFragments...)
+				}
+			}
+		}
+
+		// Imports are ejected after the rest of the code fragments, as they may only be removed once nothing
+		// references them
+		var codeFragments, imports []string
+		for _, fragments := range getValidCodeFragments(i) {
+			for _, codeFragment := range fragments {
+				switch {
+				case slices.ContainsFunc(kept, func(k string) bool { return equalCodeFragments(k, codeFragment) }):
+				case filepath.Ext(path) == ".go" && importSpecRegexp.MatchString(strings.TrimSpace(codeFragment)):
+					imports = append(imports, codeFragment)
+				default:
+					codeFragments = append(codeFragments, codeFragment)
+				}
+			}
+		}
+
+		var found bool
+		var missing []string
+		for _, codeFragment := range codeFragments {
+			var removedFragment bool
+			if content, removedFragment = removeCodeFragment(content, codeFragment); removedFragment {
+				found = true
+			} else {
+				missing = append(missing, codeFragment)
+			}
+		}
+		for _, importSpec := range imports {
+			withoutImport, removedImport := removeCodeFragment(content, importSpec)
+			if !removedImport {
+				missing = append(missing, importSpec)
+				continue
+			}
+			found = true
+			if !importUsed(withoutImport, importSpec) {
+				content = withoutImport
+			}
+		}
+
+		if found && len(missing) != 0 {
+			if e.GetIfExistsAction() == OverwriteIfUnmodified {
+				return CodeFragmentNotFoundError{path: path, codeFragment: missing[0]}
+			}
+			for _, codeFragment := range missing {
+				log.Warnf("Code fragment not found in %s, remove it manually if needed:\n%s", path,
+					strings.TrimSpace(codeFragment))
+			}
+		}
+	}
+
+	if i, isYAMLInserter := e.GetInserter().(YAMLInserter); isYAMLInserter {
+		for _, edit := range i.GetYAMLEdits() {
+			appendEdit, isAppend := edit.(YAMLAppend)
+			if !isAppend {
+				continue
+			}
+
+			values := make([]interface{}, 0, len(appendEdit.Values))
+			for _, v := range appendEdit.Values {
+				if !keptYAMLValue(e.GetKeptInserters(), path, appendEdit.Path, v) {
+					values = append(values, v)
+				}
+			}
+
+			edited, err := YAMLRemove{Path: appendEdit.Path, Values: values}.apply(path, []byte(content))
+			if err != nil {
+				return err
+			}
+			content = string(edited)
+		}
+	}
+
+	// If nothing was removed, we are done
+	if content == m.Contents {
+		return nil
+	}
+
+	m.Contents = content
+	setUpdated(m)
+	models[path] = m
+	return nil
+}
+
+// keptYAMLValue checks if any kept YAMLInserter for the file at path appends the value to the sequence at keys
+func keptYAMLValue(kept []Builder, path string, keys []string, value interface{}) bool {
+	for _, k := range kept {
+		ki, isYAMLInserter := k.(YAMLInserter)
+		if !isYAMLInserter || ki.GetPath() != path {
+			continue
+		}
+		for _, edit := range ki.GetYAMLEdits() {
+			appendEdit, isAppend := edit.(YAMLAppend)
+			if !isAppend || !slices.Equal(appendEdit.Path, keys) {
+				continue
+			}
+			for _, v := range appendEdit.Values {
+				if reflect.DeepEqual(normalizeYAMLValue(v), normalizeYAMLValue(value)) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// codeFragmentLines returns the lines of a code fragment with trimmed space, in order to match different levels
+// of indentation. The trailing empty lines are only kept if requested.
+func codeFragmentLines(codeFragment string, keepTrailing bool) []string {
+	lines := splitLines(codeFragment)
+	trimmed := make([]string, 0, len(lines))
+	for _, line := range lines {
+		trimmed = append(trimmed, strings.TrimSpace(line))
+	}
+	for !keepTrailing && len(trimmed) != 0 && trimmed[len(trimmed)-1] == "" {
+		trimmed = trimmed[:len(trimmed)-1]
+	}
+	for len(trimmed) != 0 && trimmed[0] == "" {
+		trimmed = trimmed[1:]
+	}
+	return trimmed
+}
+
+// equalCodeFragments checks if two code fragments are equal regardless of their indentation
+func equalCodeFragments(a, b string) bool {
+	return slices.Equal(codeFragmentLines(a, false), codeFragmentLines(b, false))
+}
+
+// removeCodeFragment removes the first occurrence of a code fragment from the content regardless of its
+// indentation, and reports whether it was found. Trailing empty lines of the fragment are removed too when they
+// were inserted along with it.
+func removeCodeFragment(content, codeFragment string) (string, bool) {
+	lines := splitLines(content)
+	for _, keepTrailing := range []bool{true, false} {
+		fragment := codeFragmentLines(codeFragment, keepTrailing)
+		if len(fragment) == 0 {
+			return content, true
+		}
+
+		for start := 0; start+len(fragment) <= len(lines); start++ {
+			matches := true
+			for i, line := range fragment {
+				if strings.TrimSpace(lines[start+i]) != line {
+					matches = false
+					break
+				}
+			}
+			if matches {
+				return strings.Join(lines[:start], "") + strings.Join(lines[start+len(fragment):], ""), true
+			}
+		}
+	}
+	return content, false
+}
+
+// importSpecRegexp matches a single import spec, with an optional package name
+var importSpecRegexp = regexp.MustCompile(`^(?:([\w.]+)\s+)?"([^"]+)"$`)
+
+// importUsed checks if the Go code still references the package imported by the import spec. Code that can't
+// be parsed is considered to reference it, so that the import is kept.
+func importUsed(content, importSpec string) bool {
+	match := importSpecRegexp.FindStringSubmatch(strings.TrimSpace(importSpec))
+	if match == nil {
+		return true
+	}
+	name := match[1]
+	if name == "" {
+		name = path.Base(match[2])
+	}
+	// Blank imports can't be referenced, while the references to dot imports can't be told apart
+	switch name {
+	case "_":
+		return false
+	case ".":
+		return true
+	}
+
+	file, err := parser.ParseFile(token.NewFileSet(), "", content, parser.SkipObjectResolution)
+	if err != nil {
+		return true
+	}
+	var used bool
+	ast.Inspect(file, func(n ast.Node) bool {
+		if selector, isSelector := n.(*ast.SelectorExpr); isSelector {
+			if id, isIdent := selector.X.(*ast.Ident); isIdent && id.Name == name {
+				used = true
+			}
+		}
+		return !used
+	})
+	return used
+}
@@ -18,6 +18,7 @@ package machinery
 
 import (
 	"fmt"
+	"strings"
 )
 
 // This file contains the errors returned by the scaffolding machinery
@@ -33,6 +34,21 @@ func (e ValidateError) Unwrap() error {
 	return e.error
 }
 
+// InjectError is a wrapper error that will be used for errors when injecting fields into a file builder
+type InjectError struct {
+	err error
+}
+
+// Error implements error interface
+func (e InjectError) Error() string {
+	return fmt.Sprintf("failed to inject fields: %v", e.err)
+}
+
+// Unwrap implements Wrapper interface
+func (e InjectError) Unwrap() error {
+	return e.err
+}
+
 // SetTemplateDefaultsError is a wrapper error that will be used for errors returned by Template.SetTemplateDefaults
 type SetTemplateDefaultsError struct {
 	error
@@ -143,3 +159,173 @@ type FileAlreadyExistsError struct {
 func (e FileAlreadyExistsError) Error() string {
 	return fmt.Sprintf("failed to create %s: file already exists", e.path)
 }
+
+// ModifiedFileError is returned if the file is expected to be overwritten but it was modified since it was scaffolded
+type ModifiedFileError struct {
+	path string
+}
+
+// Error implements error interface
+func (e ModifiedFileError) Error() string {
+	return fmt.Sprintf("failed to overwrite %s: file was modified since it was scaffolded", e.path)
+}
+
+// CodeFragmentNotFoundError is returned if a code fragment to eject can't be found in a file that contains
+// other code fragments of the same inserter, which means that the file was modified since it was scaffolded
+type CodeFragmentNotFoundError struct {
+	path         string
+	codeFragment string
+}
+
+// Error implements error interface
+func (e CodeFragmentNotFoundError) Error() string {
+	return fmt.Sprintf("failed to eject from %s: code fragment not found:\n%s", e.path, e.codeFragment)
+}
+
+// GoEditError is a wrapper error that will be used for errors when editing the syntax tree of a Go file
+type GoEditError struct {
+	path string
+	err  error
+}
+
+// Error implements error interface
+func (e GoEditError) Error() string {
+	return fmt.Sprintf("failed to edit %s: %v", e.path, e.err)
+}
+
+// Unwrap implements Wrapper interface
+func (e GoEditError) Unwrap() error {
+	return e.err
+}
+
+// GoTargetNotFoundError is returned if the target of a Go edit can not be found
+type GoTargetNotFoundError struct {
+	path   string
+	target string
+}
+
+// Error implements error interface
+func (e GoTargetNotFoundError) Error() string {
+	return fmt.Sprintf("failed to edit %s: unable to find %s", e.path, e.target)
+}
+
+// YAMLEditError is a wrapper error that will be used for errors when editing the node tree of a YAML file
+type YAMLEditError struct {
+	path string
+	err  error
+}
+
+// Error implements error interface
+func (e YAMLEditError) Error() string {
+	return fmt.Sprintf("failed to edit %s: %v", e.path, e.err)
+}
+
+// Unwrap implements Wrapper interface
+func (e YAMLEditError) Unwrap() error {
+	return e.err
+}
+
+// UnknownCommentSyntaxError is returned if there is no comment syntax registered for a file
+type UnknownCommentSyntaxError struct {
+	path    string
+	matches []string
+}
+
+// Error implements error interface
+func (e UnknownCommentSyntaxError) Error() string {
+	return fmt.Sprintf("unknown comment syntax for %s, expected one of: %s", e.path, strings.Join(e.matches, ", "))
+}
+
+// UnknownFormatterError is returned if there is no formatter registered with a name
+type UnknownFormatterError struct {
+	name  string
+	names []string
+}
+
+// Error implements error interface
+func (e UnknownFormatterError) Error() string {
+	return fmt.Sprintf("unknown formatter %q, expected one of: %s", e.name, strings.Join(e.names, ", "))
+}
+
+// FormatError is a wrapper error that will be used for errors when formatting a file
+type FormatError struct {
+	path string
+	err  error
+}
+
+// Error implements error interface
+func (e FormatError) Error() string {
+	return fmt.Sprintf("failed to format %s: %v", e.path, e.err)
+}
+
+// Unwrap implements Wrapper interface
+func (e FormatError) Unwrap() error {
+	return e.err
+}
+
+// FileNotFoundError is returned if the file to remove or move doesn't exist
+type FileNotFoundError struct {
+	path string
+}
+
+// Error implements error interface
+func (e FileNotFoundError) Error() string {
+	return fmt.Sprintf("failed to remove or move %s: file not found", e.path)
+}
+
+// RemoveFileError is a wrapper error that will be used for errors when removing a file
+type RemoveFileError struct {
+	err error
+}
+
+// Error implements error interface
+func (e RemoveFileError) Error() string {
+	return fmt.Sprintf("failed to remove file: %v", e.err)
+}
+
+// Unwrap implements Wrapper interface
+func (e RemoveFileError) Unwrap() error {
+	return e.err
+}
+
+// MissingPartialError is returned if a template executes a partial that is not defined
+type MissingPartialError struct {
+	path string
+	name string
+}
+
+// Error implements error interface
+func (e MissingPartialError) Error() string {
+	return fmt.Sprintf("failed to render %s: partial %q is not defined", e.path, e.name)
+}
+
+// ReadTemplateBodyError is a wrapper error that will be used for errors when reading the body of a template
+type ReadTemplateBodyError struct {
+	name string
+	err  error
+}
+
+// Error implements error interface
+func (e ReadTemplateBodyError) Error() string {
+	return fmt.Sprintf("failed to read the body of template %s: %v", e.name, e.err)
+}
+
+// Unwrap implements Wrapper interface
+func (e ReadTemplateBodyError) Unwrap() error {
+	return e.err
+}
+
+// MarkerIssuesError is returned if the files of the project have issues with the markers required to update them
+type MarkerIssuesError struct {
+	Issues []MarkerIssue
+}
+
+// Error implements error interface
+func (e MarkerIssuesError) Error() string {
+	issues := make([]string, 0, len(e.Issues))
+	for _, issue := range e.Issues {
+		issues = append(issues, issue.String())
+	}
+	return fmt.Sprintf("found issues with the scaffold markers, fix them before continuing: %s",
+		strings.Join(issues, "; "))
+}
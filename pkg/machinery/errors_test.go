@@ -43,6 +43,10 @@ var _ = Describe("Errors", func() {
 		Entry("for file reading errors", ReadFileError{testErr}),
 		Entry("for file writing errors", WriteFileError{testErr}),
 		Entry("for file closing errors", CloseFileError{testErr}),
+		Entry("for Go edit errors", GoEditError{path, testErr}),
+		Entry("for YAML edit errors", YAMLEditError{path, testErr}),
+		Entry("for file removal errors", RemoveFileError{testErr}),
+		Entry("for template body reading errors", ReadTemplateBodyError{path, testErr}),
 	)
 
 	// NOTE: the following test increases coverage
@@ -50,5 +54,12 @@ var _ = Describe("Errors", func() {
 		Expect(ModelAlreadyExistsError{path}.Error()).To(ContainSubstring("model already exists"))
 		Expect(UnknownIfExistsActionError{path, -1}.Error()).To(ContainSubstring("unknown behavior if file exists"))
 		Expect(FileAlreadyExistsError{path}.Error()).To(ContainSubstring("file already exists"))
+		Expect(ModifiedFileError{path}.Error()).To(ContainSubstring("file was modified"))
+		Expect(FileNotFoundError{path}.Error()).To(ContainSubstring("file not found"))
+		Expect(MissingPartialError{path, "header"}.Error()).To(ContainSubstring(`partial "header" is not defined`))
+		Expect(UnknownCommentSyntaxError{path, []string{`".go"`}}.Error()).To(ContainSubstring("unknown comment syntax"))
+		Expect(GoTargetNotFoundError{path, "struct Foo"}.Error()).To(ContainSubstring("unable to find struct Foo"))
+		Expect(MarkerIssuesError{[]MarkerIssue{{Kind: MissingMarker, Path: path, Marker: "// +marker"}}}.Error()).
+			To(ContainSubstring(`missing marker "// +marker"`))
 	})
 })
@@ -28,8 +28,25 @@ const (
 
 	// OverwriteFile truncates and overwrites the existing file
 	OverwriteFile
+
+	// OverwriteIfUnmodified overwrites the existing file only if it wasn't modified since it was scaffolded,
+	// and returns an error and stops processing otherwise. Files without a recorded checksum are considered
+	// modified
+	OverwriteIfUnmodified
+
+	// WriteSidecarIfModified overwrites the existing file only if it wasn't modified since it was scaffolded,
+	// and writes the new contents to a sidecar file with the ".new" suffix otherwise. Files without a recorded
+	// checksum are considered modified
+	WriteSidecarIfModified
+
+	// MergeFile overwrites the existing file if it wasn't modified since it was scaffolded, and merges the
+	// changes done to it with the new contents otherwise, writing conflict markers where they overlap
+	MergeFile
 )
 
+// sidecarSuffix is appended to the path of the files written by WriteSidecarIfModified
+const sidecarSuffix = ".new"
+
 // File describes a file that will be written
 type File struct {
 	// Path is the file to write
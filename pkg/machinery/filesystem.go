@@ -17,10 +17,40 @@ limitations under the License.
 package machinery
 
 import (
+	"errors"
+	"os"
+
 	"github.com/spf13/afero"
 )
 
 // Filesystem abstracts the underlying disk for scaffolding
 type Filesystem struct {
 	FS afero.Fs
+
+	// Report, if set, aggregates the reports of every Scaffold execution on this Filesystem
+	Report *ScaffoldReport
+}
+
+// IsSymlink checks if the file at name is a symbolic link, looking through the filesystems of overlays.
+// It returns false if the file doesn't exist or if fs doesn't support symbolic links.
+func IsSymlink(fs afero.Fs, name string) (bool, error) {
+	if rfs, isRecording := fs.(*recordingFs); isRecording {
+		if rfs.isRemoved(name) {
+			return false, nil
+		}
+		name = rfs.resolve(name)
+		fs = rfs.Fs
+	}
+
+	lstater, isLstater := fs.(afero.Lstater)
+	if !isLstater {
+		return false, nil
+	}
+	info, _, err := lstater.LstatIfPossible(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return info.Mode()&os.ModeSymlink != 0, nil
 }
@@ -0,0 +1,224 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinery
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/tools/imports"
+)
+
+// Names of the built-in formatters, which can be used in the project configuration
+const (
+	GoImportsFormatterName = "goimports"
+	GofumptFormatterName   = "gofumpt"
+	YAMLFormatterName      = "yaml"
+	NoopFormatterName      = "none"
+)
+
+// Formatter formats the contents of a scaffolded file before it is written.
+// Files are formatted concurrently, so formatters must be safe for concurrent use.
+type Formatter interface {
+	// Format returns the formatted contents of the file at path
+	Format(path string, contents []byte) ([]byte, error)
+}
+
+// FormatterFunc allows to use a function as a Formatter
+type FormatterFunc func(path string, contents []byte) ([]byte, error)
+
+// Format implements Formatter
+func (f FormatterFunc) Format(path string, contents []byte) ([]byte, error) {
+	return f(path, contents)
+}
+
+var (
+	// GoImportsFormatter formats Go files and fixes their imports like goimports
+	GoImportsFormatter Formatter = FormatterFunc(formatGoImports)
+	// GofumptFormatter formats Go files with the gofumpt binary, which needs to be found in the PATH
+	GofumptFormatter Formatter = FormatterFunc(formatGofumpt)
+	// YAMLFormatter normalizes the whitespace of YAML files, keeping their comments and layout
+	YAMLFormatter Formatter = FormatterFunc(formatYAML)
+	// NoopFormatter keeps the contents unchanged
+	NoopFormatter Formatter = FormatterFunc(func(_ string, contents []byte) ([]byte, error) { return contents, nil })
+)
+
+var (
+	formattersMutex sync.RWMutex
+	// formattersByName binds the names that can be used in the project configuration to their formatters
+	formattersByName = map[string]Formatter{
+		GoImportsFormatterName: GoImportsFormatter,
+		GofumptFormatterName:   GofumptFormatter,
+		YAMLFormatterName:      YAMLFormatter,
+		NoopFormatterName:      NoopFormatter,
+	}
+)
+
+// defaultFormatters binds file extensions (starting with a dot) and file names to their default formatters
+var defaultFormatters = map[string]Formatter{
+	".go":      GoImportsFormatter,
+	".yaml":    YAMLFormatter,
+	".yml":     YAMLFormatter,
+	".sh":      NoopFormatter,
+	"Makefile": NoopFormatter,
+}
+
+// RegisterFormatter registers a formatter under a name, so that it can be used in the project configuration.
+// Registering a name twice returns an error.
+func RegisterFormatter(name string, formatter Formatter) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("invalid formatter name %q", name)
+	}
+	if formatter == nil {
+		return fmt.Errorf("invalid formatter for %q: a formatter is required", name)
+	}
+
+	formattersMutex.Lock()
+	defer formattersMutex.Unlock()
+
+	if _, found := formattersByName[name]; found {
+		return fmt.Errorf("a formatter is already registered as %q", name)
+	}
+	formattersByName[name] = formatter
+	return nil
+}
+
+// formatterNamed returns the formatter registered under name
+func formatterNamed(name string) (Formatter, error) {
+	formattersMutex.RLock()
+	defer formattersMutex.RUnlock()
+
+	if formatter, found := formattersByName[name]; found {
+		return formatter, nil
+	}
+
+	names := make([]string, 0, len(formattersByName))
+	for registered := range formattersByName {
+		names = append(names, fmt.Sprintf("%q", registered))
+	}
+	sort.Strings(names)
+	return nil, UnknownFormatterError{name, names}
+}
+
+// formatters returns the formatters of the Scaffold by file extension or name. The formatters set through
+// options take precedence over the ones in the project configuration, which take precedence over the defaults.
+func (s Scaffold) formatters() (map[string]Formatter, error) {
+	formatters := make(map[string]Formatter, len(defaultFormatters))
+	for match, formatter := range defaultFormatters {
+		formatters[match] = formatter
+	}
+
+	if s.injector.config != nil {
+		for match, name := range s.injector.config.GetFormatters() {
+			formatter, err := formatterNamed(name)
+			if err != nil {
+				return nil, err
+			}
+			formatters[match] = formatter
+		}
+	}
+
+	for match, formatter := range s.formatterOverrides {
+		formatters[match] = formatter
+	}
+
+	return formatters, nil
+}
+
+// formatModels formats every model with the formatter that matches its path, file names taking precedence
+// over extensions. Files without a matching formatter are kept unchanged. Up to parallelism models are
+// formatted concurrently, and the error of the first failing path in lexical order is returned.
+func formatModels(models map[string]*File, formatters map[string]Formatter, parallelism int) error {
+	paths := make([]string, 0, len(models))
+	for path := range models {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	errs := make([]error, len(paths))
+	forEach(len(paths), parallelism, func(i int) {
+		path := paths[i]
+		formatter, found := formatters[filepath.Base(path)]
+		if !found {
+			if formatter, found = formatters[filepath.Ext(path)]; !found {
+				return
+			}
+		}
+
+		m := models[path]
+		formatted, err := formatter.Format(path, []byte(m.Contents))
+		if err != nil {
+			errs[i] = FormatError{path, err}
+			return
+		}
+		m.Contents = string(formatted)
+	})
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// goImportsOptions format the code like goimports, adding missing imports and removing unused ones
+var goImportsOptions = imports.Options{
+	Comments:  true,
+	TabIndent: true,
+	TabWidth:  8,
+}
+
+// formatGoImports implements GoImportsFormatter
+func formatGoImports(path string, contents []byte) ([]byte, error) {
+	return imports.Process(path, contents, &goImportsOptions)
+}
+
+// formatGofumpt implements GofumptFormatter
+func formatGofumpt(_ string, contents []byte) ([]byte, error) {
+	cmd := exec.Command("gofumpt")
+	cmd.Stdin = bytes.NewReader(contents)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// formatYAML implements YAMLFormatter, removing trailing whitespace and using line feeds as line endings
+func formatYAML(_ string, contents []byte) ([]byte, error) {
+	lines := strings.SplitAfter(strings.ReplaceAll(string(contents), "\r\n", "\n"), "\n")
+
+	var sb strings.Builder
+	sb.Grow(len(contents))
+	for _, line := range lines {
+		trimmed := strings.TrimRight(line, " \t\n")
+		_, _ = sb.WriteString(trimmed)
+		if strings.HasSuffix(line, "\n") {
+			_ = sb.WriteByte('\n')
+		}
+	}
+	return []byte(sb.String()), nil
+}
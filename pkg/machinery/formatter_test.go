@@ -0,0 +1,164 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinery
+
+import (
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+
+	cfgv3 "sigs.k8s.io/kubebuilder/v4/pkg/config/v3"
+)
+
+var _ = Describe("Formatters", func() {
+	upper := FormatterFunc(func(_ string, contents []byte) ([]byte, error) {
+		return []byte(strings.ToUpper(string(contents))), nil
+	})
+
+	Context("RegisterFormatter", func() {
+		It("should register a formatter by name", func() {
+			Expect(RegisterFormatter("test-upper", upper)).To(Succeed())
+
+			formatter, err := formatterNamed("test-upper")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(formatter.Format("file", []byte("a"))).To(Equal([]byte("A")))
+		})
+
+		It("should fail for already registered names", func() {
+			Expect(RegisterFormatter(GoImportsFormatterName, upper)).NotTo(Succeed())
+		})
+
+		It("should fail for invalid names or formatters", func() {
+			Expect(RegisterFormatter("", upper)).NotTo(Succeed())
+			Expect(RegisterFormatter("test-nil", nil)).NotTo(Succeed())
+		})
+	})
+
+	Context("YAMLFormatter", func() {
+		It("should remove trailing whitespace and carriage returns", func() {
+			Expect(YAMLFormatter.Format("file.yaml", []byte("a: b  \r\n# comment\t\nc:\n- d \n"))).
+				To(Equal([]byte("a: b\n# comment\nc:\n- d\n")))
+		})
+	})
+
+	Context("GoImportsFormatter", func() {
+		It("should format Go files", func() {
+			Expect(GoImportsFormatter.Format("file.go", []byte("package file\nimport \"fmt\"\nvar a=fmt.Sprint()\n"))).
+				To(Equal([]byte("package file\n\nimport \"fmt\"\n\nvar a = fmt.Sprint()\n")))
+		})
+	})
+
+	Context("Scaffold.Execute", func() {
+		var (
+			fs afero.Fs
+			s  *Scaffold
+		)
+
+		BeforeEach(func() {
+			fs = afero.NewMemMapFs()
+		})
+
+		read := func(path string) string {
+			b, err := afero.ReadFile(fs, path)
+			Expect(err).NotTo(HaveOccurred())
+			return string(b)
+		}
+
+		It("should format the files with the default formatters", func() {
+			s = NewScaffold(Filesystem{FS: fs}, WithChecksumsPath(""))
+			Expect(s.Execute(
+				&fakeTemplate{fakeBuilder: fakeBuilder{path: "file.go"}, body: "package file\nvar a=1\n"},
+				&fakeTemplate{fakeBuilder: fakeBuilder{path: "file.yaml"}, body: "a: b  \n"},
+				&fakeTemplate{fakeBuilder: fakeBuilder{path: "Makefile"}, body: "all:  \n"},
+				&fakeTemplate{fakeBuilder: fakeBuilder{path: "file.txt"}, body: "a  \n"},
+			)).Error().To(Succeed())
+
+			Expect(read("file.go")).To(Equal("package file\n\nvar a = 1\n"))
+			Expect(read("file.yaml")).To(Equal("a: b\n"))
+			Expect(read("Makefile")).To(Equal("all:  \n"))
+			Expect(read("file.txt")).To(Equal("a  \n"))
+		})
+
+		It("should format the files once every builder was applied", func() {
+			s = NewScaffold(Filesystem{FS: fs}, WithChecksumsPath(""), WithFormatter("file.yaml", upper))
+			Expect(s.Execute(
+				&fakeTemplate{fakeBuilder: fakeBuilder{path: "file.yaml"}, body: "a\n# +kubebuilder:scaffold:-\n"},
+				fakeInserter{
+					fakeBuilder: fakeBuilder{path: "file.yaml"},
+					codeFragments: CodeFragmentsMap{
+						NewMarkerFor("file.yaml", "-"): {"b\n"},
+					},
+				},
+			)).Error().To(Succeed())
+
+			Expect(read("file.yaml")).To(Equal("A\nB\n# +KUBEBUILDER:SCAFFOLD:-\n"))
+		})
+
+		It("should use the formatters of the project configuration", func() {
+			cfg := cfgv3.New()
+			Expect(cfg.SetFormatter(".go", NoopFormatterName)).To(Succeed())
+			Expect(cfg.SetFormatter("file.yaml", NoopFormatterName)).To(Succeed())
+
+			s = NewScaffold(Filesystem{FS: fs}, WithChecksumsPath(""), WithConfig(cfg))
+			Expect(s.Execute(
+				&fakeTemplate{fakeBuilder: fakeBuilder{path: "file.go"}, body: "package file\nvar a=1\n"},
+				&fakeTemplate{fakeBuilder: fakeBuilder{path: "file.yaml"}, body: "a: b  \n"},
+				&fakeTemplate{fakeBuilder: fakeBuilder{path: "other.yaml"}, body: "a: b  \n"},
+			)).Error().To(Succeed())
+
+			Expect(read("file.go")).To(Equal("package file\nvar a=1\n"))
+			Expect(read("file.yaml")).To(Equal("a: b  \n"))
+			Expect(read("other.yaml")).To(Equal("a: b\n"))
+		})
+
+		It("should prefer the formatters set through options", func() {
+			cfg := cfgv3.New()
+			Expect(cfg.SetFormatter(".go", NoopFormatterName)).To(Succeed())
+
+			s = NewScaffold(Filesystem{FS: fs}, WithChecksumsPath(""), WithConfig(cfg),
+				WithFormatter(".go", GoImportsFormatter))
+			Expect(s.Execute(
+				&fakeTemplate{fakeBuilder: fakeBuilder{path: "file.go"}, body: "package file\nvar a=1\n"},
+			)).Error().To(Succeed())
+
+			Expect(read("file.go")).To(Equal("package file\n\nvar a = 1\n"))
+		})
+
+		It("should fail for unknown formatters in the project configuration", func() {
+			cfg := cfgv3.New()
+			Expect(cfg.SetFormatter(".go", "unknown")).To(Succeed())
+
+			s = NewScaffold(Filesystem{FS: fs}, WithChecksumsPath(""), WithConfig(cfg))
+			_, err := s.Execute(&fakeTemplate{fakeBuilder: fakeBuilder{path: "file.go"}, body: "package file\n"})
+			Expect(errors.As(err, &UnknownFormatterError{})).To(BeTrue())
+		})
+
+		It("should fail without writing any file if formatting fails", func() {
+			failing := FormatterFunc(func(string, []byte) ([]byte, error) { return nil, errors.New("failed") })
+			s = NewScaffold(Filesystem{FS: fs}, WithChecksumsPath(""), WithFormatter("file.txt", failing))
+			_, err := s.Execute(
+				&fakeTemplate{fakeBuilder: fakeBuilder{path: "file.go"}, body: "package file\n"},
+				&fakeTemplate{fakeBuilder: fakeBuilder{path: "file.txt"}, body: "a\n"},
+			)
+			Expect(errors.As(err, &FormatError{})).To(BeTrue())
+			Expect(afero.Exists(fs, "file.go")).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,347 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinery
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	pathpkg "path"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// GoEdit is an edit of the syntax tree of a Go file
+type GoEdit interface {
+	// apply returns the source of the file at path after applying the edit
+	apply(path string, src []byte) ([]byte, error)
+}
+
+var (
+	_ GoEdit = GoImport{}
+	_ GoEdit = GoImportRewrite{}
+	_ GoEdit = GoStructField{}
+	_ GoEdit = GoStatements{}
+)
+
+// GoImport adds an import to a Go file, unless it is already imported with the same name
+type GoImport struct {
+	// Name is the optional name of the import
+	Name string
+	// Path is the import path
+	Path string
+}
+
+// apply implements GoEdit
+func (e GoImport) apply(path string, src []byte) ([]byte, error) {
+	fset, file, err := parseGoFile(path, src)
+	if err != nil {
+		return nil, err
+	}
+
+	if !astutil.AddNamedImport(fset, file, e.Name, e.Path) {
+		return src, nil
+	}
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, GoEditError{path, err}
+	}
+	return buf.Bytes(), nil
+}
+
+// GoImportRewrite rewrites the imports of a package, and of the packages nested in it, to a different path.
+// Imports without a name are named after the previous package if the last element of their path changes,
+// so that the code referencing them keeps compiling.
+type GoImportRewrite struct {
+	// From is the previous import path
+	From string
+	// To is the new import path
+	To string
+}
+
+// apply implements GoEdit
+func (e GoImportRewrite) apply(path string, src []byte) ([]byte, error) {
+	if !bytes.Contains(src, []byte(`"`+e.From)) {
+		return src, nil
+	}
+
+	fset, file, err := parseGoFile(path, src)
+	if err != nil {
+		return nil, err
+	}
+
+	rewritten := false
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil || (importPath != e.From && !strings.HasPrefix(importPath, e.From+"/")) {
+			continue
+		}
+
+		newPath := e.To + strings.TrimPrefix(importPath, e.From)
+		if spec.Name == nil && pathpkg.Base(importPath) != pathpkg.Base(newPath) {
+			spec.Name = ast.NewIdent(pathpkg.Base(importPath))
+		}
+		spec.Path.Value = strconv.Quote(newPath)
+		rewritten = true
+	}
+	if !rewritten {
+		return src, nil
+	}
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, GoEditError{path, err}
+	}
+	return buf.Bytes(), nil
+}
+
+// GoStructField adds fields to a struct type of a Go file, skipping the ones whose name is already used
+type GoStructField struct {
+	// Struct is the name of the struct type
+	Struct string
+	// Field is the source of the fields, including their tags and doc comments
+	Field string
+}
+
+// apply implements GoEdit
+func (e GoStructField) apply(path string, src []byte) ([]byte, error) {
+	fset, file, err := parseGoFile(path, src)
+	if err != nil {
+		return nil, err
+	}
+
+	var target *ast.StructType
+	ast.Inspect(file, func(n ast.Node) bool {
+		if spec, isTypeSpec := n.(*ast.TypeSpec); isTypeSpec && spec.Name.Name == e.Struct {
+			target, _ = spec.Type.(*ast.StructType)
+		}
+		return target == nil
+	})
+	if target == nil {
+		return nil, GoTargetNotFoundError{path, fmt.Sprintf("struct %s", e.Struct)}
+	}
+
+	existing := make(map[string]bool)
+	for _, field := range target.Fields.List {
+		for _, name := range fieldNames(field) {
+			existing[name] = true
+		}
+	}
+
+	wrapper := fmt.Sprintf("package p\ntype _ struct {\n%s\n}\n", e.Field)
+	wrapperFset, wrapperFile, err := parseGoFile(path, []byte(wrapper))
+	if err != nil {
+		return nil, err
+	}
+	fields := wrapperFile.Decls[0].(*ast.GenDecl).Specs[0].(*ast.TypeSpec).Type.(*ast.StructType).Fields.List
+
+	var code strings.Builder
+	for _, field := range fields {
+		names := fieldNames(field)
+		if existing[names[0]] {
+			continue
+		}
+
+		start := field.Pos()
+		if field.Doc != nil {
+			start = field.Doc.Pos()
+		}
+		_, _ = code.WriteString(nodeSource(wrapperFset, wrapper, start, field.End()))
+		_ = code.WriteByte('\n')
+	}
+
+	return insertGoSource(path, src, fset.Position(target.Fields.Closing).Offset, code.String())
+}
+
+// GoStatements adds statements to a function of a Go file, skipping the ones already present in its body.
+// If Before is set, the statements are inserted before the first statement of the body whose source
+// contains it, otherwise they are appended to the body.
+type GoStatements struct {
+	// Func is the name of the function, methods are named as "Type.Method"
+	Func string
+	// Before is the optional text that identifies the statement before which the code is inserted
+	Before string
+	// Code is the source of the statements
+	Code string
+}
+
+// apply implements GoEdit
+func (e GoStatements) apply(path string, src []byte) ([]byte, error) {
+	fset, file, err := parseGoFile(path, src)
+	if err != nil {
+		return nil, err
+	}
+
+	var target *ast.FuncDecl
+	for _, decl := range file.Decls {
+		if f, isFunc := decl.(*ast.FuncDecl); isFunc && funcName(f) == e.Func && f.Body != nil {
+			target = f
+			break
+		}
+	}
+	if target == nil {
+		return nil, GoTargetNotFoundError{path, fmt.Sprintf("function %s", e.Func)}
+	}
+
+	offset := -1
+	existing := make(map[string]bool, len(target.Body.List))
+	for _, stmt := range target.Body.List {
+		stmtSrc := nodeSource(fset, string(src), stmt.Pos(), stmt.End())
+		existing[normalizeGoSource(stmtSrc)] = true
+
+		if offset == -1 && e.Before != "" && strings.Contains(stmtSrc, e.Before) {
+			offset = fset.Position(stmt.Pos()).Offset
+		}
+	}
+	if offset == -1 {
+		if e.Before != "" {
+			statement := fmt.Sprintf("statement containing %q in function %s", e.Before, e.Func)
+			return nil, GoTargetNotFoundError{path, statement}
+		}
+		offset = fset.Position(target.Body.Rbrace).Offset
+	}
+
+	wrapper := fmt.Sprintf("package p\nfunc _() {\n%s\n}\n", e.Code)
+	wrapperFset, wrapperFile, err := parseGoFile(path, []byte(wrapper))
+	if err != nil {
+		return nil, err
+	}
+
+	var code strings.Builder
+	for _, stmt := range wrapperFile.Decls[0].(*ast.FuncDecl).Body.List {
+		stmtSrc := nodeSource(wrapperFset, wrapper, stmt.Pos(), stmt.End())
+		if existing[normalizeGoSource(stmtSrc)] {
+			continue
+		}
+		_, _ = code.WriteString(stmtSrc)
+		_ = code.WriteByte('\n')
+	}
+
+	return insertGoSource(path, src, offset, code.String())
+}
+
+// parseGoFile parses the source of a Go file including its comments
+func parseGoFile(path string, src []byte) (*token.FileSet, *ast.File, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, src, parser.ParseComments)
+	if err != nil {
+		return nil, nil, GoEditError{path, err}
+	}
+	return fset, file, nil
+}
+
+// insertGoSource inserts code at offset and formats the result
+func insertGoSource(path string, src []byte, offset int, code string) ([]byte, error) {
+	if code == "" {
+		return src, nil
+	}
+
+	edited := make([]byte, 0, len(src)+len(code)+1)
+	edited = append(edited, src[:offset]...)
+	if offset > 0 && src[offset-1] != '\n' {
+		edited = append(edited, '\n')
+	}
+	edited = append(edited, code...)
+	edited = append(edited, src[offset:]...)
+
+	formatted, err := format.Source(edited)
+	if err != nil {
+		return nil, GoEditError{path, err}
+	}
+	return formatted, nil
+}
+
+// nodeSource returns the source between two positions
+func nodeSource(fset *token.FileSet, src string, start, end token.Pos) string {
+	return src[fset.Position(start).Offset:fset.Position(end).Offset]
+}
+
+// normalizeGoSource returns the tokens of the provided source separated by spaces, so that sources
+// that only differ in formatting, comments or trailing commas are normalized to the same value
+func normalizeGoSource(src string) string {
+	fset := token.NewFileSet()
+	var s scanner.Scanner
+	s.Init(fset.AddFile("", fset.Base(), len(src)), []byte(src), nil, 0)
+
+	var tokens []string
+	for {
+		_, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		// Skip automatically inserted semicolons
+		if tok == token.SEMICOLON && lit == "\n" {
+			continue
+		}
+		// Skip trailing commas
+		if (tok == token.RBRACE || tok == token.RPAREN || tok == token.RBRACK) &&
+			len(tokens) > 0 && tokens[len(tokens)-1] == token.COMMA.String() {
+			tokens = tokens[:len(tokens)-1]
+		}
+		if lit == "" {
+			lit = tok.String()
+		}
+		tokens = append(tokens, lit)
+	}
+	return strings.Join(tokens, " ")
+}
+
+// fieldNames returns the names of a field, using the type name for embedded fields
+func fieldNames(field *ast.Field) []string {
+	if len(field.Names) == 0 {
+		return []string{typeName(field.Type)}
+	}
+
+	names := make([]string, 0, len(field.Names))
+	for _, name := range field.Names {
+		names = append(names, name.Name)
+	}
+	return names
+}
+
+// funcName returns the name of a function, prefixing methods with the name of their receiver type
+func funcName(f *ast.FuncDecl) string {
+	if f.Recv == nil || len(f.Recv.List) == 0 {
+		return f.Name.Name
+	}
+	return typeName(f.Recv.List[0].Type) + "." + f.Name.Name
+}
+
+// typeName returns the unqualified name of a type, without pointers or type parameters
+func typeName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.StarExpr:
+		return typeName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	case *ast.IndexExpr:
+		return typeName(t.X)
+	case *ast.IndexListExpr:
+		return typeName(t.X)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinery
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Go edits", func() {
+	const (
+		path = "main.go"
+		src  = `package main
+
+import (
+	"os"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+)
+
+var scheme = runtime.NewScheme()
+
+type Options struct {
+	// Name of the manager
+	Name string
+}
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+}
+
+func main() {
+	mgr := newManager()
+
+	// +kubebuilder:scaffold:builder
+
+	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
+		os.Exit(1)
+	}
+}
+`
+	)
+
+	apply := func(edits ...GoEdit) (string, error) {
+		content := []byte(src)
+		for _, edit := range edits {
+			var err error
+			if content, err = edit.apply(path, content); err != nil {
+				return "", err
+			}
+		}
+		return string(content), nil
+	}
+
+	Context("GoImport", func() {
+		It("should add missing imports", func() {
+			content, err := apply(GoImport{Name: "crewv1", Path: "example.com/api/v1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(content).To(ContainSubstring("\tcrewv1 \"example.com/api/v1\"\n"))
+		})
+
+		It("should not duplicate existing imports", func() {
+			content, err := apply(GoImport{Name: "utilruntime", Path: "k8s.io/apimachinery/pkg/util/runtime"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(content).To(Equal(src))
+		})
+	})
+
+	Context("GoImportRewrite", func() {
+		It("should rewrite the imports of a package and its nested packages", func() {
+			content, err := apply(GoImportRewrite{From: "k8s.io/apimachinery/pkg", To: "example.com/pkg"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(content).To(ContainSubstring("\t\"example.com/pkg/runtime\"\n"))
+			Expect(content).To(ContainSubstring("\tutilruntime \"example.com/pkg/util/runtime\"\n"))
+		})
+
+		It("should name the imports whose last path element changes", func() {
+			content, err := apply(GoImportRewrite{From: "k8s.io/apimachinery/pkg/runtime", To: "example.com/scheme"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(content).To(ContainSubstring("\truntime \"example.com/scheme\"\n"))
+		})
+
+		It("should not change files that don't import the package", func() {
+			content, err := apply(GoImportRewrite{From: "k8s.io/apimachinery/pkg/run", To: "example.com/run"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(content).To(Equal(src))
+		})
+	})
+
+	Context("GoStructField", func() {
+		It("should add missing fields", func() {
+			content, err := apply(GoStructField{
+				Struct: "Options",
+				Field:  "// Port of the manager\nPort int `json:\"port\"`\nName string",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(content).To(ContainSubstring("\tName string\n\t// Port of the manager\n\tPort int `json:\"port\"`\n}"))
+			Expect(content).NotTo(ContainSubstring("Name string\n\tName string"))
+		})
+
+		It("should fail if the struct can not be found", func() {
+			_, err := apply(GoStructField{Struct: "Missing", Field: "Port int"})
+			Expect(err).To(HaveOccurred())
+			Expect(errors.As(err, &GoTargetNotFoundError{})).To(BeTrue())
+		})
+	})
+
+	Context("GoStatements", func() {
+		It("should append statements to the function", func() {
+			content, err := apply(
+				GoImport{Name: "crewv1", Path: "example.com/api/v1"},
+				GoStatements{Func: "init", Code: "utilruntime.Must(crewv1.AddToScheme(scheme))"},
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(content).To(ContainSubstring(
+				"\tutilruntime.Must(clientgoscheme.AddToScheme(scheme))\n" +
+					"\tutilruntime.Must(crewv1.AddToScheme(scheme))\n}",
+			))
+		})
+
+		It("should insert statements before the requested statement", func() {
+			content, err := apply(GoStatements{
+				Func:   "main",
+				Before: "mgr.AddHealthzCheck",
+				Code:   "if err := setup(mgr); err != nil {\nos.Exit(1)\n}",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(content).To(ContainSubstring(
+				"\tif err := setup(mgr); err != nil {\n\t\tos.Exit(1)\n\t}\n" +
+					"\tif err := mgr.AddHealthzCheck(",
+			))
+		})
+
+		It("should not duplicate statements that only differ in formatting", func() {
+			content, err := apply(GoStatements{Func: "init", Code: "utilruntime.Must(\n\tclientgoscheme.AddToScheme(scheme),\n)"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(content).To(Equal(src))
+		})
+
+		It("should fail if the function can not be found", func() {
+			_, err := apply(GoStatements{Func: "Options.Validate", Code: "return nil"})
+			Expect(err).To(HaveOccurred())
+			Expect(errors.As(err, &GoTargetNotFoundError{})).To(BeTrue())
+		})
+
+		It("should fail if the statement can not be found", func() {
+			_, err := apply(GoStatements{Func: "main", Before: "mgr.Start", Code: "setup(mgr)"})
+			Expect(err).To(HaveOccurred())
+			Expect(errors.As(err, &GoTargetNotFoundError{})).To(BeTrue())
+		})
+	})
+
+	It("should fail if the file can not be parsed", func() {
+		_, err := GoImport{Path: "os"}.apply(path, []byte("package"))
+		Expect(err).To(HaveOccurred())
+		Expect(errors.As(err, &GoEditError{})).To(BeTrue())
+	})
+})
@@ -17,6 +17,8 @@ limitations under the License.
 package machinery
 
 import (
+	"errors"
+
 	"sigs.k8s.io/kubebuilder/v4/pkg/config"
 	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
 )
@@ -34,7 +36,7 @@ type injector struct {
 }
 
 // injectInto injects fields from the universe into the builder
-func (i injector) injectInto(builder Builder) {
+func (i injector) injectInto(builder Builder) error {
 	// Inject project configuration
 	if i.config != nil {
 		if builderWithDomain, hasDomain := builder.(HasDomain); hasDomain {
@@ -49,6 +51,28 @@ func (i injector) injectInto(builder Builder) {
 		if builderWithMultiGroup, hasMultiGroup := builder.(HasMultiGroup); hasMultiGroup {
 			builderWithMultiGroup.InjectMultiGroup(i.config.IsMultiGroup())
 		}
+		if builderWithPluginChain, hasPluginChain := builder.(HasPluginChain); hasPluginChain {
+			builderWithPluginChain.InjectPluginChain(i.config.GetPluginChain())
+		}
+		if builderWithResources, hasResources := builder.(HasResources); hasResources {
+			resources, err := i.config.GetResources()
+			if err != nil {
+				return InjectError{err}
+			}
+			builderWithResources.InjectResources(resources)
+		}
+		if builderWithPluginConfig, hasPluginConfig := builder.(HasPluginConfig); hasPluginConfig {
+			key := builderWithPluginConfig.GetPluginConfigKey()
+			if err := builderWithPluginConfig.InjectPluginConfig(func(configObj interface{}) error {
+				err := i.config.DecodePluginConfig(key, configObj)
+				if errors.As(err, &config.PluginKeyNotFoundError{}) || errors.As(err, &config.UnsupportedFieldError{}) {
+					return nil
+				}
+				return err
+			}); err != nil {
+				return InjectError{err}
+			}
+		}
 	}
 	// Inject boilerplate
 	if builderWithBoilerplate, hasBoilerplate := builder.(HasBoilerplate); hasBoilerplate {
@@ -60,4 +84,6 @@ func (i injector) injectInto(builder Builder) {
 			builderWithResource.InjectResource(i.resource)
 		}
 	}
+
+	return nil
 }
@@ -92,6 +92,41 @@ func (t *templateWithResource) InjectResource(res *resource.Resource) {
 	t.resource = res
 }
 
+type templateWithPluginChain struct {
+	templateBase
+	pluginChain []string
+}
+
+func (t *templateWithPluginChain) InjectPluginChain(pluginChain []string) {
+	t.pluginChain = pluginChain
+}
+
+type templateWithResources struct {
+	templateBase
+	resources []resource.Resource
+}
+
+func (t *templateWithResources) InjectResources(resources []resource.Resource) {
+	t.resources = resources
+}
+
+type templatePluginConfig struct {
+	Image string `json:"image,omitempty"`
+}
+
+type templateWithPluginConfig struct {
+	templateBase
+	pluginConfig templatePluginConfig
+}
+
+func (t *templateWithPluginConfig) GetPluginConfigKey() string {
+	return "plugin.example.com/v1"
+}
+
+func (t *templateWithPluginConfig) InjectPluginConfig(decode func(interface{}) error) error {
+	return decode(&t.pluginConfig)
+}
+
 var _ = Describe("injector", func() {
 	tmp := templateBase{
 		path:           "my/path/to/file",
@@ -114,12 +149,12 @@ var _ = Describe("injector", func() {
 				})
 
 				It("should not inject anything if the config is nil", func() {
-					injector{}.injectInto(template)
+					Expect(injector{}.injectInto(template)).To(Succeed())
 					Expect(template.domain).To(Equal(""))
 				})
 
 				It("should not inject anything if the config doesn't have a domain set", func() {
-					injector{config: c}.injectInto(template)
+					Expect(injector{config: c}.injectInto(template)).To(Succeed())
 					Expect(template.domain).To(Equal(""))
 				})
 
@@ -127,7 +162,7 @@ var _ = Describe("injector", func() {
 					const domain = "my.domain"
 					Expect(c.SetDomain(domain)).To(Succeed())
 
-					injector{config: c}.injectInto(template)
+					Expect(injector{config: c}.injectInto(template)).To(Succeed())
 					Expect(template.domain).To(Equal(domain))
 				})
 			})
@@ -140,12 +175,12 @@ var _ = Describe("injector", func() {
 				})
 
 				It("should not inject anything if the config is nil", func() {
-					injector{}.injectInto(template)
+					Expect(injector{}.injectInto(template)).To(Succeed())
 					Expect(template.repository).To(Equal(""))
 				})
 
 				It("should not inject anything if the config doesn't have a repository set", func() {
-					injector{config: c}.injectInto(template)
+					Expect(injector{config: c}.injectInto(template)).To(Succeed())
 					Expect(template.repository).To(Equal(""))
 				})
 
@@ -153,7 +188,7 @@ var _ = Describe("injector", func() {
 					const repo = "test"
 					Expect(c.SetRepository(repo)).To(Succeed())
 
-					injector{config: c}.injectInto(template)
+					Expect(injector{config: c}.injectInto(template)).To(Succeed())
 					Expect(template.repository).To(Equal(repo))
 				})
 			})
@@ -166,12 +201,12 @@ var _ = Describe("injector", func() {
 				})
 
 				It("should not inject anything if the config is nil", func() {
-					injector{}.injectInto(template)
+					Expect(injector{}.injectInto(template)).To(Succeed())
 					Expect(template.projectName).To(Equal(""))
 				})
 
 				It("should not inject anything if the config doesn't have a project name set", func() {
-					injector{config: c}.injectInto(template)
+					Expect(injector{config: c}.injectInto(template)).To(Succeed())
 					Expect(template.projectName).To(Equal(""))
 				})
 
@@ -179,7 +214,7 @@ var _ = Describe("injector", func() {
 					const projectName = "my project"
 					Expect(c.SetProjectName(projectName)).To(Succeed())
 
-					injector{config: c}.injectInto(template)
+					Expect(injector{config: c}.injectInto(template)).To(Succeed())
 					Expect(template.projectName).To(Equal(projectName))
 				})
 			})
@@ -192,22 +227,100 @@ var _ = Describe("injector", func() {
 				})
 
 				It("should not inject anything if the config is nil", func() {
-					injector{}.injectInto(template)
+					Expect(injector{}.injectInto(template)).To(Succeed())
 					Expect(template.multiGroup).To(BeFalse())
 				})
 
 				It("should not set the flag if the config doesn't have the multi-group flag set", func() {
-					injector{config: c}.injectInto(template)
+					Expect(injector{config: c}.injectInto(template)).To(Succeed())
 					Expect(template.multiGroup).To(BeFalse())
 				})
 
 				It("should set the flag if the config has the multi-group flag set", func() {
 					Expect(c.SetMultiGroup()).To(Succeed())
 
-					injector{config: c}.injectInto(template)
+					Expect(injector{config: c}.injectInto(template)).To(Succeed())
 					Expect(template.multiGroup).To(BeTrue())
 				})
 			})
+
+			Context("Plugin chain", func() {
+				var template *templateWithPluginChain
+
+				BeforeEach(func() {
+					template = &templateWithPluginChain{templateBase: tmp}
+				})
+
+				It("should not inject anything if the config is nil", func() {
+					Expect(injector{}.injectInto(template)).To(Succeed())
+					Expect(template.pluginChain).To(BeNil())
+				})
+
+				It("should inject if the config has a plugin chain set", func() {
+					pluginChain := []string{"go.kubebuilder.io/v4"}
+					Expect(c.SetPluginChain(pluginChain)).To(Succeed())
+
+					Expect(injector{config: c}.injectInto(template)).To(Succeed())
+					Expect(template.pluginChain).To(Equal(pluginChain))
+				})
+			})
+
+			Context("Resources", func() {
+				var template *templateWithResources
+
+				BeforeEach(func() {
+					template = &templateWithResources{templateBase: tmp}
+				})
+
+				It("should not inject anything if the config is nil", func() {
+					Expect(injector{}.injectInto(template)).To(Succeed())
+					Expect(template.resources).To(BeNil())
+				})
+
+				It("should inject every resource tracked by the config", func() {
+					res := resource.Resource{
+						GVK:    resource.GVK{Group: "group", Domain: "my.domain", Version: "v1", Kind: "Kind"},
+						Plural: "kinds",
+					}
+					Expect(c.AddResource(res)).To(Succeed())
+
+					Expect(injector{config: c}.injectInto(template)).To(Succeed())
+					Expect(template.resources).To(Equal([]resource.Resource{res}))
+				})
+			})
+
+			Context("Plugin config", func() {
+				var template *templateWithPluginConfig
+
+				BeforeEach(func() {
+					template = &templateWithPluginConfig{templateBase: tmp}
+				})
+
+				It("should not inject anything if the config doesn't have the plugin config", func() {
+					Expect(injector{config: c}.injectInto(template)).To(Succeed())
+					Expect(template.pluginConfig).To(Equal(templatePluginConfig{}))
+				})
+
+				It("should decode the plugin config", func() {
+					Expect(c.EncodePluginConfig(template.GetPluginConfigKey(), templatePluginConfig{Image: "busybox"})).
+						To(Succeed())
+
+					Expect(injector{config: c}.injectInto(template)).To(Succeed())
+					Expect(template.pluginConfig).To(Equal(templatePluginConfig{Image: "busybox"}))
+				})
+
+				It("should decode the plugin config as a generic object with the mixin", func() {
+					Expect(c.EncodePluginConfig("plugin.example.com/v1", templatePluginConfig{Image: "busybox"})).
+						To(Succeed())
+
+					mixin := &struct {
+						templateBase
+						PluginConfigMixin
+					}{templateBase: tmp, PluginConfigMixin: PluginConfigMixin{PluginConfigKey: "plugin.example.com/v1"}}
+					Expect(injector{config: c}.injectInto(mixin)).To(Succeed())
+					Expect(mixin.PluginConfig).To(Equal(map[string]interface{}{"image": "busybox"}))
+				})
+			})
 		})
 
 		Context("Boilerplate", func() {
@@ -218,14 +331,14 @@ var _ = Describe("injector", func() {
 			})
 
 			It("should not inject anything if no boilerplate was set", func() {
-				injector{}.injectInto(template)
+				Expect(injector{}.injectInto(template)).To(Succeed())
 				Expect(template.boilerplate).To(Equal(""))
 			})
 
 			It("should inject if the a boilerplate was set", func() {
 				const boilerplate = `Copyright "The Nho Luong DevOps"`
 
-				injector{boilerplate: boilerplate}.injectInto(template)
+				Expect(injector{boilerplate: boilerplate}.injectInto(template)).To(Succeed())
 				Expect(template.boilerplate).To(Equal(boilerplate))
 			})
 		})
@@ -238,7 +351,7 @@ var _ = Describe("injector", func() {
 			})
 
 			It("should not inject anything if the resource is nil", func() {
-				injector{}.injectInto(template)
+				Expect(injector{}.injectInto(template)).To(Succeed())
 				Expect(template.resource).To(BeNil())
 			})
 
@@ -252,7 +365,7 @@ var _ = Describe("injector", func() {
 					},
 				}
 
-				injector{resource: res}.injectInto(template)
+				Expect(injector{resource: res}.injectInto(template)).To(Succeed())
 				Expect(template.resource).To(Equal(res))
 			})
 		})
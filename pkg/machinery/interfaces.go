@@ -59,6 +59,54 @@ type Inserter interface {
 	GetCodeFragments() CodeFragmentsMap
 }
 
+// GoASTInserter is a file builder that inserts code in a Go file by editing its syntax tree, so that code
+// is not duplicated regardless of its formatting and missing insertion targets are reported as errors
+type GoASTInserter interface {
+	Builder
+	// GetGoEdits returns the edits to apply to the file, in order
+	GetGoEdits() []GoEdit
+}
+
+// YAMLInserter is a file builder that inserts values in a YAML file by editing its node tree, so that values
+// are not duplicated regardless of their formatting and comments are preserved
+type YAMLInserter interface {
+	Builder
+	// GetYAMLEdits returns the edits to apply to the file, in order
+	GetYAMLEdits() []YAMLEdit
+}
+
+// Remover is a file builder that removes a file, or a directory and every file in it. Files are removed
+// regardless of their contents unless the if-exists action is OverwriteIfUnmodified, in which case removing
+// a file modified since it was scaffolded returns an error
+type Remover interface {
+	Builder
+	// IsOptional returns true if a missing file is not an error
+	IsOptional() bool
+}
+
+// Mover is a file builder that moves a file, or a directory and every file in it, from its path to its
+// destination. The if-exists action determines what to do if a destination file already exists: OverwriteFile
+// replaces it, SkipFile keeps both files, and the rest return an error. Moving a directory also rewrites the
+// Go import paths that reference the packages in it, which requires the project repository to be known
+type Mover interface {
+	Builder
+	// GetDestination returns the path where the file is moved to
+	GetDestination() string
+}
+
+// Ejector is a file builder that reverts what an Inserter or YAMLInserter inserted in a file: it removes the code
+// fragments and the values appended to YAML sequences, except the ones that its kept inserters insert too.
+// Files that don't exist are ignored, and edits other than appending to YAML sequences are not reverted.
+// Code fragments that can't be found while others of the same inserter are found return an error if the if-exists
+// action is OverwriteIfUnmodified, and are left in the file with a warning otherwise
+type Ejector interface {
+	Builder
+	// GetInserter returns the builder whose insertions are reverted
+	GetInserter() Builder
+	// GetKeptInserters returns the builders whose insertions are preserved
+	GetKeptInserters() []Builder
+}
+
 // HasDomain allows the domain to be used on a template
 type HasDomain interface {
 	// InjectDomain sets the template domain
@@ -95,6 +143,27 @@ type HasResource interface {
 	InjectResource(*resource.Resource)
 }
 
+// HasPluginConfig allows the configuration of a plugin to be used on a template
+type HasPluginConfig interface {
+	// GetPluginConfigKey returns the key of the plugin whose configuration is injected
+	GetPluginConfigKey() string
+	// InjectPluginConfig sets the template plugin configuration by calling decode with the object to decode it into.
+	// Missing configurations, and project versions that don't support them, are not decoded.
+	InjectPluginConfig(decode func(configObj interface{}) error) error
+}
+
+// HasPluginChain allows the plugin chain to be used on a template
+type HasPluginChain interface {
+	// InjectPluginChain sets the template plugin chain
+	InjectPluginChain([]string)
+}
+
+// HasResources allows every resource tracked by the project to be used on a template
+type HasResources interface {
+	// InjectResources sets the template resources
+	InjectResources([]resource.Resource)
+}
+
 // UseCustomFuncMap allows a template to use a custom template.FuncMap instead of the default FuncMap.
 type UseCustomFuncMap interface {
 	// GetFuncMap returns a custom FuncMap.
@@ -19,59 +19,133 @@ package machinery
 import (
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 )
 
 const kbPrefix = "+kubebuilder:scaffold:"
 
-var commentsByExt = map[string]string{
-	".go":   "//",
-	".yaml": "#",
-	".yml":  "#",
-	// When adding additional file extensions, update also the NewMarkerFor documentation and error
+// CommentSyntax describes how comments are written in a kind of file
+type CommentSyntax struct {
+	// Start is the token that starts a comment, e.g. "//" or "<!--"
+	Start string
+	// End is the token that ends a block comment, e.g. "-->", and is empty for line comments
+	End string
+}
+
+var (
+	commentsMutex sync.RWMutex
+	// commentsByMatch binds file extensions (starting with a dot) and file names to their comment syntax
+	commentsByMatch = map[string]CommentSyntax{
+		".go":   {Start: "//"},
+		".yaml": {Start: "#"},
+		".yml":  {Start: "#"},
+	}
+)
+
+// RegisterCommentSyntax registers the comment syntax of the files that match, which is either a file
+// extension starting with a dot, e.g. ".tf", or a file name, e.g. "Makefile". File names take precedence
+// over extensions. Registering a different syntax for an already registered match returns an error.
+func RegisterCommentSyntax(match string, syntax CommentSyntax) error {
+	if match == "" || strings.ContainsAny(match, `/\`) {
+		return fmt.Errorf("invalid comment syntax match %q", match)
+	}
+	if strings.TrimSpace(syntax.Start) == "" {
+		return fmt.Errorf("invalid comment syntax for %q: a start token is required", match)
+	}
+
+	commentsMutex.Lock()
+	defer commentsMutex.Unlock()
+
+	if registered, found := commentsByMatch[match]; found && registered != syntax {
+		return fmt.Errorf("a different comment syntax is already registered for %q", match)
+	}
+	commentsByMatch[match] = syntax
+	return nil
+}
+
+// commentSyntaxFor returns the comment syntax registered for the file at path
+func commentSyntaxFor(path string) (CommentSyntax, error) {
+	commentsMutex.RLock()
+	defer commentsMutex.RUnlock()
+
+	if syntax, found := commentsByMatch[filepath.Base(path)]; found {
+		return syntax, nil
+	}
+	if syntax, found := commentsByMatch[filepath.Ext(path)]; found {
+		return syntax, nil
+	}
+
+	matches := make([]string, 0, len(commentsByMatch))
+	for match := range commentsByMatch {
+		matches = append(matches, fmt.Sprintf("%q", match))
+	}
+	sort.Strings(matches)
+	return CommentSyntax{}, UnknownCommentSyntaxError{path, matches}
 }
 
 // Marker represents a machine-readable comment that will be used for scaffolding purposes
 type Marker struct {
-	prefix  string
-	comment string
-	value   string
+	prefix     string
+	comment    string
+	commentEnd string
+	value      string
 }
 
 // NewMarkerFor creates a new marker customized for the specific file. The created marker
 // is prefixed with `+kubebuilder:scaffold:` the default prefix for kubebuilder.
-// Supported file extensions: .go, .yaml, .yml.
+// It panics for files without a registered comment syntax, see MarkerFor for a safe alternative.
 func NewMarkerFor(path string, value string) Marker {
 	return NewMarkerWithPrefixFor(kbPrefix, path, value)
 }
 
-// NewMarkerWithPrefixFor creates a new custom prefixed marker customized for the specific file
-// Supported file extensions: .go, .yaml, .yml
+// NewMarkerWithPrefixFor creates a new custom prefixed marker customized for the specific file.
+// It panics for files without a registered comment syntax, see MarkerWithPrefixFor for a safe alternative.
 func NewMarkerWithPrefixFor(prefix string, path string, value string) Marker {
-	ext := filepath.Ext(path)
-	if comment, found := commentsByExt[ext]; found {
-		return Marker{
-			prefix:  markerPrefix(prefix),
-			comment: comment,
-			value:   value,
-		}
+	m, err := MarkerWithPrefixFor(prefix, path, value)
+	if err != nil {
+		panic(err)
 	}
+	return m
+}
 
-	extensions := make([]string, 0, len(commentsByExt))
-	for extension := range commentsByExt {
-		extensions = append(extensions, fmt.Sprintf("%q", extension))
+// MarkerFor creates a new marker customized for the specific file, prefixed with `+kubebuilder:scaffold:`.
+// It returns an error for files without a registered comment syntax.
+func MarkerFor(path string, value string) (Marker, error) {
+	return MarkerWithPrefixFor(kbPrefix, path, value)
+}
+
+// MarkerWithPrefixFor creates a new custom prefixed marker customized for the specific file.
+// It returns an error for files without a registered comment syntax.
+func MarkerWithPrefixFor(prefix string, path string, value string) (Marker, error) {
+	syntax, err := commentSyntaxFor(path)
+	if err != nil {
+		return Marker{}, err
 	}
-	panic(fmt.Errorf("unknown file extension: '%s', expected one of: %s", ext, strings.Join(extensions, ", ")))
+
+	return Marker{
+		prefix:     markerPrefix(prefix),
+		comment:    syntax.Start,
+		commentEnd: syntax.End,
+		value:      value,
+	}, nil
 }
 
 // String implements Stringer
 func (m Marker) String() string {
+	if m.commentEnd != "" {
+		return m.comment + " " + m.prefix + m.value + " " + m.commentEnd
+	}
 	return m.comment + " " + m.prefix + m.value
 }
 
 // EqualsLine compares a marker with a string representation to check if they are the same marker
 func (m Marker) EqualsLine(line string) bool {
 	line = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), m.comment))
+	if m.commentEnd != "" {
+		line = strings.TrimSpace(strings.TrimSuffix(line, m.commentEnd))
+	}
 	return line == m.prefix+m.value
 }
 
@@ -17,6 +17,9 @@ limitations under the License.
 package machinery
 
 import (
+	"errors"
+	"path/filepath"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -174,3 +177,45 @@ var _ = Describe("NewMarkerFor with unsupported extensions", func() {
 		Expect(func() { NewMarkerFor("file.md", "test") }).To(Panic())
 	})
 })
+
+var _ = Describe("RegisterCommentSyntax", func() {
+	BeforeEach(func() {
+		Expect(RegisterCommentSyntax("Makefile", CommentSyntax{Start: "#"})).To(Succeed())
+		Expect(RegisterCommentSyntax(".tf", CommentSyntax{Start: "#"})).To(Succeed())
+		Expect(RegisterCommentSyntax(".html", CommentSyntax{Start: "<!--", End: "-->"})).To(Succeed())
+	})
+
+	DescribeTable("should create markers for the registered comment syntaxes",
+		func(path, str string) {
+			marker, err := MarkerFor(path, "test")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(marker.String()).To(Equal(str))
+			Expect(marker.EqualsLine("  " + str + "  ")).To(BeTrue())
+		},
+		Entry("for file names", filepath.Join("dir", "Makefile"), "# +kubebuilder:scaffold:test"),
+		Entry("for file extensions", "main.tf", "# +kubebuilder:scaffold:test"),
+		Entry("for block comments", "index.html", "<!-- +kubebuilder:scaffold:test -->"),
+	)
+
+	It("should accept registering the same comment syntax again", func() {
+		Expect(RegisterCommentSyntax(".tf", CommentSyntax{Start: "#"})).To(Succeed())
+	})
+
+	It("should fail to register a different comment syntax for a registered match", func() {
+		Expect(RegisterCommentSyntax(".tf", CommentSyntax{Start: "//"})).NotTo(Succeed())
+	})
+
+	It("should fail to register invalid comment syntaxes", func() {
+		Expect(RegisterCommentSyntax("", CommentSyntax{Start: "#"})).NotTo(Succeed())
+		Expect(RegisterCommentSyntax("dir/Makefile", CommentSyntax{Start: "#"})).NotTo(Succeed())
+		Expect(RegisterCommentSyntax(".sh", CommentSyntax{})).NotTo(Succeed())
+	})
+})
+
+var _ = Describe("MarkerFor", func() {
+	It("should return an error for unknown extensions", func() {
+		_, err := MarkerFor("file.unknownext", "test")
+		Expect(err).To(HaveOccurred())
+		Expect(errors.As(err, &UnknownCommentSyntaxError{})).To(BeTrue())
+	})
+})
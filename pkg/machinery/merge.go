@@ -0,0 +1,163 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinery
+
+import (
+	"strings"
+)
+
+const (
+	conflictCurrentMarker   = "<<<<<<< current\n"
+	conflictSeparatorMarker = "=======\n"
+	conflictGeneratedMarker = ">>>>>>> generated\n"
+)
+
+// change replaces the base lines in [start, end) by lines
+type change struct {
+	start, end int
+	lines      []string
+}
+
+// changes returns the changes of an edit script, in base coordinates
+func changes(ops []diffOp) []change {
+	var (
+		result  []change
+		current *change
+		line    int
+	)
+	for _, op := range ops {
+		if op.kind == diffEqual {
+			if current != nil {
+				result = append(result, *current)
+				current = nil
+			}
+			line++
+			continue
+		}
+
+		if current == nil {
+			current = &change{start: line, end: line}
+		}
+		if op.kind == diffDelete {
+			line++
+			current.end = line
+		} else {
+			current.lines = append(current.lines, op.line)
+		}
+	}
+	if current != nil {
+		result = append(result, *current)
+	}
+	return result
+}
+
+// applyChanges returns the lines in base[start:end] after applying the provided changes, which must be
+// contained in that range
+func applyChanges(base []string, start, end int, cs []change) []string {
+	var lines []string
+	for _, c := range cs {
+		lines = append(lines, base[start:c.start]...)
+		lines = append(lines, c.lines...)
+		start = c.end
+	}
+	return append(lines, base[start:end]...)
+}
+
+// Merge performs a three-way merge of the changes from base to current and from base to generated.
+// Changes done by only one side are applied, while overlapping changes that differ are surrounded by
+// conflict markers, with the current lines first. It also returns whether any conflict was found.
+func Merge(base, current, generated string) (string, bool) {
+	baseLines := splitLines(base)
+	currentChanges := changes(diffLines(baseLines, splitLines(current)))
+	generatedChanges := changes(diffLines(baseLines, splitLines(generated)))
+
+	var (
+		sb       strings.Builder
+		conflict bool
+		line     int
+	)
+	write := func(lines []string) {
+		for _, l := range lines {
+			_, _ = sb.WriteString(l)
+		}
+	}
+	// Conflict markers need to start at the beginning of a line
+	writeTerminated := func(lines []string) {
+		write(lines)
+		if len(lines) > 0 && !strings.HasSuffix(lines[len(lines)-1], "\n") {
+			_ = sb.WriteByte('\n')
+		}
+	}
+
+	for len(currentChanges) > 0 || len(generatedChanges) > 0 {
+		// Start a group with the first change of either side
+		var (
+			first                        change
+			currentGroup, generatedGroup []change
+		)
+		if len(generatedChanges) == 0 ||
+			len(currentChanges) > 0 && currentChanges[0].start <= generatedChanges[0].start {
+			first, currentChanges = currentChanges[0], currentChanges[1:]
+			currentGroup = []change{first}
+		} else {
+			first, generatedChanges = generatedChanges[0], generatedChanges[1:]
+			generatedGroup = []change{first}
+		}
+		start, end := first.start, first.end
+
+		// Extend the group while the changes of any side overlap or touch it
+		for {
+			if len(currentChanges) > 0 && currentChanges[0].start <= end {
+				currentGroup = append(currentGroup, currentChanges[0])
+				end = max(end, currentChanges[0].end)
+				currentChanges = currentChanges[1:]
+				continue
+			}
+			if len(generatedChanges) > 0 && generatedChanges[0].start <= end {
+				generatedGroup = append(generatedGroup, generatedChanges[0])
+				end = max(end, generatedChanges[0].end)
+				generatedChanges = generatedChanges[1:]
+				continue
+			}
+			break
+		}
+
+		write(baseLines[line:start])
+		line = end
+
+		currentLines := applyChanges(baseLines, start, end, currentGroup)
+		generatedLines := applyChanges(baseLines, start, end, generatedGroup)
+		switch {
+		case len(generatedGroup) == 0:
+			write(currentLines)
+		case len(currentGroup) == 0:
+			write(generatedLines)
+		case strings.Join(currentLines, "") == strings.Join(generatedLines, ""):
+			write(currentLines)
+		default:
+			conflict = true
+			_, _ = sb.WriteString(conflictCurrentMarker)
+			writeTerminated(currentLines)
+			_, _ = sb.WriteString(conflictSeparatorMarker)
+			writeTerminated(generatedLines)
+			_, _ = sb.WriteString(conflictGeneratedMarker)
+		}
+	}
+	write(baseLines[line:])
+
+	return sb.String(), conflict
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinery
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Merge", func() {
+	const base = "a\nb\nc\nd\ne\nf\ng\n"
+
+	DescribeTable("should merge the changes of both sides",
+		func(current, generated, expected string, expectedConflict bool) {
+			merged, conflict := Merge(base, current, generated)
+			Expect(merged).To(Equal(expected))
+			Expect(conflict).To(Equal(expectedConflict))
+		},
+		Entry("without changes",
+			base, base, base, false),
+		Entry("with only current changes",
+			"a\nB\nc\nd\ne\nf\ng\n", base, "a\nB\nc\nd\ne\nf\ng\n", false),
+		Entry("with only generated changes",
+			base, "a\nb\nc\nd\ne\nF\ng\n", "a\nb\nc\nd\ne\nF\ng\n", false),
+		Entry("with changes in different lines",
+			"a\nB\nc\nd\ne\nf\ng\n", "a\nb\nc\nd\ne\nF\ng\n", "a\nB\nc\nd\ne\nF\ng\n", false),
+		Entry("with insertions and deletions in different lines",
+			"0\na\nb\nc\nd\ne\nf\ng\n", "a\nb\nc\nd\ne\ng\n", "0\na\nb\nc\nd\ne\ng\n", false),
+		Entry("with the same changes",
+			"a\nB\nc\nd\ne\nf\ng\n", "a\nB\nc\nd\ne\nf\ng\n", "a\nB\nc\nd\ne\nf\ng\n", false),
+		Entry("with overlapping changes",
+			"a\nB\nc\nd\ne\nf\ng\n", "a\nb2\nc\nd\ne\nF\ng\n",
+			"a\n<<<<<<< current\nB\n=======\nb2\n>>>>>>> generated\nc\nd\ne\nF\ng\n", true),
+		Entry("with insertions at the same line",
+			"a\nb\nc\nx\nd\ne\nf\ng\n", "a\nb\nc\ny\nd\ne\nf\ng\n",
+			"a\nb\nc\n<<<<<<< current\nx\n=======\ny\n>>>>>>> generated\nd\ne\nf\ng\n", true),
+		Entry("with overlapping changes without trailing new line",
+			"a\nb\nc\nd\ne\nf\nG", "a\nb\nc\nd\ne\nf\nH",
+			"a\nb\nc\nd\ne\nf\n<<<<<<< current\nG\n=======\nH\n>>>>>>> generated\n", true),
+	)
+
+	It("should conflict whole files without base", func() {
+		merged, conflict := Merge("", "a\n", "b\n")
+		Expect(merged).To(Equal("<<<<<<< current\na\n=======\nb\n>>>>>>> generated\n"))
+		Expect(conflict).To(BeTrue())
+	})
+})
@@ -80,6 +80,34 @@ func (t *InserterMixin) GetIfExistsAction() IfExistsAction {
 	return OverwriteFile
 }
 
+// RemoverMixin is the mixin that should be embedded in Remover builders
+type RemoverMixin struct {
+	PathMixin
+	IfExistsActionMixin
+
+	// Optional determines if a missing file is not an error
+	Optional bool
+}
+
+// IsOptional implements Remover
+func (t *RemoverMixin) IsOptional() bool {
+	return t.Optional
+}
+
+// MoverMixin is the mixin that should be embedded in Mover builders
+type MoverMixin struct {
+	PathMixin
+	IfExistsActionMixin
+
+	// Destination is the path where the file is moved to
+	Destination string
+}
+
+// GetDestination implements Mover
+func (t *MoverMixin) GetDestination() string {
+	return t.Destination
+}
+
 // DomainMixin provides templates with a injectable domain field
 type DomainMixin struct {
 	// Domain is the domain for the APIs
@@ -153,3 +181,51 @@ func (m *ResourceMixin) InjectResource(res *resource.Resource) {
 		m.Resource = res
 	}
 }
+
+// ResourcesMixin provides templates with an injectable field with every resource tracked by the project
+type ResourcesMixin struct {
+	// Resources are the resources tracked by the project
+	Resources []resource.Resource
+}
+
+// InjectResources implements HasResources
+func (m *ResourcesMixin) InjectResources(resources []resource.Resource) {
+	if m.Resources == nil {
+		m.Resources = resources
+	}
+}
+
+// PluginChainMixin provides templates with an injectable plugin chain field
+type PluginChainMixin struct {
+	// PluginChain is the list of plugin keys used to scaffold the project
+	PluginChain []string
+}
+
+// InjectPluginChain implements HasPluginChain
+func (m *PluginChainMixin) InjectPluginChain(pluginChain []string) {
+	if m.PluginChain == nil {
+		m.PluginChain = pluginChain
+	}
+}
+
+// PluginConfigMixin provides templates with an injectable plugin configuration field, decoded as a generic object.
+// Templates that need a typed configuration can implement HasPluginConfig decoding it into their own type.
+type PluginConfigMixin struct {
+	// PluginConfigKey is the key of the plugin whose configuration is injected
+	PluginConfigKey string
+	// PluginConfig is the configuration of the plugin
+	PluginConfig map[string]interface{}
+}
+
+// GetPluginConfigKey implements HasPluginConfig
+func (m *PluginConfigMixin) GetPluginConfigKey() string {
+	return m.PluginConfigKey
+}
+
+// InjectPluginConfig implements HasPluginConfig
+func (m *PluginConfigMixin) InjectPluginConfig(decode func(configObj interface{}) error) error {
+	if m.PluginConfig != nil {
+		return nil
+	}
+	return decode(&m.PluginConfig)
+}
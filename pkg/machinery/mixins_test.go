@@ -94,6 +94,28 @@ var _ = Describe("InserterMixin", func() {
 	})
 })
 
+var _ = Describe("RemoverMixin", func() {
+	tmp := RemoverMixin{PathMixin: PathMixin{"path/to/file.go"}, Optional: true}
+
+	Context("IsOptional", func() {
+		It("should return if the file is optional", func() {
+			Expect(tmp.IsOptional()).To(BeTrue())
+		})
+	})
+})
+
+var _ = Describe("MoverMixin", func() {
+	const destination = "path/to/destination.go"
+
+	tmp := MoverMixin{PathMixin: PathMixin{"path/to/file.go"}, Destination: destination}
+
+	Context("GetDestination", func() {
+		It("should return the destination", func() {
+			Expect(tmp.GetDestination()).To(Equal(destination))
+		})
+	})
+})
+
 var _ = Describe("DomainMixin", func() {
 	const domain = "my.domain"
 
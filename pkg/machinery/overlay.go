@@ -0,0 +1,592 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinery
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+const (
+	// stagedFileSuffix is appended to the files written during the first phase of a commit
+	stagedFileSuffix = ".kubebuilder-staged"
+
+	writeFlags = os.O_WRONLY | os.O_RDWR | os.O_CREATE | os.O_TRUNC | os.O_APPEND
+)
+
+// Overlay keeps in memory every change done through its Filesystem, leaving the underlying Filesystem untouched
+// until Commit is called. Reads that are not shadowed by a change are served from the underlying Filesystem, and
+// the files of the underlying Filesystem that are removed are hidden from them.
+type Overlay struct {
+	// base is the underlying file system
+	base afero.Fs
+	// layer stores the changed files
+	layer afero.Fs
+	// fs is the copy-on-write union of base and layer
+	fs *recordingFs
+	// root is the absolute path of the directory that relative paths refer to, if known
+	root string
+}
+
+// NewOverlay returns a new Overlay on top of the provided Filesystem.
+// If it is the OS filesystem, absolute paths to files of the working directory and their relative paths
+// refer to the same changes.
+func NewOverlay(fs Filesystem) *Overlay {
+	var root string
+	if _, isOsFs := fs.FS.(*afero.OsFs); isOsFs {
+		// Without the working directory, absolute paths are just not resolved
+		root, _ = os.Getwd()
+	}
+
+	layer := afero.NewMemMapFs()
+	return &Overlay{
+		base:  fs.FS,
+		layer: layer,
+		fs:    newRecordingFs(fs.FS, layer, root),
+		root:  root,
+	}
+}
+
+// Filesystem returns the Filesystem that should be used to stage changes
+func (o *Overlay) Filesystem() Filesystem {
+	return Filesystem{FS: o.fs}
+}
+
+// changedPaths returns the sorted paths of the files that were written through the Overlay
+func (o *Overlay) changedPaths() []string {
+	paths := make([]string, 0, len(o.fs.written))
+	for path := range o.fs.written {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// removedPaths returns the paths of the files and directories of the underlying Filesystem that were removed
+// through the Overlay, sorted so that the files of a directory go before the directory itself
+func (o *Overlay) removedPaths() []string {
+	paths := make([]string, 0, len(o.fs.removed))
+	for path := range o.fs.removed {
+		paths = append(paths, path)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(paths)))
+	return paths
+}
+
+// Diff writes to w the unified diff of every changed or removed file, sorted by path
+func (o *Overlay) Diff(w io.Writer) error {
+	diffs := make(map[string]string)
+	for _, path := range o.removedPaths() {
+		if isDir, err := afero.IsDir(o.base, path); err != nil || isDir {
+			continue
+		}
+		oldContents, _, err := readIfExists(o.base, path)
+		if err != nil {
+			return err
+		}
+		diffs[path] = RemovalDiff(path, oldContents)
+	}
+
+	for _, path := range o.changedPaths() {
+		newContents, err := afero.ReadFile(o.layer, path)
+		if err != nil {
+			return ReadFileError{err}
+		}
+
+		oldContents, existed, err := readIfExists(o.base, path)
+		if err != nil {
+			return err
+		}
+
+		diffs[path] = UnifiedDiff(path, oldContents, string(newContents), existed)
+	}
+
+	paths := make([]string, 0, len(diffs))
+	for path := range diffs {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		if _, err := io.WriteString(w, diffs[path]); err != nil {
+			return WriteFileError{err}
+		}
+	}
+
+	return nil
+}
+
+// Commit writes every change to the underlying Filesystem.
+//
+// Changes are first written next to their destination and then moved into place, so that a failure
+// while writing leaves the underlying Filesystem unchanged. Removed files are deleted once every change
+// is in place. If moving a file into place or deleting a file fails, the files that were already
+// replaced or deleted are restored to their previous contents.
+func (o *Overlay) Commit() error {
+	type stagedFile struct {
+		path        string
+		staged      string
+		oldContents string
+		existed     bool
+	}
+	type removedFile struct {
+		path        string
+		oldContents string
+		isDir       bool
+		perm        os.FileMode
+	}
+
+	var (
+		staged      []stagedFile
+		removed     []removedFile
+		createdDirs []string
+	)
+	rollback := func(replaced int) {
+		for i := len(removed) - 1; i >= 0; i-- {
+			if f := removed[i]; f.isDir {
+				_ = o.base.MkdirAll(f.path, f.perm)
+			} else {
+				_ = afero.WriteFile(o.base, f.path, []byte(f.oldContents), f.perm)
+			}
+		}
+		for i, f := range staged {
+			if i < replaced {
+				if f.existed {
+					_ = afero.WriteFile(o.base, f.path, []byte(f.oldContents), defaultFilePermission)
+				} else {
+					_ = o.base.Remove(f.path)
+				}
+			} else {
+				_ = o.base.Remove(f.staged)
+			}
+		}
+		for i := len(createdDirs) - 1; i >= 0; i-- {
+			_ = o.base.RemoveAll(createdDirs[i])
+		}
+	}
+
+	// Write every changed file next to its destination
+	for _, path := range o.changedPaths() {
+		info, err := o.layer.Stat(path)
+		if err != nil {
+			rollback(0)
+			return ExistsFileError{err}
+		}
+		contents, err := afero.ReadFile(o.layer, path)
+		if err != nil {
+			rollback(0)
+			return ReadFileError{err}
+		}
+		oldContents, existed, err := readIfExists(o.base, path)
+		if err != nil {
+			rollback(0)
+			return err
+		}
+		if existed && oldContents == string(contents) {
+			continue
+		}
+
+		createdDir, err := o.mkdirAll(filepath.Dir(path))
+		if createdDir != "" {
+			createdDirs = append(createdDirs, createdDir)
+		}
+		if err != nil {
+			rollback(0)
+			return CreateDirectoryError{err}
+		}
+
+		perm := info.Mode().Perm()
+		if existed {
+			if baseInfo, err := o.base.Stat(path); err == nil {
+				perm = baseInfo.Mode().Perm()
+			}
+		}
+
+		f := stagedFile{path: path, staged: path + stagedFileSuffix, oldContents: oldContents, existed: existed}
+		if err := afero.WriteFile(o.base, f.staged, contents, perm); err != nil {
+			_ = o.base.Remove(f.staged)
+			rollback(0)
+			return WriteFileError{err}
+		}
+		staged = append(staged, f)
+	}
+
+	// Move every staged file into place
+	for i, f := range staged {
+		if err := o.base.Rename(f.staged, f.path); err != nil {
+			rollback(i)
+			return WriteFileError{err}
+		}
+	}
+
+	// Delete the removed files, and then their directories
+	for _, path := range o.removedPaths() {
+		info, err := o.base.Stat(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			rollback(len(staged))
+			return ExistsFileError{err}
+		}
+
+		f := removedFile{path: path, isDir: info.IsDir(), perm: info.Mode().Perm()}
+		if !f.isDir {
+			b, err := afero.ReadFile(o.base, path)
+			if err != nil {
+				rollback(len(staged))
+				return ReadFileError{err}
+			}
+			f.oldContents = string(b)
+		}
+		if err := o.base.Remove(path); err != nil {
+			rollback(len(staged))
+			return RemoveFileError{err}
+		}
+		removed = append(removed, f)
+	}
+
+	// Start with a clean overlay, keeping the Filesystem that was already returned valid
+	o.layer = afero.NewMemMapFs()
+	*o.fs = *newRecordingFs(o.base, o.layer, o.root)
+
+	return nil
+}
+
+// mkdirAll creates a directory and its parents in the underlying Filesystem, returning the
+// outermost directory that did not exist before so that it can be removed on rollback
+func (o *Overlay) mkdirAll(dir string) (string, error) {
+	var missing string
+	for d := dir; d != "." && d != string(filepath.Separator); d = filepath.Dir(d) {
+		exists, err := afero.DirExists(o.base, d)
+		if err != nil {
+			return "", err
+		}
+		if exists {
+			break
+		}
+		missing = d
+	}
+	if missing == "" {
+		return "", nil
+	}
+
+	return missing, o.base.MkdirAll(dir, defaultDirectoryPermission)
+}
+
+// recordingFs is an afero.Fs that keeps track of the files that are opened for writing, and hides the files of
+// the underlying filesystem that are removed
+type recordingFs struct {
+	afero.Fs
+
+	// base is the underlying file system
+	base afero.Fs
+	// layer stores the changed files
+	layer afero.Fs
+	// root is the absolute path of the directory that relative paths refer to, if known
+	root string
+
+	written map[string]struct{}
+	removed map[string]struct{}
+}
+
+// newRecordingFs returns a new recordingFs with the copy-on-write union of base and layer
+func newRecordingFs(base, layer afero.Fs, root string) *recordingFs {
+	return &recordingFs{
+		Fs:      afero.NewCopyOnWriteFs(afero.NewReadOnlyFs(base), layer),
+		base:    base,
+		layer:   layer,
+		root:    root,
+		written: make(map[string]struct{}),
+		removed: make(map[string]struct{}),
+	}
+}
+
+// resolve returns the single key of the file at name: its clean path, relative to the root of the overlay
+// if it is an absolute path inside it
+func (fs *recordingFs) resolve(name string) string {
+	name = filepath.Clean(name)
+	if fs.root == "" || !filepath.IsAbs(name) {
+		return name
+	}
+	if rel, err := filepath.Rel(fs.root, name); err == nil && (rel == "." || filepath.IsLocal(rel)) {
+		return rel
+	}
+	return name
+}
+
+// isRemoved checks if the file at name was removed from the underlying filesystem
+func (fs *recordingFs) isRemoved(name string) bool {
+	_, removed := fs.removed[fs.resolve(name)]
+	return removed
+}
+
+// restore stops hiding the file at name and its parent directories, as they are going to be written again
+func (fs *recordingFs) restore(name string) {
+	for dir := fs.resolve(name); dir != "." && dir != string(filepath.Separator); dir = filepath.Dir(dir) {
+		delete(fs.removed, dir)
+	}
+}
+
+// Create implements afero.Fs
+func (fs *recordingFs) Create(name string) (afero.File, error) {
+	name = fs.resolve(name)
+	fs.restore(name)
+	f, err := fs.Fs.Create(name)
+	if err == nil {
+		fs.written[name] = struct{}{}
+	}
+	return f, err
+}
+
+// Mkdir implements afero.Fs
+func (fs *recordingFs) Mkdir(name string, perm os.FileMode) error {
+	name = fs.resolve(name)
+	fs.restore(name)
+	return fs.Fs.Mkdir(name, perm)
+}
+
+// MkdirAll implements afero.Fs
+func (fs *recordingFs) MkdirAll(path string, perm os.FileMode) error {
+	path = fs.resolve(path)
+	fs.restore(path)
+	return fs.Fs.MkdirAll(path, perm)
+}
+
+// Open implements afero.Fs
+func (fs *recordingFs) Open(name string) (afero.File, error) {
+	name = fs.resolve(name)
+	if fs.isRemoved(name) {
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+	f, err := fs.Fs.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return removedFilter{File: f, fs: fs, dir: name}, nil
+}
+
+// OpenFile implements afero.Fs
+func (fs *recordingFs) OpenFile(name string, flag int, perm os.FileMode) (afero.File, error) {
+	name = fs.resolve(name)
+	if fs.isRemoved(name) {
+		if flag&os.O_CREATE == 0 {
+			return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+		}
+		fs.restore(name)
+		// The removed contents must not be read back
+		flag |= os.O_TRUNC
+	}
+	f, err := fs.Fs.OpenFile(name, flag, perm)
+	if err == nil && flag&writeFlags != 0 {
+		fs.written[name] = struct{}{}
+	}
+	return f, err
+}
+
+// Remove implements afero.Fs
+func (fs *recordingFs) Remove(name string) error {
+	name = fs.resolve(name)
+	if _, err := fs.Stat(name); err != nil {
+		return err
+	}
+
+	if err := fs.layer.Remove(name); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	delete(fs.written, name)
+
+	exists, err := afero.Exists(fs.base, name)
+	if err != nil {
+		return err
+	}
+	if exists {
+		fs.removed[name] = struct{}{}
+	}
+	return nil
+}
+
+// RemoveAll implements afero.Fs
+func (fs *recordingFs) RemoveAll(path string) error {
+	path = fs.resolve(path)
+	if _, err := fs.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	// Remove the files of the directories before the directories themselves
+	paths, err := fs.walk(path)
+	if err != nil {
+		return err
+	}
+	for i := len(paths) - 1; i >= 0; i-- {
+		if err := fs.Remove(paths[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Rename implements afero.Fs.
+// The file or directory at oldname is copied to the layer at newname, replacing the file at newname
+// if any, and then removed, so that both the written and the removed files are recorded.
+func (fs *recordingFs) Rename(oldname, newname string) error {
+	oldname, newname = fs.resolve(oldname), fs.resolve(newname)
+	info, err := fs.Stat(oldname)
+	if err != nil {
+		return &os.LinkError{Op: "rename", Old: oldname, New: newname, Err: err}
+	}
+	if oldname == newname {
+		return nil
+	}
+	if info.IsDir() {
+		if _, err := fs.Stat(newname); err == nil {
+			return &os.LinkError{Op: "rename", Old: oldname, New: newname, Err: os.ErrExist}
+		}
+		if rel, err := filepath.Rel(oldname, newname); err == nil && filepath.IsLocal(rel) {
+			return &os.LinkError{Op: "rename", Old: oldname, New: newname, Err: os.ErrInvalid}
+		}
+	}
+
+	paths, err := fs.walk(oldname)
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		rel, err := filepath.Rel(oldname, path)
+		if err != nil {
+			return err
+		}
+		if err := fs.copy(path, filepath.Join(newname, rel)); err != nil {
+			return &os.LinkError{Op: "rename", Old: oldname, New: newname, Err: err}
+		}
+	}
+
+	return fs.RemoveAll(oldname)
+}
+
+// walk returns the path of the file or directory at root and the paths of the files and directories inside it,
+// parents going before their contents
+func (fs *recordingFs) walk(root string) ([]string, error) {
+	var paths []string
+	err := afero.Walk(fs, root, func(path string, _ os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		paths = append(paths, path)
+		return nil
+	})
+	return paths, err
+}
+
+// copy writes the file or directory at oldname to the layer at newname, keeping its permissions
+func (fs *recordingFs) copy(oldname, newname string) error {
+	info, err := fs.Stat(oldname)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return fs.MkdirAll(newname, info.Mode().Perm())
+	}
+
+	contents, err := afero.ReadFile(fs, oldname)
+	if err != nil {
+		return err
+	}
+	if err := fs.MkdirAll(filepath.Dir(newname), defaultDirectoryPermission); err != nil {
+		return err
+	}
+	return afero.WriteFile(fs, newname, contents, info.Mode().Perm())
+}
+
+// Stat implements afero.Fs
+func (fs *recordingFs) Stat(name string) (os.FileInfo, error) {
+	name = fs.resolve(name)
+	if fs.isRemoved(name) {
+		return nil, &os.PathError{Op: "stat", Path: name, Err: os.ErrNotExist}
+	}
+	return fs.Fs.Stat(name)
+}
+
+// Chmod implements afero.Fs
+func (fs *recordingFs) Chmod(name string, mode os.FileMode) error {
+	return fs.Fs.Chmod(fs.resolve(name), mode)
+}
+
+// Chown implements afero.Fs
+func (fs *recordingFs) Chown(name string, uid, gid int) error {
+	return fs.Fs.Chown(fs.resolve(name), uid, gid)
+}
+
+// Chtimes implements afero.Fs
+func (fs *recordingFs) Chtimes(name string, atime, mtime time.Time) error {
+	return fs.Fs.Chtimes(fs.resolve(name), atime, mtime)
+}
+
+// removedFilter is an afero.File that hides the removed files from the directory listings
+type removedFilter struct {
+	afero.File
+
+	fs  *recordingFs
+	dir string
+}
+
+// Readdir implements afero.File
+func (f removedFilter) Readdir(count int) ([]os.FileInfo, error) {
+	infos, err := f.File.Readdir(count)
+	filtered := make([]os.FileInfo, 0, len(infos))
+	for _, info := range infos {
+		if !f.fs.isRemoved(filepath.Join(f.dir, info.Name())) {
+			filtered = append(filtered, info)
+		}
+	}
+	return filtered, err
+}
+
+// Readdirnames implements afero.File
+func (f removedFilter) Readdirnames(n int) ([]string, error) {
+	names, err := f.File.Readdirnames(n)
+	filtered := make([]string, 0, len(names))
+	for _, name := range names {
+		if !f.fs.isRemoved(filepath.Join(f.dir, name)) {
+			filtered = append(filtered, name)
+		}
+	}
+	return filtered, err
+}
+
+// readIfExists returns the contents of the file at path and whether it exists
+func readIfExists(fs afero.Fs, path string) (string, bool, error) {
+	exists, err := afero.Exists(fs, path)
+	if err != nil {
+		return "", false, ExistsFileError{err}
+	}
+	if !exists {
+		return "", false, nil
+	}
+
+	b, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return "", true, ReadFileError{err}
+	}
+	return string(b), true, nil
+}
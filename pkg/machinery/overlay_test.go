@@ -0,0 +1,328 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinery
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+)
+
+var _ = Describe("Overlay", func() {
+	var (
+		base    afero.Fs
+		overlay *Overlay
+	)
+
+	BeforeEach(func() {
+		base = afero.NewMemMapFs()
+		Expect(afero.WriteFile(base, "existing", []byte("old\n"), 0o600)).To(Succeed())
+
+		overlay = NewOverlay(Filesystem{FS: base})
+	})
+
+	It("should stage changes without modifying the underlying filesystem", func() {
+		fs := overlay.Filesystem().FS
+		Expect(afero.WriteFile(fs, "existing", []byte("new\n"), 0o600)).To(Succeed())
+		Expect(fs.MkdirAll("dir", 0o700)).To(Succeed())
+		Expect(afero.WriteFile(fs, "dir/created", []byte("created\n"), 0o600)).To(Succeed())
+
+		b, err := afero.ReadFile(fs, "existing")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(Equal("new\n"))
+
+		b, err = afero.ReadFile(base, "existing")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(Equal("old\n"))
+		Expect(afero.Exists(base, "dir/created")).To(BeFalse())
+	})
+
+	It("should report the staged changes as a unified diff", func() {
+		s := NewScaffold(overlay.Filesystem(), WithChecksumsPath(""))
+		Expect(s.Execute(
+			&fakeTemplate{fakeBuilder: fakeBuilder{path: "existing", ifExistsAction: OverwriteFile}, body: "new\n"},
+			&fakeTemplate{fakeBuilder: fakeBuilder{path: "dir/created"}, body: "created\n"},
+		)).Error().To(Succeed())
+
+		out := &bytes.Buffer{}
+		Expect(overlay.Diff(out)).To(Succeed())
+		Expect(out.String()).To(Equal(
+			"--- /dev/null\n+++ b/dir/created\n@@ -0,0 +1 @@\n+created\n" +
+				"--- a/existing\n+++ b/existing\n@@ -1 +1 @@\n-old\n+new\n",
+		))
+	})
+
+	It("should hide the removed files without modifying the underlying filesystem", func() {
+		Expect(afero.WriteFile(base, "dir/removed", []byte("removed\n"), 0o600)).To(Succeed())
+
+		fs := overlay.Filesystem().FS
+		Expect(fs.Remove("dir/removed")).To(Succeed())
+		Expect(afero.Exists(fs, "dir/removed")).To(BeFalse())
+		Expect(afero.IsEmpty(fs, "dir")).To(BeTrue())
+		Expect(fs.Remove("dir")).To(Succeed())
+		Expect(afero.Exists(fs, "dir")).To(BeFalse())
+		Expect(fs.Remove("dir/removed")).NotTo(Succeed())
+		Expect(afero.Exists(base, "dir/removed")).To(BeTrue())
+
+		out := &bytes.Buffer{}
+		Expect(overlay.Diff(out)).To(Succeed())
+		Expect(out.String()).To(Equal("--- a/dir/removed\n+++ /dev/null\n@@ -1 +0,0 @@\n-removed\n"))
+	})
+
+	It("should report the symbolic links of the underlying filesystem", func() {
+		base = afero.NewBasePathFs(afero.NewOsFs(), GinkgoT().TempDir())
+		Expect(afero.WriteFile(base, "existing", []byte("old\n"), 0o600)).To(Succeed())
+		Expect(base.(afero.Linker).SymlinkIfPossible("existing", "link")).To(Succeed())
+		fs := NewOverlay(Filesystem{FS: base}).Filesystem().FS
+
+		Expect(IsSymlink(fs, "link")).To(BeTrue())
+		Expect(IsSymlink(fs, "existing")).To(BeFalse())
+		Expect(IsSymlink(fs, "missing")).To(BeFalse())
+
+		Expect(fs.Remove("link")).To(Succeed())
+		Expect(IsSymlink(fs, "link")).To(BeFalse())
+	})
+
+	It("should resolve the absolute paths of the files of the working directory", func() {
+		dir, err := filepath.EvalSymlinks(GinkgoT().TempDir())
+		Expect(err).NotTo(HaveOccurred())
+		wd, err := os.Getwd()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.Chdir(dir)).To(Succeed())
+		DeferCleanup(os.Chdir, wd)
+
+		base = afero.NewOsFs()
+		Expect(afero.WriteFile(base, "existing", []byte("old\n"), 0o600)).To(Succeed())
+		Expect(afero.WriteFile(base, "removed", []byte("removed\n"), 0o600)).To(Succeed())
+		overlay = NewOverlay(Filesystem{FS: base})
+		fs := overlay.Filesystem().FS
+
+		Expect(afero.WriteFile(fs, filepath.Join(dir, "existing"), []byte("new\n"), 0o600)).To(Succeed())
+		b, err := afero.ReadFile(fs, "existing")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(Equal("new\n"))
+
+		Expect(fs.Remove(filepath.Join(dir, "removed"))).To(Succeed())
+		Expect(afero.Exists(fs, "removed")).To(BeFalse())
+		Expect(afero.WriteFile(fs, "created", []byte("created\n"), 0o600)).To(Succeed())
+		Expect(fs.Remove(filepath.Join(dir, "created"))).To(Succeed())
+		Expect(afero.Exists(fs, "created")).To(BeFalse())
+
+		var paths []string
+		Expect(afero.Walk(fs, ".", func(path string, _ os.FileInfo, err error) error {
+			paths = append(paths, path)
+			return err
+		})).To(Succeed())
+		Expect(paths).To(Equal([]string{".", "existing"}))
+
+		var diff bytes.Buffer
+		Expect(overlay.Diff(&diff)).To(Succeed())
+		Expect(diff.String()).NotTo(ContainSubstring(dir))
+		Expect(diff.String()).To(ContainSubstring("+++ b/existing"))
+	})
+
+	It("should not read back the contents of a removed file that is written again", func() {
+		fs := overlay.Filesystem().FS
+		Expect(fs.Remove("existing")).To(Succeed())
+		f, err := fs.OpenFile("existing", os.O_WRONLY|os.O_CREATE, 0o600)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(f.Close()).To(Succeed())
+
+		b, err := afero.ReadFile(fs, "existing")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(BeEmpty())
+	})
+
+	It("should rename the files of the underlying filesystem", func() {
+		Expect(afero.WriteFile(base, "dir/moved", []byte("moved\n"), 0o600)).To(Succeed())
+
+		fs := overlay.Filesystem().FS
+		Expect(fs.Rename("existing", "renamed")).To(Succeed())
+		Expect(fs.Rename("dir", "other/dir")).To(Succeed())
+		Expect(afero.Exists(fs, "existing")).To(BeFalse())
+		Expect(afero.Exists(fs, "dir")).To(BeFalse())
+		Expect(afero.ReadFile(fs, "renamed")).To(Equal([]byte("old\n")))
+		Expect(afero.ReadFile(fs, "other/dir/moved")).To(Equal([]byte("moved\n")))
+		Expect(afero.Exists(base, "existing")).To(BeTrue())
+
+		out := &bytes.Buffer{}
+		Expect(overlay.Diff(out)).To(Succeed())
+		Expect(out.String()).To(Equal(
+			"--- a/dir/moved\n+++ /dev/null\n@@ -1 +0,0 @@\n-moved\n" +
+				"--- a/existing\n+++ /dev/null\n@@ -1 +0,0 @@\n-old\n" +
+				"--- /dev/null\n+++ b/other/dir/moved\n@@ -0,0 +1 @@\n+moved\n" +
+				"--- /dev/null\n+++ b/renamed\n@@ -0,0 +1 @@\n+old\n",
+		))
+
+		Expect(overlay.Commit()).To(Succeed())
+		Expect(afero.Exists(base, "existing")).To(BeFalse())
+		Expect(afero.Exists(base, "dir")).To(BeFalse())
+		Expect(afero.ReadFile(base, "renamed")).To(Equal([]byte("old\n")))
+		Expect(afero.ReadFile(base, "other/dir/moved")).To(Equal([]byte("moved\n")))
+	})
+
+	It("should not rename a directory into itself or onto an existing file", func() {
+		Expect(afero.WriteFile(base, "dir/file", []byte("file\n"), 0o600)).To(Succeed())
+
+		fs := overlay.Filesystem().FS
+		Expect(fs.Rename("dir", "dir/subdir")).NotTo(Succeed())
+		Expect(fs.Rename("dir", "existing")).NotTo(Succeed())
+		Expect(fs.Rename("missing", "renamed")).NotTo(Succeed())
+		Expect(afero.Exists(fs, "dir/file")).To(BeTrue())
+	})
+
+	It("should remove the directories of the underlying filesystem with their files", func() {
+		Expect(afero.WriteFile(base, "dir/subdir/removed", []byte("removed\n"), 0o600)).To(Succeed())
+
+		fs := overlay.Filesystem().FS
+		Expect(afero.WriteFile(fs, "dir/created", []byte("created\n"), 0o600)).To(Succeed())
+		Expect(fs.RemoveAll("dir")).To(Succeed())
+		Expect(fs.RemoveAll("missing")).To(Succeed())
+		Expect(afero.Exists(fs, "dir")).To(BeFalse())
+		Expect(afero.Exists(base, "dir/subdir/removed")).To(BeTrue())
+
+		out := &bytes.Buffer{}
+		Expect(overlay.Diff(out)).To(Succeed())
+		Expect(out.String()).To(Equal("--- a/dir/subdir/removed\n+++ /dev/null\n@@ -1 +0,0 @@\n-removed\n"))
+
+		Expect(overlay.Commit()).To(Succeed())
+		Expect(afero.Exists(base, "dir")).To(BeFalse())
+		Expect(afero.Exists(base, "existing")).To(BeTrue())
+	})
+
+	Context("Commit", func() {
+		BeforeEach(func() {
+			fs := overlay.Filesystem().FS
+			Expect(afero.WriteFile(fs, "existing", []byte("new\n"), 0o600)).To(Succeed())
+			Expect(fs.MkdirAll("dir/subdir", 0o700)).To(Succeed())
+			Expect(afero.WriteFile(fs, "dir/subdir/created", []byte("created\n"), 0o600)).To(Succeed())
+		})
+
+		It("should write the staged changes to the underlying filesystem", func() {
+			Expect(overlay.Commit()).To(Succeed())
+
+			b, err := afero.ReadFile(base, "existing")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(b)).To(Equal("new\n"))
+			b, err = afero.ReadFile(base, "dir/subdir/created")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(b)).To(Equal("created\n"))
+			Expect(afero.Exists(base, "existing"+stagedFileSuffix)).To(BeFalse())
+
+			out := &bytes.Buffer{}
+			Expect(overlay.Diff(out)).To(Succeed())
+			Expect(out.String()).To(BeEmpty())
+		})
+
+		It("should delete the removed files and directories from the underlying filesystem", func() {
+			Expect(afero.WriteFile(base, "removed/file", []byte("removed\n"), 0o600)).To(Succeed())
+			fs := overlay.Filesystem().FS
+			Expect(fs.Remove("removed/file")).To(Succeed())
+			Expect(fs.Remove("removed")).To(Succeed())
+
+			Expect(overlay.Commit()).To(Succeed())
+
+			Expect(afero.Exists(base, "removed")).To(BeFalse())
+			Expect(afero.Exists(fs, "dir/subdir/created")).To(BeTrue())
+		})
+
+		It("should restore the underlying filesystem if deleting a file fails", func() {
+			Expect(afero.WriteFile(base, "removed", []byte("removed\n"), 0o600)).To(Succeed())
+			Expect(afero.WriteFile(base, "kept", []byte("kept\n"), 0o600)).To(Succeed())
+			fs := overlay.Filesystem().FS
+			Expect(fs.Remove("removed")).To(Succeed())
+			Expect(fs.Remove("kept")).To(Succeed())
+			overlay.base = failingFs{Fs: base, failOnRemove: "kept"}
+
+			err := overlay.Commit()
+			Expect(err).To(HaveOccurred())
+			Expect(errors.As(err, &RemoveFileError{})).To(BeTrue())
+
+			b, err := afero.ReadFile(base, "removed")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(b)).To(Equal("removed\n"))
+			b, err = afero.ReadFile(base, "existing")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(b)).To(Equal("old\n"))
+		})
+
+		It("should leave the underlying filesystem unchanged if writing fails", func() {
+			overlay.base = failingFs{Fs: base, failOn: "existing" + stagedFileSuffix}
+
+			Expect(overlay.Commit()).NotTo(Succeed())
+
+			b, err := afero.ReadFile(base, "existing")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(b)).To(Equal("old\n"))
+			Expect(afero.Exists(base, "dir")).To(BeFalse())
+			Expect(afero.Exists(base, "dir/subdir/created"+stagedFileSuffix)).To(BeFalse())
+		})
+
+		It("should restore the underlying filesystem if moving a file into place fails", func() {
+			overlay.base = failingFs{Fs: base, failOnRename: "existing" + stagedFileSuffix}
+
+			err := overlay.Commit()
+			Expect(err).To(HaveOccurred())
+			Expect(errors.As(err, &WriteFileError{})).To(BeTrue())
+
+			b, err := afero.ReadFile(base, "existing")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(b)).To(Equal("old\n"))
+			Expect(afero.Exists(base, "dir")).To(BeFalse())
+			Expect(afero.Exists(base, "existing"+stagedFileSuffix)).To(BeFalse())
+		})
+	})
+})
+
+// failingFs is used to mock write, rename and remove errors in the underlying filesystem of an Overlay
+type failingFs struct {
+	afero.Fs
+
+	failOn       string
+	failOnRename string
+	failOnRemove string
+}
+
+// OpenFile implements afero.Fs
+func (fs failingFs) OpenFile(name string, flag int, perm os.FileMode) (afero.File, error) {
+	if fs.failOn != "" && strings.HasSuffix(name, fs.failOn) {
+		return nil, errors.New("write error")
+	}
+	return fs.Fs.OpenFile(name, flag, perm)
+}
+
+// Rename implements afero.Fs
+func (fs failingFs) Rename(oldname, newname string) error {
+	if fs.failOnRename != "" && strings.HasSuffix(oldname, fs.failOnRename) {
+		return errors.New("rename error")
+	}
+	return fs.Fs.Rename(oldname, newname)
+}
+
+// Remove implements afero.Fs
+func (fs failingFs) Remove(name string) error {
+	if fs.failOnRemove != "" && strings.HasSuffix(name, fs.failOnRemove) {
+		return errors.New("remove error")
+	}
+	return fs.Fs.Remove(name)
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinery
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+const (
+	// DefaultTemplateOverridesDir is the project-local directory where template overrides are looked for
+	DefaultTemplateOverridesDir = ".kubebuilder/templates"
+
+	// templateOverrideSuffix is appended to the path of the scaffolded file to get the path of its override
+	templateOverrideSuffix = ".tmpl"
+)
+
+// UserTemplateOverridesDir returns the user-level directory where template overrides are looked for,
+// or an empty string if the user configuration directory can not be determined
+func UserTemplateOverridesDir() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "kubebuilder", "templates")
+}
+
+// templateOverride returns the body of the override of a template, if any.
+//
+// Overrides are looked for in every override directory, in order, at the path of the scaffolded file
+// with a ".tmpl" suffix. If the template has a resource, the path where the resource values are replaced
+// by their placeholders is also checked, e.g. "internal/controller/%[kind]_controller.go.tmpl".
+func (s Scaffold) templateOverride(t Template) (string, bool, error) {
+	paths := []string{t.GetPath()}
+	if _, hasResource := t.(HasResource); hasResource && s.injector.resource != nil {
+		if generic := genericPath(t.GetPath(), s.injector.resource.Replacer()); generic != t.GetPath() {
+			paths = append(paths, generic)
+		}
+	}
+
+	for _, dir := range s.templateOverrideDirs {
+		if dir == "" {
+			continue
+		}
+		for _, path := range paths {
+			body, found, err := readIfExists(s.fs, filepath.Join(dir, path+templateOverrideSuffix))
+			if err != nil || found {
+				return body, found, err
+			}
+		}
+	}
+
+	return "", false, nil
+}
+
+// genericPath replaces the path elements that match a resource value by the placeholder of that value
+func genericPath(path string, replacer *strings.Replacer) string {
+	placeholders := make(map[string]string)
+	for _, placeholder := range []string{"%[kind]", "%[plural]", "%[version]", "%[group]"} {
+		if value := replacer.Replace(placeholder); value != "" && value != placeholder {
+			if _, found := placeholders[value]; !found {
+				placeholders[value] = placeholder
+			}
+		}
+	}
+
+	var sb strings.Builder
+	start := 0
+	for i := 0; i <= len(path); i++ {
+		if i < len(path) && !strings.ContainsRune(`/\_.-`, rune(path[i])) {
+			continue
+		}
+		element := path[start:i]
+		if placeholder, found := placeholders[element]; found {
+			element = placeholder
+		}
+		_, _ = sb.WriteString(element)
+		if i < len(path) {
+			_ = sb.WriteByte(path[i])
+		}
+		start = i + 1
+	}
+	return sb.String()
+}
+
+// DumpTemplates writes the body of the provided templates to dir, at the paths where they would be
+// looked for as overrides, so that they can be used as a starting point. Resources with placeholders
+// as values, e.g. "%[kind]", can be used to dump templates that are scaffolded for every resource.
+// Existing files are only overwritten if force is set.
+func (s *Scaffold) DumpTemplates(dir string, force bool, templates ...Template) error {
+	for _, t := range templates {
+		if err := s.injector.injectInto(t); err != nil {
+			return err
+		}
+		if err := t.SetTemplateDefaults(); err != nil {
+			return SetTemplateDefaultsError{err}
+		}
+
+		path := filepath.Join(dir, t.GetPath()+templateOverrideSuffix)
+		if !force {
+			exists, err := afero.Exists(s.fs, path)
+			if err != nil {
+				return ExistsFileError{err}
+			}
+			if exists {
+				return FileAlreadyExistsError{path}
+			}
+		}
+
+		if err := s.writeFile(&File{Path: path, Contents: t.GetBody()}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinery
+
+import (
+	"errors"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
+)
+
+var _ = Describe("Template overrides", func() {
+	const (
+		projectDir = DefaultTemplateOverridesDir
+		userDir    = "/home/user/.config/kubebuilder/templates"
+	)
+
+	var (
+		fs  afero.Fs
+		s   *Scaffold
+		res *resource.Resource
+	)
+
+	BeforeEach(func() {
+		fs = afero.NewMemMapFs()
+		res = &resource.Resource{
+			GVK:    resource.GVK{Group: "crew", Domain: "my.domain", Version: "v1", Kind: "Captain"},
+			Plural: "captains",
+		}
+		s = NewScaffold(Filesystem{FS: fs},
+			WithResource(res),
+			WithChecksumsPath(""),
+			WithTemplateOverrideDirs(projectDir, userDir),
+		)
+	})
+
+	read := func(path string) string {
+		b, err := afero.ReadFile(fs, path)
+		Expect(err).NotTo(HaveOccurred())
+		return string(b)
+	}
+
+	It("should use the built-in body without overrides", func() {
+		Expect(s.Execute(&resourceTemplate{})).Error().To(Succeed())
+		Expect(read("captain.txt")).To(Equal("built-in Captain"))
+	})
+
+	It("should use the project-local override with the injected fields and the default FuncMap", func() {
+		Expect(afero.WriteFile(fs, filepath.Join(projectDir, "captain.txt.tmpl"),
+			[]byte("project {{ .Resource.Kind | lower }}"), 0o644)).To(Succeed())
+		Expect(afero.WriteFile(fs, filepath.Join(userDir, "captain.txt.tmpl"),
+			[]byte("user"), 0o644)).To(Succeed())
+
+		Expect(s.Execute(&resourceTemplate{})).Error().To(Succeed())
+		Expect(read("captain.txt")).To(Equal("project captain"))
+	})
+
+	It("should use the user-level override if there is no project-local one", func() {
+		Expect(afero.WriteFile(fs, filepath.Join(userDir, "captain.txt.tmpl"), []byte("user"), 0o644)).To(Succeed())
+
+		Expect(s.Execute(&resourceTemplate{})).Error().To(Succeed())
+		Expect(read("captain.txt")).To(Equal("user"))
+	})
+
+	It("should use overrides whose path has the resource placeholders", func() {
+		Expect(afero.WriteFile(fs, filepath.Join(projectDir, "%[kind].txt.tmpl"),
+			[]byte("generic {{ .Resource.Kind }}"), 0o644)).To(Succeed())
+
+		Expect(s.Execute(&resourceTemplate{})).Error().To(Succeed())
+		Expect(read("captain.txt")).To(Equal("generic Captain"))
+	})
+
+	Context("DumpTemplates", func() {
+		BeforeEach(func() {
+			s = NewScaffold(Filesystem{FS: fs}, WithResource(&resource.Resource{
+				GVK:    resource.GVK{Group: "%[group]", Version: "%[version]", Kind: "%[kind]"},
+				Plural: "%[plural]",
+			}))
+		})
+
+		It("should write the built-in bodies to the override paths", func() {
+			Expect(s.DumpTemplates(projectDir, false, &resourceTemplate{})).To(Succeed())
+			Expect(read(filepath.Join(projectDir, "%[kind].txt.tmpl"))).To(Equal(resourceTemplateBody))
+		})
+
+		It("should only overwrite existing files if forced", func() {
+			path := filepath.Join(projectDir, "%[kind].txt.tmpl")
+			Expect(afero.WriteFile(fs, path, []byte("custom"), 0o644)).To(Succeed())
+
+			err := s.DumpTemplates(projectDir, false, &resourceTemplate{})
+			Expect(err).To(HaveOccurred())
+			Expect(errors.As(err, &FileAlreadyExistsError{})).To(BeTrue())
+			Expect(read(path)).To(Equal("custom"))
+
+			Expect(s.DumpTemplates(projectDir, true, &resourceTemplate{})).To(Succeed())
+			Expect(read(path)).To(Equal(resourceTemplateBody))
+		})
+	})
+})
+
+const resourceTemplateBody = "built-in {{ .Resource.Kind }}"
+
+// resourceTemplate is a Template scaffolded for every resource
+type resourceTemplate struct {
+	TemplateMixin
+	ResourceMixin
+}
+
+// SetTemplateDefaults implements Template
+func (f *resourceTemplate) SetTemplateDefaults() error {
+	f.Path = f.Resource.Replacer().Replace("%[kind].txt")
+	f.TemplateBody = resourceTemplateBody
+	return nil
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinery
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+	"text/template/parse"
+)
+
+var (
+	partialsMutex sync.RWMutex
+	// globalPartials binds the names of the globally registered partials to their bodies
+	globalPartials = map[string]string{}
+)
+
+// RegisterPartial registers a named partial for every template, which can execute it with
+// `{{ template "name" . }}`. Partials are parsed with the default delimiters and may define further
+// partials with `{{ define }}`. Registering a different body for an already registered name returns an error.
+func RegisterPartial(name, body string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("invalid partial name %q", name)
+	}
+
+	partialsMutex.Lock()
+	defer partialsMutex.Unlock()
+
+	if registered, found := globalPartials[name]; found && registered != body {
+		return fmt.Errorf("a different partial is already registered as %q", name)
+	}
+	globalPartials[name] = body
+	return nil
+}
+
+// WithPartials registers named partials for the templates of the Scaffold, e.g. the ones of a plugin.
+// They take precedence over the partials registered globally.
+func WithPartials(partials map[string]string) ScaffoldOption {
+	return func(s *Scaffold) {
+		if s.partials == nil {
+			s.partials = make(map[string]string, len(partials))
+		}
+		for name, body := range partials {
+			s.partials[name] = body
+		}
+	}
+}
+
+// templatePartials returns the partials available to the templates of the Scaffold
+func (s Scaffold) templatePartials() map[string]string {
+	partialsMutex.RLock()
+	defer partialsMutex.RUnlock()
+
+	partials := make(map[string]string, len(globalPartials)+len(s.partials))
+	for name, body := range globalPartials {
+		partials[name] = body
+	}
+	for name, body := range s.partials {
+		partials[name] = body
+	}
+	return partials
+}
+
+// addPartials parses the partials and adds them to temp, unless temp already defines them
+func addPartials(temp *template.Template, funcs template.FuncMap, partials map[string]string) error {
+	names := make([]string, 0, len(partials))
+	for name := range partials {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if defined(temp, name) {
+			continue
+		}
+
+		partial, err := template.New(name).Funcs(funcs).Parse(partials[name])
+		if err != nil {
+			return fmt.Errorf("unable to parse partial %q: %w", name, err)
+		}
+		for _, p := range partial.Templates() {
+			if p.Tree == nil || defined(temp, p.Name()) {
+				continue
+			}
+			if _, err := temp.AddParseTree(p.Name(), p.Tree); err != nil {
+				return fmt.Errorf("unable to add partial %q: %w", p.Name(), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkPartials checks that every template executed by temp is defined
+func checkPartials(path string, temp *template.Template) error {
+	for _, t := range temp.Templates() {
+		if t.Tree == nil {
+			continue
+		}
+		for _, name := range executedTemplates(t.Tree.Root) {
+			if !defined(temp, name) {
+				return MissingPartialError{path, name}
+			}
+		}
+	}
+	return nil
+}
+
+// defined returns true if temp has a template named name with a body
+func defined(temp *template.Template, name string) bool {
+	t := temp.Lookup(name)
+	return t != nil && t.Tree != nil
+}
+
+// executedTemplates returns the names of the templates executed by a node and its children
+func executedTemplates(node parse.Node) []string {
+	var names []string
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, child := range n.Nodes {
+			names = append(names, executedTemplates(child)...)
+		}
+	case *parse.TemplateNode:
+		names = append(names, n.Name)
+	case *parse.IfNode:
+		names = append(names, executedTemplates(n.List)...)
+		names = append(names, executedTemplates(n.ElseList)...)
+	case *parse.RangeNode:
+		names = append(names, executedTemplates(n.List)...)
+		names = append(names, executedTemplates(n.ElseList)...)
+	case *parse.WithNode:
+		names = append(names, executedTemplates(n.List)...)
+		names = append(names, executedTemplates(n.ElseList)...)
+	}
+	return names
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinery
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+)
+
+var _ = Describe("Partials", func() {
+	const path = "file.txt"
+
+	Context("RegisterPartial", func() {
+		It("should register a partial by name", func() {
+			Expect(RegisterPartial("test-global", "global {{ .TestField }}")).To(Succeed())
+			Expect(RegisterPartial("test-global", "global {{ .TestField }}")).To(Succeed())
+
+			s := NewScaffold(Filesystem{FS: afero.NewMemMapFs()}, WithChecksumsPath(""))
+			Expect(s.templatePartials()).To(HaveKeyWithValue("test-global", "global {{ .TestField }}"))
+		})
+
+		It("should fail for already registered names with a different body", func() {
+			Expect(RegisterPartial("test-different", "a")).To(Succeed())
+			Expect(RegisterPartial("test-different", "b")).NotTo(Succeed())
+		})
+
+		It("should fail for invalid names", func() {
+			Expect(RegisterPartial(" ", "a")).NotTo(Succeed())
+		})
+	})
+
+	Context("Scaffold.Execute", func() {
+		var s *Scaffold
+
+		BeforeEach(func() {
+			s = NewScaffold(Filesystem{FS: afero.NewMemMapFs()},
+				WithChecksumsPath(""),
+				WithTemplateOverrideDirs(),
+				WithPartials(map[string]string{
+					"test-header": "// {{ .TestField }}\n",
+					"test-nested": `{{ define "test-inner" }}inner{{ end }}[{{ template "test-inner" }}]`,
+				}),
+			)
+		})
+
+		render := func(body string) (string, error) {
+			_, err := s.Execute(&fakeTemplate{fakeBuilder: fakeBuilder{path: path, TestField: "value"}, body: body})
+			if err != nil {
+				return "", err
+			}
+			b, err := afero.ReadFile(s.fs, path)
+			return string(b), err
+		}
+
+		It("should execute the partials", func() {
+			Expect(render(`{{ template "test-header" . }}{{ template "test-nested" }}`)).
+				To(Equal("// value\n[inner]"))
+		})
+
+		It("should prefer the partials defined by the template", func() {
+			Expect(render(`{{ define "test-header" }}own{{ end }}{{ template "test-header" . }}`)).To(Equal("own"))
+		})
+
+		It("should keep the default delimiters for the partials", func() {
+			s.partials["test-header"] = "{{ .TestField }}"
+			tmp := &fakeTemplate{fakeBuilder: fakeBuilder{path: path, TestField: "value"}, body: `[[ template "test-header" . ]]`}
+			tmp.SetDelim("[[", "]]")
+			Expect(s.Execute(tmp)).Error().To(Succeed())
+
+			b, err := afero.ReadFile(s.fs, path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(b)).To(Equal("value"))
+		})
+
+		It("should fail before rendering if a partial is missing, even in branches that are not executed", func() {
+			_, err := render(`{{ if false }}{{ template "test-missing" }}{{ end }}`)
+			Expect(err).To(HaveOccurred())
+			Expect(errors.As(err, &ValidateError{})).To(BeTrue())
+			Expect(errors.As(err, &MissingPartialError{})).To(BeTrue())
+			Expect(afero.Exists(s.fs, path)).To(BeFalse())
+		})
+	})
+})
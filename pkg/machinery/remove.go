@@ -0,0 +1,338 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinery
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+var _ Remover = remover{}
+
+// Remove returns a Remover of the file scaffolded by builder, whose path is set up as if it was being scaffolded.
+// Missing files are ignored, and files modified since they were scaffolded are only removed if force is set.
+func Remove(builder Builder, force bool) Remover {
+	return remover{builder: builder, force: force}
+}
+
+// remover is the Remover returned by Remove
+type remover struct {
+	builder Builder
+	force   bool
+}
+
+// GetPath implements Builder
+func (r remover) GetPath() string {
+	return r.builder.GetPath()
+}
+
+// GetIfExistsAction implements Builder
+func (r remover) GetIfExistsAction() IfExistsAction {
+	if r.force {
+		return OverwriteFile
+	}
+	return OverwriteIfUnmodified
+}
+
+// IsOptional implements Remover
+func (remover) IsOptional() bool {
+	return true
+}
+
+// removeFileModels drops the models of the files removed by a Remover and marks the existing ones for removal
+func (s Scaffold) removeFileModels(r Remover, models map[string]*File, removed map[string]bool,
+	reports fileReports,
+) error {
+	paths, err := s.filesUnder(r.GetPath(), models)
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 && !r.IsOptional() {
+		return FileNotFoundError{r.GetPath()}
+	}
+
+	for _, p := range paths {
+		delete(models, p)
+
+		exists, err := afero.Exists(s.fs, p)
+		if err != nil {
+			return ExistsFileError{err}
+		}
+		// Files that were only going to be created don't need to be removed
+		if !exists {
+			delete(reports, p)
+			continue
+		}
+
+		if r.GetIfExistsAction() == OverwriteIfUnmodified {
+			current, err := s.loadModelFromFile(p)
+			if err != nil {
+				return err
+			}
+			if !s.checksums.isUnmodified(p, current.Contents) {
+				return ModifiedFileError{p}
+			}
+		}
+
+		removed[p] = true
+		reports.get(p).Action = FileRemoved
+	}
+
+	return nil
+}
+
+// moveFileModels builds the models of the files moved by a Mover and marks the existing ones for removal
+func (s Scaffold) moveFileModels(m Mover, models map[string]*File, removed map[string]bool,
+	reports fileReports,
+) error {
+	src, dst := m.GetPath(), m.GetDestination()
+
+	paths, err := s.filesUnder(src, models)
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		// Files that were already moved are ignored, so that moves can be repeated
+		if _, found := models[dst]; found {
+			return nil
+		}
+		if exists, err := afero.Exists(s.fs, dst); err != nil {
+			return ExistsFileError{err}
+		} else if exists {
+			return nil
+		}
+		return FileNotFoundError{src}
+	}
+
+	for _, p := range paths {
+		rel, err := filepath.Rel(filepath.Clean(src), p)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		_, found := models[target]
+		exists, err := afero.Exists(s.fs, target)
+		if err != nil {
+			return ExistsFileError{err}
+		}
+		if found || exists {
+			switch m.GetIfExistsAction() {
+			case SkipFile:
+				continue
+			case OverwriteFile:
+			default:
+				return FileAlreadyExistsError{target}
+			}
+		}
+
+		f, err := s.currentModel(p, models)
+		if err != nil {
+			return err
+		}
+		models[target] = &File{Path: target, Contents: f.Contents, IfExistsAction: OverwriteFile}
+		reports.get(target).scaffolded = true
+		delete(removed, target)
+		delete(models, p)
+
+		if exists, err = afero.Exists(s.fs, p); err != nil {
+			return ExistsFileError{err}
+		}
+		// Files that were only going to be created don't need to be removed
+		if !exists {
+			delete(reports, p)
+			continue
+		}
+
+		removed[p] = true
+		report := reports.get(p)
+		report.Action = FileMoved
+		report.MovedTo = target
+	}
+
+	// Moving a directory moves the Go packages in it, so the imports that reference them need to be rewritten
+	movedDirectory := len(paths) > 1 || paths[0] != filepath.Clean(src)
+	if !movedDirectory || s.injector.config == nil || s.injector.config.GetRepository() == "" {
+		return nil
+	}
+	repo := s.injector.config.GetRepository()
+	return s.rewriteImports(GoImportRewrite{
+		From: path.Join(repo, filepath.ToSlash(src)),
+		To:   path.Join(repo, filepath.ToSlash(dst)),
+	}, models, removed)
+}
+
+// rewriteImports applies an import rewrite to every Go file of the project that isn't going to be removed
+func (s Scaffold) rewriteImports(rewrite GoImportRewrite, models map[string]*File, removed map[string]bool) error {
+	paths := make(map[string]bool, len(models))
+	for p := range models {
+		paths[p] = true
+	}
+	err := afero.Walk(s.fs, ".", func(p string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			// Hidden directories, such as the recorded bases, and vendored dependencies are not part of the project
+			if p != "." && (strings.HasPrefix(info.Name(), ".") || info.Name() == "vendor") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		paths[p] = true
+		return nil
+	})
+	if err != nil {
+		return ReadFileError{err}
+	}
+
+	for p := range paths {
+		if filepath.Ext(p) != ".go" || removed[p] {
+			continue
+		}
+
+		m, err := s.currentModel(p, models)
+		if err != nil {
+			return err
+		}
+		content, err := rewrite.apply(p, []byte(m.Contents))
+		if err != nil {
+			return err
+		}
+		if string(content) == m.Contents {
+			continue
+		}
+
+		m.Contents = string(content)
+		setUpdated(m)
+		models[p] = m
+	}
+
+	return nil
+}
+
+// filesUnder returns the sorted paths of the existing files and models that are either at path or in the
+// directory at path
+func (s Scaffold) filesUnder(dir string, models map[string]*File) ([]string, error) {
+	dir = filepath.Clean(dir)
+
+	found := make(map[string]bool)
+	for p := range models {
+		if p == dir || strings.HasPrefix(p, dir+string(filepath.Separator)) {
+			found[p] = true
+		}
+	}
+	err := afero.Walk(s.fs, dir, func(p string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			found[p] = true
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, ReadFileError{err}
+	}
+
+	paths := make([]string, 0, len(found))
+	for p := range found {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// currentModel returns the model of a file, or loads it from the actual file if there is none
+func (s Scaffold) currentModel(path string, models map[string]*File) (*File, error) {
+	if m, found := models[path]; found {
+		return m, nil
+	}
+	return s.loadModelFromFile(path)
+}
+
+// sortedPaths returns the paths marked for removal, sorted
+func sortedPaths(removed map[string]bool) []string {
+	paths := make([]string, 0, len(removed))
+	for p := range removed {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// writeRemovalDiff writes the unified diff of the files to remove to the dry-run writer
+func (s Scaffold) writeRemovalDiff(removed map[string]bool) error {
+	for _, p := range sortedPaths(removed) {
+		current, err := s.loadModelFromFile(p)
+		if err != nil {
+			return err
+		}
+		if _, err := s.dryRun.Write([]byte(RemovalDiff(p, current.Contents))); err != nil {
+			return WriteFileError{err}
+		}
+	}
+	return nil
+}
+
+// removeFiles removes the files marked for removal, and the directories that are left empty
+func (s Scaffold) removeFiles(removed map[string]bool) error {
+	for _, p := range sortedPaths(removed) {
+		if err := s.removeFile(p); err != nil {
+			return err
+		}
+		if err := s.removeEmptyDirectories(filepath.Dir(p)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeFile removes a file, ignoring it if it doesn't exist
+func (s Scaffold) removeFile(path string) error {
+	if err := s.fs.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return RemoveFileError{err}
+	}
+	return nil
+}
+
+// removeEmptyDirectories removes dir and its parents as long as they are empty
+func (s Scaffold) removeEmptyDirectories(dir string) error {
+	for ; dir != "." && dir != string(filepath.Separator); dir = filepath.Dir(dir) {
+		empty, err := afero.IsEmpty(s.fs, dir)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return ReadFileError{err}
+		}
+		if !empty {
+			return nil
+		}
+		if err := s.fs.Remove(dir); err != nil {
+			return RemoveFileError{err}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinery
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// FileAction describes what was done to a file
+type FileAction string
+
+const (
+	// FileCreated means that the file didn't exist and was written
+	FileCreated FileAction = "created"
+	// FileOverwritten means that the file existed and was replaced by its scaffolded contents
+	FileOverwritten FileAction = "overwritten"
+	// FileUpdated means that code was inserted into an existing file
+	FileUpdated FileAction = "updated"
+	// FileMerged means that the changes done to a modified file were merged with its scaffolded contents
+	FileMerged FileAction = "merged"
+	// FileSidecar means that a modified file was kept and its scaffolded contents were written next to it
+	FileSidecar FileAction = "sidecar"
+	// FileSkipped means that the file existed and was kept
+	FileSkipped FileAction = "skipped"
+	// FileUnchanged means that every code fragment to be inserted into the file was already present
+	FileUnchanged FileAction = "unchanged"
+	// FileRemoved means that the file was removed
+	FileRemoved FileAction = "removed"
+	// FileMoved means that the file was moved to a different path
+	FileMoved FileAction = "moved"
+)
+
+// FileReport describes what was done to a file
+type FileReport struct {
+	// Action is what was done to the file
+	Action FileAction `json:"action"`
+	// Path is the path of the file
+	Path string `json:"path"`
+	// Sidecar is the path of the file where the scaffolded contents were written for FileSidecar actions
+	Sidecar string `json:"sidecar,omitempty"`
+	// MovedTo is the path where the file was moved to for FileMoved actions
+	MovedTo string `json:"movedTo,omitempty"`
+	// Bytes is the number of bytes written
+	Bytes int `json:"bytes"`
+	// Markers are the markers where code fragments were inserted
+	Markers []string `json:"markers,omitempty"`
+	// SkippedFragments is the number of code fragments and edits that were skipped because they were
+	// already present
+	SkippedFragments int `json:"skippedFragments,omitempty"`
+	// Conflict is set if merging the file resulted in conflicts
+	Conflict bool `json:"conflict,omitempty"`
+
+	// scaffolded is set if the file was built from a template instead of loaded from disk
+	scaffolded bool
+}
+
+// ScaffoldReport describes what was done to the files by one or more scaffolds
+type ScaffoldReport struct {
+	// Files are the reports of every file, sorted by path
+	Files []FileReport `json:"files"`
+}
+
+// Add aggregates the provided reports into r. Reports of a file that was already reported are combined,
+// so that a file created by a scaffold and updated by a later one is reported as created, unless it was
+// removed or moved afterwards.
+func (r *ScaffoldReport) Add(reports ...ScaffoldReport) {
+	for _, report := range reports {
+		for _, f := range report.Files {
+			i := sort.Search(len(r.Files), func(i int) bool { return r.Files[i].Path >= f.Path })
+			if i == len(r.Files) || r.Files[i].Path != f.Path {
+				r.Files = slices.Insert(r.Files, i, f)
+				continue
+			}
+			r.Files[i].combine(f)
+		}
+	}
+}
+
+// combine updates the report of a file with a later report of the same file
+func (f *FileReport) combine(later FileReport) {
+	switch {
+	case later.Action == FileSkipped || later.Action == FileUnchanged:
+	case f.Action == FileCreated && later.Action != FileRemoved && later.Action != FileMoved:
+		f.Bytes = later.Bytes
+	default:
+		f.Action = later.Action
+		f.Sidecar = later.Sidecar
+		f.MovedTo = later.MovedTo
+		f.Bytes = later.Bytes
+	}
+
+	for _, marker := range later.Markers {
+		if !slices.Contains(f.Markers, marker) {
+			f.Markers = append(f.Markers, marker)
+		}
+	}
+	f.SkippedFragments += later.SkippedFragments
+	f.Conflict = f.Conflict || later.Conflict
+}
+
+// WriteSummary writes a human-readable summary of the report to w
+func (r ScaffoldReport) WriteSummary(w io.Writer) error {
+	var sb strings.Builder
+	for _, f := range r.Files {
+		var details []string
+		if f.Sidecar != "" {
+			details = append(details, fmt.Sprintf("written to %s", f.Sidecar))
+		}
+		if f.MovedTo != "" {
+			details = append(details, fmt.Sprintf("to %s", f.MovedTo))
+		}
+		if !slices.Contains([]FileAction{FileSkipped, FileUnchanged, FileRemoved, FileMoved}, f.Action) {
+			details = append(details, fmt.Sprintf("%d bytes", f.Bytes))
+		}
+		if len(f.Markers) != 0 {
+			details = append(details, fmt.Sprintf("markers: %s", strings.Join(f.Markers, ", ")))
+		}
+		if f.SkippedFragments != 0 {
+			details = append(details, fmt.Sprintf("%d fragments already present", f.SkippedFragments))
+		}
+		if f.Conflict {
+			details = append(details, "conflicts")
+		}
+
+		_, _ = fmt.Fprintf(&sb, "%-12s %s", f.Action, f.Path)
+		if len(details) != 0 {
+			_, _ = fmt.Fprintf(&sb, " (%s)", strings.Join(details, "; "))
+		}
+		_ = sb.WriteByte('\n')
+	}
+
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return WriteFileError{err}
+	}
+	return nil
+}
+
+// fileReports accumulates the reports of the files handled by a single execution, by path
+type fileReports map[string]*FileReport
+
+// get returns the report of a file, adding it if needed
+func (r fileReports) get(path string) *FileReport {
+	if f, found := r[path]; found {
+		return f
+	}
+	f := &FileReport{Path: path}
+	r[path] = f
+	return f
+}
+
+// report returns the sorted report of every file
+func (r fileReports) report() ScaffoldReport {
+	report := ScaffoldReport{Files: make([]FileReport, 0, len(r))}
+	for _, f := range r {
+		report.Files = append(report.Files, *f)
+	}
+	sort.Slice(report.Files, func(i, j int) bool { return report.Files[i].Path < report.Files[j].Path })
+	return report
+}
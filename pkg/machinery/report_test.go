@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinery
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ScaffoldReport", func() {
+	Context("Add", func() {
+		It("should keep the files sorted by path", func() {
+			var report ScaffoldReport
+			report.Add(
+				ScaffoldReport{Files: []FileReport{{Action: FileCreated, Path: "b"}}},
+				ScaffoldReport{Files: []FileReport{{Action: FileCreated, Path: "c"}, {Action: FileCreated, Path: "a"}}},
+			)
+			Expect(report.Files).To(Equal([]FileReport{
+				{Action: FileCreated, Path: "a"},
+				{Action: FileCreated, Path: "b"},
+				{Action: FileCreated, Path: "c"},
+			}))
+		})
+
+		It("should report files created and later updated as created", func() {
+			report := ScaffoldReport{Files: []FileReport{{Action: FileCreated, Path: "a", Bytes: 1}}}
+			report.Add(ScaffoldReport{Files: []FileReport{
+				{Action: FileUpdated, Path: "a", Bytes: 2, Markers: []string{"m"}, SkippedFragments: 1},
+			}})
+			Expect(report.Files).To(Equal([]FileReport{
+				{Action: FileCreated, Path: "a", Bytes: 2, Markers: []string{"m"}, SkippedFragments: 1},
+			}))
+		})
+
+		It("should report files created and later removed or moved as such", func() {
+			report := ScaffoldReport{Files: []FileReport{
+				{Action: FileCreated, Path: "a", Bytes: 1},
+				{Action: FileCreated, Path: "b", Bytes: 1},
+			}}
+			report.Add(ScaffoldReport{Files: []FileReport{
+				{Action: FileRemoved, Path: "a"},
+				{Action: FileMoved, Path: "b", MovedTo: "c"},
+			}})
+			Expect(report.Files).To(Equal([]FileReport{
+				{Action: FileRemoved, Path: "a"},
+				{Action: FileMoved, Path: "b", MovedTo: "c"},
+			}))
+		})
+
+		It("should keep the last action that wrote the file", func() {
+			report := ScaffoldReport{Files: []FileReport{{Action: FileUpdated, Path: "a", Bytes: 1, Markers: []string{"m"}}}}
+			report.Add(
+				ScaffoldReport{Files: []FileReport{{Action: FileOverwritten, Path: "a", Bytes: 2, Markers: []string{"m"}}}},
+				ScaffoldReport{Files: []FileReport{{Action: FileUnchanged, Path: "a", SkippedFragments: 1}}},
+			)
+			Expect(report.Files).To(Equal([]FileReport{
+				{Action: FileOverwritten, Path: "a", Bytes: 2, Markers: []string{"m"}, SkippedFragments: 1},
+			}))
+		})
+	})
+
+	Context("WriteSummary", func() {
+		It("should write a line per file", func() {
+			report := ScaffoldReport{Files: []FileReport{
+				{Action: FileCreated, Path: "a", Bytes: 1},
+				{Action: FileSidecar, Path: "b", Sidecar: "b.new", Bytes: 2},
+				{Action: FileUpdated, Path: "c", Bytes: 3, Markers: []string{"m1", "m2"}, SkippedFragments: 1},
+				{Action: FileMerged, Path: "d", Bytes: 4, Conflict: true},
+				{Action: FileUnchanged, Path: "e", SkippedFragments: 2},
+				{Action: FileRemoved, Path: "f"},
+				{Action: FileMoved, Path: "g", MovedTo: "h"},
+			}}
+
+			var sb strings.Builder
+			Expect(report.WriteSummary(&sb)).To(Succeed())
+			Expect(sb.String()).To(Equal(`created      a (1 bytes)
+sidecar      b (written to b.new; 2 bytes)
+updated      c (3 bytes; markers: m1, m2; 1 fragments already present)
+merged       d (4 bytes; conflicts)
+unchanged    e (2 fragments already present)
+removed      f
+moved        g (to h)
+`))
+		})
+	})
+})
@@ -20,11 +20,16 @@ import (
 	"bufio"
 	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"runtime"
+	"slices"
+	"sort"
 	"strings"
 	"text/template"
 
+	log "github.com/sirupsen/logrus"
 	"github.com/spf13/afero"
 	"golang.org/x/tools/imports"
 
@@ -39,13 +44,6 @@ const (
 	defaultFilePermission      os.FileMode = 0600
 )
 
-var options = imports.Options{
-	Comments:   true,
-	TabIndent:  true,
-	TabWidth:   8,
-	FormatOnly: true,
-}
-
 // Scaffold uses templates to scaffold new files
 type Scaffold struct {
 	// fs allows to mock the file system for tests
@@ -57,6 +55,31 @@ type Scaffold struct {
 
 	// injector is used to provide several fields to the templates
 	injector injector
+
+	// dryRun, if set, receives a unified diff of the changes instead of writing them
+	dryRun io.Writer
+
+	// checksumsPath is the file where the checksums of the scaffolded files are recorded
+	checksumsPath string
+	// checksums are the checksums of the scaffolded files loaded by Execute
+	checksums *checksums
+	// basesDir is the directory where the contents of the scaffolded files are recorded to be merged later
+	basesDir string
+
+	// templateOverrideDirs are the directories where template overrides are looked for, in order
+	templateOverrideDirs []string
+
+	// formatterOverrides are the formatters set through options, by file extension or name
+	formatterOverrides map[string]Formatter
+
+	// partials are the named partials available to the templates besides the ones registered globally
+	partials map[string]string
+
+	// parallelism is the maximum number of templates rendered, and files formatted, concurrently
+	parallelism int
+
+	// report, if set, aggregates the reports of every execution
+	report *ScaffoldReport
 }
 
 // ScaffoldOption allows to provide optional arguments to the Scaffold
@@ -65,9 +88,15 @@ type ScaffoldOption func(*Scaffold)
 // NewScaffold returns a new Scaffold with the provided plugins
 func NewScaffold(fs Filesystem, options ...ScaffoldOption) *Scaffold {
 	s := &Scaffold{
-		fs:       fs.FS,
-		dirPerm:  defaultDirectoryPermission,
-		filePerm: defaultFilePermission,
+		fs:            fs.FS,
+		dirPerm:       defaultDirectoryPermission,
+		filePerm:      defaultFilePermission,
+		checksumsPath: DefaultChecksumsPath,
+		parallelism:   runtime.GOMAXPROCS(0),
+
+		templateOverrideDirs: []string{DefaultTemplateOverridesDir, UserTemplateOverridesDir()},
+
+		report: fs.Report,
 	}
 
 	for _, option := range options {
@@ -116,54 +145,311 @@ func WithResource(resource *resource.Resource) ScaffoldOption {
 	}
 }
 
-// Execute writes to disk the provided files
-func (s *Scaffold) Execute(builders ...Builder) error {
-	// Initialize the files
+// WithDryRun makes the Scaffold write to w a unified diff of the files that would be
+// created, overwritten or updated instead of writing them to disk
+func WithDryRun(w io.Writer) ScaffoldOption {
+	return func(s *Scaffold) {
+		s.dryRun = w
+	}
+}
+
+// WithChecksumsPath sets the file where the checksums of the scaffolded files are recorded.
+// An empty path disables recording checksums, so every existing file is considered modified.
+func WithChecksumsPath(path string) ScaffoldOption {
+	return func(s *Scaffold) {
+		s.checksumsPath = path
+	}
+}
+
+// WithBasesDir sets the directory where the contents of the scaffolded files are recorded, so that
+// later changes can be merged with the ones done by the user. They are not recorded by default.
+func WithBasesDir(dir string) ScaffoldOption {
+	return func(s *Scaffold) {
+		s.basesDir = dir
+	}
+}
+
+// WithTemplateOverrideDirs sets the directories where template overrides are looked for, in order.
+// No directory disables template overrides.
+func WithTemplateOverrideDirs(dirs ...string) ScaffoldOption {
+	return func(s *Scaffold) {
+		s.templateOverrideDirs = dirs
+	}
+}
+
+// WithFormatter sets the formatter of the files that match, which is either a file extension starting with
+// a dot, e.g. ".go", or a file name, e.g. "Makefile". It takes precedence over the project configuration.
+func WithFormatter(match string, formatter Formatter) ScaffoldOption {
+	return func(s *Scaffold) {
+		if s.formatterOverrides == nil {
+			s.formatterOverrides = make(map[string]Formatter)
+		}
+		s.formatterOverrides[match] = formatter
+	}
+}
+
+// WithParallelism sets the maximum number of templates rendered, and files formatted, concurrently.
+// Values lower than 2 render and format them one after another.
+func WithParallelism(parallelism int) ScaffoldOption {
+	return func(s *Scaffold) {
+		s.parallelism = parallelism
+	}
+}
+
+// Execute writes to disk the provided files and returns a report of what was done to each of them.
+// The report is also added to the one of the Filesystem, if any.
+//
+// Template builders are rendered concurrently, so they must not share mutable state. The rest of the
+// builders are applied in order once every template is rendered, and errors are reported as if the
+// builders were applied one after another.
+func (s *Scaffold) Execute(builders ...Builder) (ScaffoldReport, error) {
+	reports := make(fileReports, len(builders))
+	if err := s.execute(builders, reports); err != nil {
+		return ScaffoldReport{}, err
+	}
+
+	report := reports.report()
+	if s.report != nil {
+		s.report.Add(report)
+	}
+	return report, nil
+}
+
+// execute writes to disk the provided files, reporting what was done to each of them
+func (s *Scaffold) execute(builders []Builder, reports fileReports) error {
+	// Load the checksums of the previously scaffolded files
+	if s.checksumsPath != "" {
+		var err error
+		if s.checksums, err = loadChecksums(s.fs, s.checksumsPath); err != nil {
+			return err
+		}
+	} else {
+		s.checksums = &checksums{Files: make(map[string]string)}
+	}
+
+	// Initialize the files, and the ones to remove
 	files := make(map[string]*File, len(builders))
+	removed := make(map[string]bool)
 
-	for _, builder := range builders {
-		// Inject common fields
-		s.injector.injectInto(builder)
+	// Prepare every builder and render the templates concurrently, as they don't depend on each other
+	partials := s.templatePartials()
+	prepared := make([]preparedBuilder, len(builders))
+	forEach(len(builders), s.parallelism, func(i int) {
+		prepared[i] = s.prepareBuilder(builders[i], partials)
+	})
 
-		// Validate file builders
-		if reqValBuilder, requiresValidation := builder.(RequiresValidation); requiresValidation {
-			if err := reqValBuilder.Validate(); err != nil {
-				return ValidateError{err}
-			}
+	for i, builder := range builders {
+		if err := prepared[i].err; err != nil {
+			return err
 		}
 
 		// Build models for Template builders
 		if t, isTemplate := builder.(Template); isTemplate {
-			if err := s.buildFileModel(t, files); err != nil {
+			if err := s.buildFileModel(t, prepared[i], files, reports); err != nil {
 				return err
 			}
 		}
 
 		// Build models for Inserter builders
 		if i, isInserter := builder.(Inserter); isInserter {
-			if err := s.updateFileModel(i, files); err != nil {
+			if err := s.updateFileModel(i, files, reports); err != nil {
+				return err
+			}
+		}
+
+		// Build models for GoASTInserter builders
+		if i, isGoASTInserter := builder.(GoASTInserter); isGoASTInserter {
+			if err := s.updateGoFileModel(i, files, reports); err != nil {
+				return err
+			}
+		}
+
+		// Build models for YAMLInserter builders
+		if i, isYAMLInserter := builder.(YAMLInserter); isYAMLInserter {
+			if err := s.updateYAMLFileModel(i, files, reports); err != nil {
+				return err
+			}
+		}
+
+		// Drop models and mark files for removal for Remover builders
+		if r, isRemover := builder.(Remover); isRemover {
+			if err := s.removeFileModels(r, files, removed, reports); err != nil {
+				return err
+			}
+		}
+
+		// Update models for Ejector builders
+		if e, isEjector := builder.(Ejector); isEjector {
+			if err := s.ejectFromFileModel(e, files, removed); err != nil {
 				return err
 			}
 		}
+
+		// Build models and mark files for removal for Mover builders
+		if m, isMover := builder.(Mover); isMover {
+			if err := s.moveFileModels(m, files, removed, reports); err != nil {
+				return err
+			}
+		}
+
+		// Files built after being removed are kept
+		if _, found := files[builder.GetPath()]; found {
+			delete(removed, builder.GetPath())
+		}
+	}
+
+	// Format every file once, after every builder was applied
+	formatters, err := s.formatters()
+	if err != nil {
+		return err
+	}
+	if err := formatModels(files, formatters, s.parallelism); err != nil {
+		return err
+	}
+
+	// Check every file before persisting any of them, so that a failure doesn't leave them half-written
+	pending, err := s.pendingFiles(files, reports)
+	if err != nil {
+		return err
+	}
+
+	// Report the changes instead of persisting them if requested
+	if s.dryRun != nil {
+		if err := s.writeDiff(pending); err != nil {
+			return err
+		}
+		return s.writeRemovalDiff(removed)
 	}
 
 	// Persist the files to disk
-	for _, f := range files {
+	for _, f := range pending {
 		if err := s.writeFile(f); err != nil {
 			return err
 		}
 	}
+	if err := s.removeFiles(removed); err != nil {
+		return err
+	}
+
+	return s.record(files, pending, removed)
+}
+
+// record stores the checksums and contents of the scaffolded files that were written, and forgets the ones
+// of the removed files. Sidecar files are not recorded and merged files are recorded with their scaffolded contents.
+func (s Scaffold) record(files map[string]*File, written []*File, removed map[string]bool) error {
+	if s.checksumsPath == "" {
+		return nil
+	}
+
+	for path := range removed {
+		delete(s.checksums.Files, path)
+		if s.basesDir != "" {
+			base := filepath.Join(s.basesDir, path)
+			if err := s.removeFile(base); err != nil {
+				return err
+			}
+			if err := s.removeEmptyDirectories(filepath.Dir(base)); err != nil {
+				return err
+			}
+		}
+	}
 
-	return nil
+	for _, f := range written {
+		scaffolded, found := files[f.Path]
+		if !found {
+			continue
+		}
+
+		s.checksums.Files[f.Path] = checksum(scaffolded.Contents)
+		if s.basesDir != "" {
+			base := &File{Path: filepath.Join(s.basesDir, f.Path), Contents: scaffolded.Contents}
+			if err := s.writeFile(base); err != nil {
+				return err
+			}
+		}
+	}
+
+	return s.checksums.save(s)
+}
+
+// preparedBuilder is a builder whose common fields were injected and which was validated and, if it is a
+// template, rendered
+type preparedBuilder struct {
+	// err is the error returned while injecting the common fields, validating or setting the template defaults
+	err error
+
+	// contents are the rendered template, and renderErr the error returned while rendering it
+	contents  []byte
+	renderErr error
+}
+
+// prepareBuilder injects the common fields into a builder, validates it, and renders it if it is a template.
+// Rendering errors are kept apart, as they are ignored for templates that end up being skipped.
+// The builders wrapped by Ejector builders and by Remove are set up the same way, but never rendered.
+func (s Scaffold) prepareBuilder(builder Builder, partials map[string]string) (p preparedBuilder) {
+	switch b := builder.(type) {
+	case Ejector:
+		for _, inserter := range append([]Builder{b.GetInserter()}, b.GetKeptInserters()...) {
+			if p.err = s.setUpBuilder(inserter); p.err != nil {
+				return p
+			}
+		}
+		return p
+	case remover:
+		p.err = s.setUpBuilder(b.builder)
+		return p
+	}
+
+	if p.err = s.setUpBuilder(builder); p.err != nil {
+		return p
+	}
+
+	t, isTemplate := builder.(Template)
+	if !isTemplate {
+		return p
+	}
+
+	// Use the template override if any
+	body, found, err := s.templateOverride(t)
+	if err != nil {
+		p.renderErr = err
+		return p
+	}
+	if !found {
+		body = t.GetBody()
+	}
+
+	p.contents, p.renderErr = doTemplate(t, body, partials)
+	return p
 }
 
-// buildFileModel scaffolds a single file
-func (Scaffold) buildFileModel(t Template, models map[string]*File) error {
+// setUpBuilder injects the common fields into a builder, validates it, and sets its default values if it is a
+// template
+func (s Scaffold) setUpBuilder(builder Builder) error {
+	// Inject common fields
+	if err := s.injector.injectInto(builder); err != nil {
+		return err
+	}
+
+	// Validate file builders
+	if reqValBuilder, requiresValidation := builder.(RequiresValidation); requiresValidation {
+		if err := reqValBuilder.Validate(); err != nil {
+			return ValidateError{err}
+		}
+	}
+
 	// Set the template default values
-	if err := t.SetTemplateDefaults(); err != nil {
-		return SetTemplateDefaultsError{err}
+	if t, isTemplate := builder.(Template); isTemplate {
+		if err := t.SetTemplateDefaults(); err != nil {
+			return SetTemplateDefaultsError{err}
+		}
 	}
 
+	return nil
+}
+
+// buildFileModel scaffolds a single file from its prepared template
+func (s Scaffold) buildFileModel(t Template, p preparedBuilder, models map[string]*File, reports fileReports) error {
 	path := t.GetPath()
 
 	// Handle already existing models
@@ -173,27 +459,28 @@ func (Scaffold) buildFileModel(t Template, models map[string]*File) error {
 			return nil
 		case Error:
 			return ModelAlreadyExistsError{path}
-		case OverwriteFile:
+		case OverwriteFile, OverwriteIfUnmodified, WriteSidecarIfModified, MergeFile:
 		default:
 			return UnknownIfExistsActionError{path, t.GetIfExistsAction()}
 		}
 	}
 
-	b, err := doTemplate(t)
-	if err != nil {
-		return err
+	if p.renderErr != nil {
+		return p.renderErr
 	}
 
 	models[path] = &File{
 		Path:           path,
-		Contents:       string(b),
+		Contents:       string(p.contents),
 		IfExistsAction: t.GetIfExistsAction(),
 	}
+	reports.get(path).scaffolded = true
 	return nil
 }
 
-// doTemplate executes the template for a file using the input
-func doTemplate(t Template) ([]byte, error) {
+// doTemplate executes the template body for a file using the input. Templates that execute a partial
+// which is neither defined by the body nor provided are reported as validation errors before executing them.
+func doTemplate(t Template, body string, partials map[string]string) ([]byte, error) {
 	// Create a new template.Template using the type of the Template as the name
 	temp := template.New(fmt.Sprintf("%T", t))
 	leftDelim, rightDelim := t.GetDelim()
@@ -209,31 +496,28 @@ func doTemplate(t Template) ([]byte, error) {
 	temp.Funcs(fm)
 
 	// Set the template body
-	if _, err := temp.Parse(t.GetBody()); err != nil {
+	if _, err := temp.Parse(body); err != nil {
+		return nil, err
+	}
+
+	// Add the partials and check that none is missing
+	if err := addPartials(temp, fm, partials); err != nil {
 		return nil, err
 	}
+	if err := checkPartials(t.GetPath(), temp); err != nil {
+		return nil, ValidateError{err}
+	}
 
 	// Execute the template
 	out := &bytes.Buffer{}
 	if err := temp.Execute(out, t); err != nil {
 		return nil, err
 	}
-	b := out.Bytes()
-
-	// TODO(adirio): move go-formatting to write step
-	// gofmt the imports
-	if filepath.Ext(t.GetPath()) == ".go" {
-		var err error
-		if b, err = imports.Process(t.GetPath(), b, &options); err != nil {
-			return nil, err
-		}
-	}
-
-	return b, nil
+	return out.Bytes(), nil
 }
 
 // updateFileModel updates a single file
-func (s Scaffold) updateFileModel(i Inserter, models map[string]*File) error {
+func (s Scaffold) updateFileModel(i Inserter, models map[string]*File, reports fileReports) error {
 	m, err := s.loadPreviousModel(i, models)
 	if err != nil {
 		return err
@@ -241,6 +525,7 @@ func (s Scaffold) updateFileModel(i Inserter, models map[string]*File) error {
 
 	// Get valid code fragments
 	codeFragments := getValidCodeFragments(i)
+	fragments := countCodeFragments(codeFragments)
 
 	// Remove code fragments that already were applied
 	err = filterExistingValues(m.Contents, codeFragments)
@@ -248,33 +533,108 @@ func (s Scaffold) updateFileModel(i Inserter, models map[string]*File) error {
 		return err
 	}
 
+	report := reports.get(m.Path)
+	report.SkippedFragments += fragments - countCodeFragments(codeFragments)
+
 	// If no code fragment to insert, we are done
 	if len(codeFragments) == 0 {
 		return nil
 	}
 
+	for marker := range codeFragments {
+		if value := marker.prefix + marker.value; !slices.Contains(report.Markers, value) {
+			report.Markers = append(report.Markers, value)
+		}
+	}
+	sort.Strings(report.Markers)
+
 	content, err := insertStrings(m.Contents, codeFragments)
 	if err != nil {
 		return err
 	}
 
-	// TODO(adirio): move go-formatting to write step
-	formattedContent := content
-	if ext := filepath.Ext(i.GetPath()); ext == ".go" {
-		formattedContent, err = imports.Process(i.GetPath(), content, nil)
+	m.Contents = string(content)
+	setUpdated(m)
+	models[m.Path] = m
+	return nil
+}
+
+// updateGoFileModel updates a single Go file by editing its syntax tree
+func (s Scaffold) updateGoFileModel(i GoASTInserter, models map[string]*File, reports fileReports) error {
+	m, err := s.loadPreviousModel(i, models)
+	if err != nil {
+		return err
+	}
+
+	report := reports.get(m.Path)
+	content := []byte(m.Contents)
+	for _, edit := range i.GetGoEdits() {
+		edited, err := edit.apply(m.Path, content)
+		if err != nil {
+			return err
+		}
+		// Edits that don't change the file were already applied
+		if bytes.Equal(edited, content) {
+			report.SkippedFragments++
+		}
+		content = edited
+	}
+
+	// If no edit changed the file, we are done
+	if string(content) == m.Contents {
+		return nil
+	}
+
+	m.Contents = string(content)
+	setUpdated(m)
+	models[m.Path] = m
+	return nil
+}
+
+// updateYAMLFileModel updates a single YAML file by editing its node tree
+func (s Scaffold) updateYAMLFileModel(i YAMLInserter, models map[string]*File, reports fileReports) error {
+	m, err := s.loadPreviousModel(i, models)
+	if err != nil {
+		return err
+	}
+
+	report := reports.get(m.Path)
+	content := []byte(m.Contents)
+	for _, edit := range i.GetYAMLEdits() {
+		edited, err := edit.apply(m.Path, content)
 		if err != nil {
 			return err
 		}
+		// Edits that don't change the file were already applied
+		if bytes.Equal(edited, content) {
+			report.SkippedFragments++
+		}
+		content = edited
 	}
 
-	m.Contents = string(formattedContent)
-	m.IfExistsAction = OverwriteFile
+	// If no edit changed the file, we are done
+	if string(content) == m.Contents {
+		return nil
+	}
+
+	m.Contents = string(content)
+	setUpdated(m)
 	models[m.Path] = m
 	return nil
 }
 
+// setUpdated sets the behavior of an updated model when its file already exists.
+// Models that protect modified files keep their behavior, the rest need to overwrite previous files.
+func setUpdated(m *File) {
+	switch m.IfExistsAction {
+	case OverwriteIfUnmodified, WriteSidecarIfModified, MergeFile:
+	default:
+		m.IfExistsAction = OverwriteFile
+	}
+}
+
 // loadPreviousModel gets the previous model from the models map or the actual file
-func (s Scaffold) loadPreviousModel(i Inserter, models map[string]*File) (*File, error) {
+func (s Scaffold) loadPreviousModel(i Builder, models map[string]*File) (*File, error) {
 	path := i.GetPath()
 
 	// Lets see if we already have a model for this file
@@ -302,8 +662,8 @@ func (s Scaffold) loadPreviousModel(i Inserter, models map[string]*File) (*File,
 		case Error:
 			// Writing will result in an error, so we can return error now
 			return nil, FileAlreadyExistsError{path}
-		case OverwriteFile:
-			// Model has preference
+		case OverwriteFile, OverwriteIfUnmodified, WriteSidecarIfModified, MergeFile:
+			// Model has preference, modified files will be checked when writing
 			return m, nil
 		default:
 			return nil, UnknownIfExistsActionError{path, m.IfExistsAction}
@@ -357,6 +717,15 @@ func getValidCodeFragments(i Inserter) CodeFragmentsMap {
 	return codeFragments
 }
 
+// countCodeFragments returns the number of code fragments of every marker
+func countCodeFragments(codeFragmentsMap CodeFragmentsMap) int {
+	count := 0
+	for _, codeFragments := range codeFragmentsMap {
+		count += len(codeFragments)
+	}
+	return count
+}
+
 // filterExistingValues removes code fragments that already exist in the content.
 func filterExistingValues(content string, codeFragmentsMap CodeFragmentsMap) error {
 	for marker, codeFragments := range codeFragmentsMap {
@@ -472,25 +841,130 @@ func insertStrings(content string, codeFragmentsMap CodeFragmentsMap) ([]byte, e
 	return out.Bytes(), nil
 }
 
-func (s Scaffold) writeFile(f *File) (err error) {
-	// Check if the file to write already exists
+// fileToWrite checks if the file already exists and returns the file that has to be written according to
+// its IfExistsAction, or nil if it doesn't have to be written. The action is reported to report.
+func (s Scaffold) fileToWrite(f *File, report *FileReport) (*File, error) {
 	exists, err := afero.Exists(s.fs, f.Path)
 	if err != nil {
-		return ExistsFileError{err}
-	}
-	if exists {
+		return nil, ExistsFileError{err}
+	}
+	if !exists {
+		report.Action = FileCreated
+		return f, nil
+	}
+
+	// Existing files are either overwritten by a template or updated by inserting code into them
+	report.Action = FileOverwritten
+	if !report.scaffolded {
+		report.Action = FileUpdated
+	}
+
+	switch f.IfExistsAction {
+	case OverwriteFile:
+		// The file is written as if it didn't exist
+		return f, nil
+	case SkipFile:
+		// The file is not written but the process will carry on
+		report.Action = FileSkipped
+		return nil, nil
+	case Error:
+		// The file is not written and the process will fail
+		return nil, FileAlreadyExistsError{f.Path}
+	case OverwriteIfUnmodified, WriteSidecarIfModified, MergeFile:
+		current, err := s.loadModelFromFile(f.Path)
+		if err != nil {
+			return nil, err
+		}
+		// Unmodified files, and files that already have the new contents, are written as if they didn't exist
+		if s.checksums.isUnmodified(f.Path, current.Contents) || current.Contents == f.Contents {
+			return f, nil
+		}
+		// Modified files are either kept, failing the process, kept along with a sidecar file, or merged
 		switch f.IfExistsAction {
-		case OverwriteFile:
-			// By not returning, the file is written as if it didn't exist
-		case SkipFile:
-			// By returning nil, the file is not written but the process will carry on
-			return nil
-		case Error:
-			// By returning an error, the file is not written and the process will fail
-			return FileAlreadyExistsError{f.Path}
+		case OverwriteIfUnmodified:
+			return nil, ModifiedFileError{f.Path}
+		case WriteSidecarIfModified:
+			report.Action = FileSidecar
+			report.Sidecar = f.Path + sidecarSuffix
+			return &File{Path: report.Sidecar, Contents: f.Contents, IfExistsAction: OverwriteFile}, nil
+		default:
+			merged, conflict, err := s.merge(f, current.Contents)
+			report.Action = FileMerged
+			report.Conflict = conflict
+			return merged, err
+		}
+	default:
+		return nil, UnknownIfExistsActionError{f.Path, f.IfExistsAction}
+	}
+}
+
+// merge returns the file resulting from merging the changes done to its current contents since it was
+// scaffolded with its new contents, and whether any conflict was found. Files without recorded contents
+// are merged as if they were empty.
+func (s Scaffold) merge(f *File, current string) (*File, bool, error) {
+	var base string
+	if s.basesDir != "" {
+		var err error
+		if base, _, err = readIfExists(s.fs, filepath.Join(s.basesDir, f.Path)); err != nil {
+			return nil, false, err
+		}
+	}
+
+	merged, conflict := Merge(base, current, f.Contents)
+	if conflict {
+		log.Warnf("Merging %s resulted in conflicts, resolve them before continuing", f.Path)
+	}
+	return &File{Path: f.Path, Contents: merged, IfExistsAction: OverwriteFile}, conflict, nil
+}
+
+// pendingFiles returns the files that have to be written, sorted by path, and reports what will be done
+// to each of them. Files whose code fragments were already present are reported as unchanged.
+func (s Scaffold) pendingFiles(files map[string]*File, reports fileReports) ([]*File, error) {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	pending := make([]*File, 0, len(files))
+	for _, path := range paths {
+		report := reports.get(path)
+		f, err := s.fileToWrite(files[path], report)
+		if err != nil {
+			return nil, err
+		}
+		if f != nil {
+			report.Bytes = len(f.Contents)
+			pending = append(pending, f)
+		}
+	}
+
+	for _, report := range reports {
+		if report.Action == "" {
+			report.Action = FileUnchanged
 		}
 	}
 
+	return pending, nil
+}
+
+// writeDiff writes the unified diff of the files to the dry-run writer
+func (s Scaffold) writeDiff(files []*File) error {
+	for _, f := range files {
+		oldContents, existed, err := readIfExists(s.fs, f.Path)
+		if err != nil {
+			return err
+		}
+
+		if _, err := io.WriteString(s.dryRun, UnifiedDiff(f.Path, oldContents, f.Contents, existed)); err != nil {
+			return WriteFileError{err}
+		}
+	}
+
+	return nil
+}
+
+func (s Scaffold) writeFile(f *File) (err error) {
 	// Create the directory if needed
 	if err := s.fs.MkdirAll(filepath.Dir(f.Path), s.dirPerm); err != nil {
 		return CreateDirectoryError{err}
@@ -15,8 +15,12 @@ limitations under the License.
 package machinery
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
+	"testing"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -104,6 +108,30 @@ var _ = Describe("Scaffold", func() {
 			Expect(s.injector.resource).NotTo(BeNil())
 			Expect(s.injector.resource.GVK.IsEqualTo(res.GVK)).To(BeTrue())
 		})
+
+		It("should succeed with dry-run option", func() {
+			out := &bytes.Buffer{}
+
+			s := NewScaffold(Filesystem{FS: afero.NewMemMapFs()}, WithDryRun(out))
+			Expect(s.fs).NotTo(BeNil())
+			Expect(s.dryRun).To(BeIdenticalTo(out))
+		})
+
+		It("should succeed with checksums path option", func() {
+			const checksumsPath = "checksums"
+
+			s := NewScaffold(Filesystem{FS: afero.NewMemMapFs()}, WithChecksumsPath(checksumsPath))
+			Expect(s.fs).NotTo(BeNil())
+			Expect(s.checksumsPath).To(Equal(checksumsPath))
+			Expect(s.basesDir).To(BeEmpty())
+		})
+
+		It("should succeed with bases directory option", func() {
+			s := NewScaffold(Filesystem{FS: afero.NewMemMapFs()}, WithBasesDir(DefaultBasesDir))
+			Expect(s.fs).NotTo(BeNil())
+			Expect(s.checksumsPath).To(Equal(DefaultChecksumsPath))
+			Expect(s.basesDir).To(Equal(DefaultBasesDir))
+		})
 	})
 
 	Describe("Scaffold.Execute", func() {
@@ -126,7 +154,7 @@ var _ = Describe("Scaffold", func() {
 
 		DescribeTable("successes",
 			func(path, expected string, files ...Builder) {
-				Expect(s.Execute(files...)).To(Succeed())
+				Expect(s.Execute(files...)).Error().To(Succeed())
 
 				b, err := afero.ReadFile(s.fs, path)
 				Expect(err).NotTo(HaveOccurred())
@@ -165,7 +193,7 @@ var _ = Describe("Scaffold", func() {
 
 		DescribeTable("file builders related errors",
 			func(errType interface{}, files ...Builder) {
-				err := s.Execute(files...)
+				_, err := s.Execute(files...)
 				Expect(err).To(HaveOccurred())
 				Expect(errors.As(err, errType)).To(BeTrue())
 			},
@@ -192,7 +220,7 @@ var _ = Describe("Scaffold", func() {
 		// Following errors are unwrapped, so we need to check for substrings
 		DescribeTable("template related errors",
 			func(errMsg string, files ...Builder) {
-				err := s.Execute(files...)
+				_, err := s.Execute(files...)
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring(errMsg))
 			},
@@ -214,12 +242,48 @@ var _ = Describe("Scaffold", func() {
 			func(path, input, expected string, files ...Builder) {
 				Expect(afero.WriteFile(s.fs, path, []byte(input), 0o666)).To(Succeed())
 
-				Expect(s.Execute(files...)).To(Succeed())
+				Expect(s.Execute(files...)).Error().To(Succeed())
 
 				b, err := afero.ReadFile(s.fs, path)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(string(b)).To(Equal(expected))
 			},
+			Entry("should edit the syntax tree of go files",
+				pathGo,
+				`package test
+
+func f() {
+	a()
+}
+`,
+				`package test
+
+import "fmt"
+
+func f() {
+	a()
+	fmt.Println()
+}
+`,
+				fakeGoASTInserter{
+					fakeBuilder: fakeBuilder{path: pathGo},
+					edits: []GoEdit{
+						GoImport{Path: "fmt"},
+						GoStatements{Func: "f", Code: "a()\nfmt.Println()"},
+					},
+				},
+			),
+			Entry("should edit the node tree of yaml files",
+				pathYaml,
+				"resources:\n- a.yaml # a\n",
+				"resources:\n- a.yaml # a\n- b.yaml\n",
+				fakeYAMLInserter{
+					fakeBuilder: fakeBuilder{path: pathYaml},
+					edits: []YAMLEdit{
+						YAMLAppend{Path: []string{"resources"}, Values: []interface{}{"a.yaml", "b.yaml"}},
+					},
+				},
+			),
 			Entry("should insert lines for go files",
 				pathGo,
 				`package test
@@ -407,7 +471,7 @@ func init() {
 			func(errType interface{}, files ...Builder) {
 				Expect(afero.WriteFile(s.fs, path, []byte{}, 0o666)).To(Succeed())
 
-				err := s.Execute(files...)
+				_, err := s.Execute(files...)
 				Expect(err).To(HaveOccurred())
 				Expect(errors.As(err, errType)).To(BeTrue())
 			},
@@ -432,7 +496,7 @@ func init() {
 				Expect(s.Execute(&fakeTemplate{
 					fakeBuilder: fakeBuilder{path: path},
 					body:        content,
-				})).To(Succeed())
+				})).Error().To(Succeed())
 
 				b, err := afero.ReadFile(s.fs, path)
 				Expect(err).NotTo(HaveOccurred())
@@ -443,7 +507,7 @@ func init() {
 				Expect(s.Execute(&fakeTemplate{
 					fakeBuilder: fakeBuilder{path: path, ifExistsAction: OverwriteFile},
 					body:        content,
-				})).To(Succeed())
+				})).Error().To(Succeed())
 
 				b, err := afero.ReadFile(s.fs, path)
 				Expect(err).NotTo(HaveOccurred())
@@ -451,17 +515,803 @@ func init() {
 			})
 
 			It("should error if configured to do so", func() {
-				err := s.Execute(&fakeTemplate{
+				_, err := s.Execute(&fakeTemplate{
 					fakeBuilder: fakeBuilder{path: path, ifExistsAction: Error},
 					body:        content,
 				})
 				Expect(err).To(HaveOccurred())
 				Expect(errors.As(err, &FileAlreadyExistsError{})).To(BeTrue())
 			})
+
+			It("should not write any file if one of them errors", func() {
+				_, err := s.Execute(
+					&fakeTemplate{fakeBuilder: fakeBuilder{path: pathGo}, body: "package file"},
+					&fakeTemplate{fakeBuilder: fakeBuilder{path: path, ifExistsAction: Error}, body: content},
+				)
+				Expect(err).To(HaveOccurred())
+				Expect(errors.As(err, &FileAlreadyExistsError{})).To(BeTrue())
+				Expect(afero.Exists(s.fs, pathGo)).To(BeFalse())
+			})
+		})
+
+		Context("dry run", func() {
+			var out *bytes.Buffer
+
+			BeforeEach(func() {
+				out = &bytes.Buffer{}
+				s.dryRun = out
+				Expect(afero.WriteFile(s.fs, pathYaml, []byte("a\n# +kubebuilder:scaffold:-\n"), 0o666)).To(Succeed())
+			})
+
+			It("should report the changes without writing them", func() {
+				Expect(s.Execute(
+					&fakeTemplate{fakeBuilder: fakeBuilder{path: path}, body: content},
+					&fakeTemplate{fakeBuilder: fakeBuilder{path: pathYaml}, body: content},
+					fakeInserter{
+						fakeBuilder: fakeBuilder{path: pathYaml},
+						codeFragments: CodeFragmentsMap{
+							NewMarkerFor(pathYaml, "-"): {"b\n"},
+						},
+					},
+				)).Error().To(Succeed())
+
+				Expect(out.String()).To(Equal(
+					"--- /dev/null\n+++ b/filename\n@@ -0,0 +1 @@\n+Hello world!\n\\ No newline at end of file\n" +
+						"--- a/filename.yaml\n+++ b/filename.yaml\n@@ -1,2 +1,3 @@\n a\n+b\n # +kubebuilder:scaffold:-\n",
+				))
+
+				Expect(afero.Exists(s.fs, path)).To(BeFalse())
+				b, err := afero.ReadFile(s.fs, pathYaml)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(b)).To(Equal("a\n# +kubebuilder:scaffold:-\n"))
+			})
+
+			It("should fail if a file would fail to be written", func() {
+				_, err := s.Execute(&fakeTemplate{
+					fakeBuilder: fakeBuilder{path: pathYaml, ifExistsAction: Error},
+					body:        content,
+				})
+				Expect(err).To(HaveOccurred())
+				Expect(errors.As(err, &FileAlreadyExistsError{})).To(BeTrue())
+				Expect(out.String()).To(BeEmpty())
+			})
+		})
+
+		Context("checksums", func() {
+			const modified = "modified"
+
+			BeforeEach(func() {
+				s.checksumsPath = DefaultChecksumsPath
+				Expect(s.Execute(&fakeTemplate{fakeBuilder: fakeBuilder{path: path}, body: content})).Error().To(Succeed())
+			})
+
+			It("should record the checksums of the scaffolded files", func() {
+				c, err := loadChecksums(s.fs, DefaultChecksumsPath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(c.Files).To(Equal(map[string]string{path: checksum(content)}))
+			})
+
+			DescribeTable("should overwrite unmodified files",
+				func(action IfExistsAction) {
+					Expect(s.Execute(&fakeTemplate{
+						fakeBuilder: fakeBuilder{path: path, ifExistsAction: action},
+						body:        "new",
+					})).Error().To(Succeed())
+
+					b, err := afero.ReadFile(s.fs, path)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(b)).To(Equal("new"))
+					Expect(afero.Exists(s.fs, path+sidecarSuffix)).To(BeFalse())
+
+					c, err := loadChecksums(s.fs, DefaultChecksumsPath)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(c.Files).To(HaveKeyWithValue(path, checksum("new")))
+				},
+				Entry("if asked to overwrite if unmodified", OverwriteIfUnmodified),
+				Entry("if asked to write a sidecar if modified", WriteSidecarIfModified),
+			)
+
+			It("should fail to overwrite modified files if asked to overwrite if unmodified", func() {
+				Expect(afero.WriteFile(s.fs, path, []byte(modified), 0o666)).To(Succeed())
+
+				_, err := s.Execute(&fakeTemplate{
+					fakeBuilder: fakeBuilder{path: path, ifExistsAction: OverwriteIfUnmodified},
+					body:        "new",
+				})
+				Expect(err).To(HaveOccurred())
+				Expect(errors.As(err, &ModifiedFileError{})).To(BeTrue())
+
+				b, err := afero.ReadFile(s.fs, path)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(b)).To(Equal(modified))
+			})
+
+			It("should write a sidecar file for modified files if asked to", func() {
+				Expect(afero.WriteFile(s.fs, path, []byte(modified), 0o666)).To(Succeed())
+
+				Expect(s.Execute(&fakeTemplate{
+					fakeBuilder: fakeBuilder{path: path, ifExistsAction: WriteSidecarIfModified},
+					body:        "new",
+				})).Error().To(Succeed())
+
+				b, err := afero.ReadFile(s.fs, path)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(b)).To(Equal(modified))
+				b, err = afero.ReadFile(s.fs, path+sidecarSuffix)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(b)).To(Equal("new"))
+
+				c, err := loadChecksums(s.fs, DefaultChecksumsPath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(c.Files).To(HaveKeyWithValue(path, checksum(content)))
+				Expect(c.Files).NotTo(HaveKey(path + sidecarSuffix))
+			})
+
+			It("should merge modified files if asked to", func() {
+				s.basesDir = DefaultBasesDir
+				Expect(s.Execute(&fakeTemplate{
+					fakeBuilder: fakeBuilder{path: pathYaml},
+					body:        "a\nb\nc\nd\ne\n",
+				})).Error().To(Succeed())
+				Expect(afero.WriteFile(s.fs, pathYaml, []byte("A\nb\nc\nd\ne\n"), 0o666)).To(Succeed())
+
+				Expect(s.Execute(&fakeTemplate{
+					fakeBuilder: fakeBuilder{path: pathYaml, ifExistsAction: MergeFile},
+					body:        "a\nb\nc\nd\nE\n",
+				})).Error().To(Succeed())
+
+				b, err := afero.ReadFile(s.fs, pathYaml)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(b)).To(Equal("A\nb\nc\nd\nE\n"))
+
+				b, err = afero.ReadFile(s.fs, filepath.Join(DefaultBasesDir, pathYaml))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(b)).To(Equal("a\nb\nc\nd\nE\n"))
+
+				c, err := loadChecksums(s.fs, DefaultChecksumsPath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(c.Files).To(HaveKeyWithValue(pathYaml, checksum("a\nb\nc\nd\nE\n")))
+			})
+
+			It("should not overwrite files without a recorded checksum if asked to overwrite if unmodified", func() {
+				Expect(afero.WriteFile(s.fs, pathYaml, []byte(modified), 0o666)).To(Succeed())
+
+				_, err := s.Execute(&fakeTemplate{
+					fakeBuilder: fakeBuilder{path: pathYaml, ifExistsAction: OverwriteIfUnmodified},
+					body:        content,
+				})
+				Expect(err).To(HaveOccurred())
+				Expect(errors.As(err, &ModifiedFileError{})).To(BeTrue())
+
+				b, err := afero.ReadFile(s.fs, pathYaml)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(b)).To(Equal(modified))
+			})
+
+			It("should write a sidecar for files without a recorded checksum if asked to", func() {
+				Expect(afero.WriteFile(s.fs, pathYaml, []byte(modified), 0o666)).To(Succeed())
+
+				Expect(s.Execute(&fakeTemplate{
+					fakeBuilder: fakeBuilder{path: pathYaml, ifExistsAction: WriteSidecarIfModified},
+					body:        content,
+				})).Error().To(Succeed())
+
+				b, err := afero.ReadFile(s.fs, pathYaml)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(b)).To(Equal(modified))
+				b, err = afero.ReadFile(s.fs, pathYaml+sidecarSuffix)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(b)).To(Equal(content))
+			})
+
+			It("should overwrite files without a recorded checksum that already have the new contents", func() {
+				Expect(afero.WriteFile(s.fs, pathYaml, []byte(content), 0o666)).To(Succeed())
+
+				Expect(s.Execute(&fakeTemplate{
+					fakeBuilder: fakeBuilder{path: pathYaml, ifExistsAction: WriteSidecarIfModified},
+					body:        content,
+				})).Error().To(Succeed())
+
+				Expect(afero.Exists(s.fs, pathYaml+sidecarSuffix)).To(BeFalse())
+				c, err := loadChecksums(s.fs, DefaultChecksumsPath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(c.Files).To(HaveKeyWithValue(pathYaml, checksum(content)))
+			})
+		})
+
+		Context("report", func() {
+			It("should report what was done to every file", func() {
+				Expect(afero.WriteFile(s.fs, path, []byte(content), 0o666)).To(Succeed())
+				Expect(afero.WriteFile(s.fs, pathGo, []byte("package file\n// +kubebuilder:scaffold:-\n"), 0o666)).To(Succeed())
+				Expect(afero.WriteFile(s.fs, pathYaml, []byte("a: b\n# +kubebuilder:scaffold:-\n"), 0o666)).To(Succeed())
+
+				report, err := s.Execute(
+					&fakeTemplate{fakeBuilder: fakeBuilder{path: "new"}, body: content},
+					&fakeTemplate{fakeBuilder: fakeBuilder{path: path}, body: "skipped"},
+					fakeInserter{
+						fakeBuilder: fakeBuilder{path: pathGo},
+						codeFragments: CodeFragmentsMap{
+							NewMarkerFor(pathGo, "-"): {"var a int\n", "package file\n"},
+						},
+					},
+					fakeInserter{
+						fakeBuilder: fakeBuilder{path: pathYaml},
+						codeFragments: CodeFragmentsMap{
+							NewMarkerFor(pathYaml, "-"): {"a: b\n"},
+						},
+					},
+				)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(report.Files).To(Equal([]FileReport{
+					{Action: FileSkipped, Path: path, scaffolded: true},
+					{
+						Action:           FileUpdated,
+						Path:             pathGo,
+						Bytes:            len("package file\n\nvar a int\n\n// +kubebuilder:scaffold:-\n"),
+						Markers:          []string{"+kubebuilder:scaffold:-"},
+						SkippedFragments: 1,
+					},
+					{Action: FileUnchanged, Path: pathYaml, SkippedFragments: 1},
+					{Action: FileCreated, Path: "new", Bytes: len(content), scaffolded: true},
+				}))
+			})
+
+			It("should report the file written for modified files", func() {
+				s.checksumsPath = DefaultChecksumsPath
+				Expect(s.Execute(&fakeTemplate{fakeBuilder: fakeBuilder{path: path}, body: content})).Error().To(Succeed())
+				Expect(afero.WriteFile(s.fs, path, []byte("modified"), 0o666)).To(Succeed())
+
+				report, err := s.Execute(&fakeTemplate{
+					fakeBuilder: fakeBuilder{path: path, ifExistsAction: WriteSidecarIfModified},
+					body:        "new",
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(report.Files).To(Equal([]FileReport{{
+					Action:     FileSidecar,
+					Path:       path,
+					Sidecar:    path + sidecarSuffix,
+					Bytes:      len("new"),
+					scaffolded: true,
+				}}))
+			})
+
+			It("should aggregate the reports in the Filesystem", func() {
+				fsReport := &ScaffoldReport{}
+				s = NewScaffold(Filesystem{FS: afero.NewMemMapFs(), Report: fsReport}, WithChecksumsPath(""))
+
+				Expect(s.Execute(&fakeTemplate{fakeBuilder: fakeBuilder{path: pathGo}, body: "package file\n"})).
+					Error().To(Succeed())
+				Expect(s.Execute(&fakeTemplate{fakeBuilder: fakeBuilder{path: path}, body: content})).
+					Error().To(Succeed())
+
+				Expect(fsReport.Files).To(HaveLen(2))
+				Expect(fsReport.Files[0].Path).To(Equal(path))
+				Expect(fsReport.Files[1].Path).To(Equal(pathGo))
+			})
+		})
+
+		Context("remove", func() {
+			BeforeEach(func() {
+				Expect(afero.WriteFile(s.fs, filepath.Join("dir", "a"), []byte(content), 0o666)).To(Succeed())
+				Expect(afero.WriteFile(s.fs, filepath.Join("dir", "sub", "b"), []byte(content), 0o666)).To(Succeed())
+			})
+
+			It("should remove a file", func() {
+				report, err := s.Execute(fakeRemover{fakeBuilder: fakeBuilder{path: filepath.Join("dir", "a")}})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(report.Files).To(Equal([]FileReport{{Action: FileRemoved, Path: filepath.Join("dir", "a")}}))
+
+				Expect(afero.Exists(s.fs, filepath.Join("dir", "a"))).To(BeFalse())
+				Expect(afero.Exists(s.fs, filepath.Join("dir", "sub", "b"))).To(BeTrue())
+			})
+
+			It("should remove a directory and every file in it", func() {
+				Expect(s.Execute(fakeRemover{fakeBuilder: fakeBuilder{path: "dir"}})).Error().To(Succeed())
+
+				Expect(afero.Exists(s.fs, "dir")).To(BeFalse())
+			})
+
+			It("should drop the models of the removed files", func() {
+				Expect(s.Execute(
+					&fakeTemplate{fakeBuilder: fakeBuilder{path: path}, body: content},
+					fakeRemover{fakeBuilder: fakeBuilder{path: path}},
+				)).Error().To(Succeed())
+
+				Expect(afero.Exists(s.fs, path)).To(BeFalse())
+			})
+
+			It("should keep the files built after being removed", func() {
+				Expect(s.Execute(
+					fakeRemover{fakeBuilder: fakeBuilder{path: filepath.Join("dir", "a")}},
+					&fakeTemplate{
+						fakeBuilder: fakeBuilder{path: filepath.Join("dir", "a"), ifExistsAction: OverwriteFile},
+						body:        "new",
+					},
+				)).Error().To(Succeed())
+
+				b, err := afero.ReadFile(s.fs, filepath.Join("dir", "a"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(b)).To(Equal("new"))
+			})
+
+			It("should fail for missing files unless they are optional", func() {
+				_, err := s.Execute(fakeRemover{fakeBuilder: fakeBuilder{path: path}})
+				Expect(err).To(HaveOccurred())
+				Expect(errors.As(err, &FileNotFoundError{})).To(BeTrue())
+
+				Expect(s.Execute(fakeRemover{fakeBuilder: fakeBuilder{path: path}, optional: true})).Error().To(Succeed())
+			})
+
+			It("should remove the files scaffolded by a template, ignoring missing ones", func() {
+				Expect(afero.WriteFile(s.fs, path, []byte(content), 0o666)).To(Succeed())
+
+				Expect(s.Execute(
+					Remove(&fakeTemplate{fakeBuilder: fakeBuilder{path: path}}, true),
+					Remove(&fakeTemplate{fakeBuilder: fakeBuilder{path: pathGo}}, true),
+				)).Error().To(Succeed())
+				Expect(afero.Exists(s.fs, path)).To(BeFalse())
+
+				_, err := s.Execute(Remove(&fakeTemplate{fakeBuilder: fakeBuilder{path: path}, err: errors.New("defaults")},
+					true))
+				Expect(err).To(HaveOccurred())
+				Expect(errors.As(err, &SetTemplateDefaultsError{})).To(BeTrue())
+			})
+
+			It("should only remove the files scaffolded by a template if they are unmodified unless forced", func() {
+				s.checksumsPath = DefaultChecksumsPath
+				unmodified, modified := filepath.Join("dir", "a"), filepath.Join("dir", "sub", "b")
+				Expect(s.Execute(
+					&fakeTemplate{fakeBuilder: fakeBuilder{path: unmodified, ifExistsAction: OverwriteFile}, body: content},
+					&fakeTemplate{fakeBuilder: fakeBuilder{path: modified, ifExistsAction: OverwriteFile}, body: content},
+				)).Error().To(Succeed())
+				Expect(afero.WriteFile(s.fs, modified, []byte("user"), 0o666)).To(Succeed())
+
+				Expect(s.Execute(Remove(&fakeTemplate{fakeBuilder: fakeBuilder{path: unmodified}}, false))).
+					Error().To(Succeed())
+				Expect(afero.Exists(s.fs, unmodified)).To(BeFalse())
+
+				_, err := s.Execute(Remove(&fakeTemplate{fakeBuilder: fakeBuilder{path: modified}}, false))
+				Expect(err).To(HaveOccurred())
+				Expect(errors.As(err, &ModifiedFileError{})).To(BeTrue())
+				Expect(afero.Exists(s.fs, modified)).To(BeTrue())
+
+				Expect(s.Execute(Remove(&fakeTemplate{fakeBuilder: fakeBuilder{path: modified}}, true))).
+					Error().To(Succeed())
+				Expect(afero.Exists(s.fs, modified)).To(BeFalse())
+			})
+
+			It("should fail to remove modified files if asked to remove them if unmodified", func() {
+				s.checksumsPath = DefaultChecksumsPath
+				Expect(s.Execute(&fakeTemplate{
+					fakeBuilder: fakeBuilder{path: filepath.Join("dir", "a"), ifExistsAction: OverwriteFile},
+					body:        "scaffolded",
+				})).Error().To(Succeed())
+				Expect(afero.WriteFile(s.fs, filepath.Join("dir", "a"), []byte(content), 0o666)).To(Succeed())
+
+				_, err := s.Execute(fakeRemover{
+					fakeBuilder: fakeBuilder{path: filepath.Join("dir", "a"), ifExistsAction: OverwriteIfUnmodified},
+				})
+				Expect(err).To(HaveOccurred())
+				Expect(errors.As(err, &ModifiedFileError{})).To(BeTrue())
+				Expect(afero.Exists(s.fs, filepath.Join("dir", "a"))).To(BeTrue())
+			})
+
+			It("should forget the checksums and contents of the removed files", func() {
+				s.checksumsPath = DefaultChecksumsPath
+				s.basesDir = DefaultBasesDir
+				Expect(s.Execute(&fakeTemplate{fakeBuilder: fakeBuilder{path: path}, body: content})).Error().To(Succeed())
+
+				Expect(s.Execute(fakeRemover{
+					fakeBuilder: fakeBuilder{path: path, ifExistsAction: OverwriteIfUnmodified},
+				})).Error().To(Succeed())
+
+				c, err := loadChecksums(s.fs, DefaultChecksumsPath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(c.Files).To(BeEmpty())
+				Expect(afero.Exists(s.fs, filepath.Join(DefaultBasesDir, path))).To(BeFalse())
+			})
+
+			It("should report the removals without removing the files in dry run", func() {
+				out := &bytes.Buffer{}
+				s.dryRun = out
+
+				Expect(s.Execute(fakeRemover{fakeBuilder: fakeBuilder{path: filepath.Join("dir", "a")}})).
+					Error().To(Succeed())
+
+				Expect(out.String()).To(Equal("--- a/dir/a\n+++ /dev/null\n@@ -1 +0,0 @@\n-Hello world!\n" +
+					"\\ No newline at end of file\n"))
+				Expect(afero.Exists(s.fs, filepath.Join("dir", "a"))).To(BeTrue())
+			})
+		})
+
+		Context("move", func() {
+			const (
+				repo = "example.com/project"
+				api  = `package v1
+
+type Foo struct{}
+`
+				user = `package main
+
+import (
+	v1 "example.com/project/api/v1"
+	"example.com/project/api/v1/sub"
+)
+
+var _ = v1.Foo{}
+var _ = sub.Bar{}
+`
+			)
+
+			BeforeEach(func() {
+				Expect(afero.WriteFile(s.fs, filepath.Join("api", "v1", "foo.go"), []byte(api), 0o666)).To(Succeed())
+				Expect(afero.WriteFile(s.fs, filepath.Join("api", "v1", "sub", "bar.go"), []byte("package sub\n"), 0o666)).
+					To(Succeed())
+				Expect(afero.WriteFile(s.fs, "main.go", []byte(user), 0o666)).To(Succeed())
+			})
+
+			It("should move a file", func() {
+				report, err := s.Execute(fakeMover{
+					fakeBuilder: fakeBuilder{path: "main.go"},
+					destination: filepath.Join("cmd", "main.go"),
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(report.Files).To(Equal([]FileReport{
+					{Action: FileCreated, Path: filepath.Join("cmd", "main.go"), Bytes: len(user), scaffolded: true},
+					{Action: FileMoved, Path: "main.go", MovedTo: filepath.Join("cmd", "main.go")},
+				}))
+
+				Expect(afero.Exists(s.fs, "main.go")).To(BeFalse())
+				b, err := afero.ReadFile(s.fs, filepath.Join("cmd", "main.go"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(b)).To(Equal(user))
+			})
+
+			It("should move a directory and rewrite the imports of its packages", func() {
+				cfg := cfgv3.New()
+				Expect(cfg.SetRepository(repo)).To(Succeed())
+				s.injector.config = cfg
+
+				Expect(s.Execute(fakeMover{
+					fakeBuilder: fakeBuilder{path: "api"},
+					destination: filepath.Join("internal", "webhook"),
+				})).Error().To(Succeed())
+
+				Expect(afero.Exists(s.fs, "api")).To(BeFalse())
+				Expect(afero.Exists(s.fs, filepath.Join("internal", "webhook", "v1", "foo.go"))).To(BeTrue())
+				Expect(afero.Exists(s.fs, filepath.Join("internal", "webhook", "v1", "sub", "bar.go"))).To(BeTrue())
+
+				b, err := afero.ReadFile(s.fs, "main.go")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(b)).To(ContainSubstring(`v1 "example.com/project/internal/webhook/v1"`))
+				Expect(string(b)).To(ContainSubstring(`"example.com/project/internal/webhook/v1/sub"`))
+			})
+
+			It("should name the rewritten imports if the package path changes its last element", func() {
+				cfg := cfgv3.New()
+				Expect(cfg.SetRepository(repo)).To(Succeed())
+				s.injector.config = cfg
+
+				Expect(s.Execute(fakeMover{
+					fakeBuilder: fakeBuilder{path: filepath.Join("api", "v1", "sub")},
+					destination: filepath.Join("pkg", "other"),
+				})).Error().To(Succeed())
+
+				b, err := afero.ReadFile(s.fs, "main.go")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(b)).To(ContainSubstring(`sub "example.com/project/pkg/other"`))
+			})
+
+			It("should ignore files that were already moved", func() {
+				mover := fakeMover{fakeBuilder: fakeBuilder{path: "main.go"}, destination: filepath.Join("cmd", "main.go")}
+				Expect(s.Execute(mover)).Error().To(Succeed())
+				Expect(s.Execute(mover)).Error().To(Succeed())
+			})
+
+			It("should fail for missing files", func() {
+				_, err := s.Execute(fakeMover{fakeBuilder: fakeBuilder{path: path}, destination: "other"})
+				Expect(err).To(HaveOccurred())
+				Expect(errors.As(err, &FileNotFoundError{})).To(BeTrue())
+			})
+
+			DescribeTable("should handle existing destinations according to the if-exists action",
+				func(action IfExistsAction, expected string, moved bool) {
+					Expect(afero.WriteFile(s.fs, path, []byte(content), 0o666)).To(Succeed())
+
+					_, err := s.Execute(fakeMover{
+						fakeBuilder: fakeBuilder{path: "main.go", ifExistsAction: action},
+						destination: path,
+					})
+					if action == Error {
+						Expect(err).To(HaveOccurred())
+						Expect(errors.As(err, &FileAlreadyExistsError{})).To(BeTrue())
+					} else {
+						Expect(err).NotTo(HaveOccurred())
+					}
+
+					b, err := afero.ReadFile(s.fs, path)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(b)).To(Equal(expected))
+					Expect(afero.Exists(s.fs, "main.go")).To(Equal(!moved))
+				},
+				Entry("overwriting it", OverwriteFile, user, true),
+				Entry("skipping the file", SkipFile, content, false),
+				Entry("failing", Error, content, false),
+			)
+		})
+
+		Context("eject", func() {
+			const src = `package file
+
+import (
+	"fmt"
+	"os"
+	// +kubebuilder:scaffold:imports
+)
+
+func f() {
+	fmt.Println()
+
+	os.Exit(0)
+
+	// +kubebuilder:scaffold:calls
+}
+`
+			var imports, calls Marker
+
+			BeforeEach(func() {
+				imports, calls = NewMarkerFor(pathGo, "imports"), NewMarkerFor(pathGo, "calls")
+				Expect(afero.WriteFile(s.fs, pathGo, []byte(src), 0o666)).To(Succeed())
+			})
+
+			It("should remove the inserted code fragments except the kept ones", func() {
+				Expect(s.Execute(Eject(
+					fakeInserter{fakeBuilder: fakeBuilder{path: pathGo}, codeFragments: CodeFragmentsMap{
+						imports: {"\"fmt\"\n", "\"os\"\n"},
+						calls:   {"os.Exit(0)\n\n"},
+					}},
+					false,
+					fakeInserter{fakeBuilder: fakeBuilder{path: pathGo}, codeFragments: CodeFragmentsMap{
+						imports: {"\"fmt\"\n"},
+					}},
+				))).Error().To(Succeed())
+
+				b, err := afero.ReadFile(s.fs, pathGo)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(b)).To(Equal(`package file
+
+import (
+	"fmt"
+	// +kubebuilder:scaffold:imports
+)
+
+func f() {
+	fmt.Println()
+
+	// +kubebuilder:scaffold:calls
+}
+`))
+			})
+
+			It("should remove the appended YAML values except the kept ones", func() {
+				Expect(afero.WriteFile(s.fs, pathYaml, []byte("resources:\n- a\n- b\n- c\n"), 0o666)).To(Succeed())
+
+				Expect(s.Execute(Eject(
+					fakeYAMLInserter{fakeBuilder: fakeBuilder{path: pathYaml}, edits: []YAMLEdit{
+						YAMLAppend{Path: []string{"resources"}, Values: []interface{}{"a", "b"}},
+						YAMLSet{Path: []string{"resources"}, Value: "d"},
+					}},
+					false,
+					fakeYAMLInserter{fakeBuilder: fakeBuilder{path: pathYaml}, edits: []YAMLEdit{
+						YAMLAppend{Path: []string{"resources"}, Values: []interface{}{"b"}},
+					}},
+				))).Error().To(Succeed())
+
+				b, err := afero.ReadFile(s.fs, pathYaml)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(b)).To(Equal("resources:\n- b\n- c\n"))
+			})
+
+			It("should ignore missing and removed files", func() {
+				missing := filepath.Join("dir", pathGo)
+				inserter := fakeInserter{fakeBuilder: fakeBuilder{path: missing}, codeFragments: CodeFragmentsMap{
+					NewMarkerFor(missing, "imports"): {"a\n"},
+				}}
+				Expect(s.Execute(Eject(inserter, false))).Error().To(Succeed())
+				Expect(afero.Exists(s.fs, missing)).To(BeFalse())
+
+				Expect(s.Execute(
+					fakeRemover{fakeBuilder: fakeBuilder{path: pathGo}},
+					Eject(fakeInserter{fakeBuilder: fakeBuilder{path: pathGo}, codeFragments: CodeFragmentsMap{
+						imports: {"\"os\"\n"},
+					}}, false),
+				)).Error().To(Succeed())
+				Expect(afero.Exists(s.fs, pathGo)).To(BeFalse())
+			})
+
+			It("should keep the imports that are still referenced", func() {
+				Expect(s.Execute(Eject(fakeInserter{fakeBuilder: fakeBuilder{path: pathGo}, codeFragments: CodeFragmentsMap{
+					imports: {"\"fmt\"\n", "\"os\"\n"},
+					calls:   {"fmt.Println()\n\n"},
+				}}, false))).Error().To(Succeed())
+
+				b, err := afero.ReadFile(s.fs, pathGo)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(b)).To(Equal(`package file
+
+import (
+	"os"
+	// +kubebuilder:scaffold:imports
+)
+
+func f() {
+	os.Exit(0)
+
+	// +kubebuilder:scaffold:calls
+}
+`))
+			})
+
+			It("should ignore inserters none of whose code fragments are found", func() {
+				Expect(s.Execute(Eject(fakeInserter{fakeBuilder: fakeBuilder{path: pathGo}, codeFragments: CodeFragmentsMap{
+					imports: {"\"strings\"\n"},
+					calls:   {"strings.TrimSpace(\"\")\n"},
+				}}, false))).Error().To(Succeed())
+
+				b, err := afero.ReadFile(s.fs, pathGo)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(b)).To(Equal(src))
+			})
+
+			It("should only eject partially found code fragments if forced", func() {
+				inserter := fakeInserter{fakeBuilder: fakeBuilder{path: pathGo}, codeFragments: CodeFragmentsMap{
+					imports: {"\"os\"\n"},
+					calls:   {"os.Exit(0)\n\n", "os.Exit(1)\n"},
+				}}
+
+				_, err := s.Execute(Eject(inserter, false))
+				Expect(err).To(HaveOccurred())
+				Expect(errors.As(err, &CodeFragmentNotFoundError{})).To(BeTrue())
+				b, err := afero.ReadFile(s.fs, pathGo)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(b)).To(Equal(src))
+
+				Expect(s.Execute(Eject(inserter, true))).Error().To(Succeed())
+				b, err = afero.ReadFile(s.fs, pathGo)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(b)).To(Equal(`package file
+
+import (
+	"fmt"
+	// +kubebuilder:scaffold:imports
+)
+
+func f() {
+	fmt.Println()
+
+	// +kubebuilder:scaffold:calls
+}
+`))
+			})
+		})
+
+		Context("parallel rendering", func() {
+			BeforeEach(func() {
+				s.parallelism = 4
+			})
+
+			It("should render the templates concurrently and apply the rest of the builders in order", func() {
+				builders := make([]Builder, 0, 12)
+				for i := 0; i < 10; i++ {
+					builders = append(builders, &fakeTemplate{
+						fakeBuilder: fakeBuilder{path: fmt.Sprintf("file%d.go", i), TestField: fmt.Sprint(i)},
+						body:        "package   file{{ .TestField }}\n// +kubebuilder:scaffold:-\n",
+					})
+				}
+				for _, fragment := range []string{"// 1\n", "// 2\n"} {
+					builders = append(builders, fakeInserter{
+						fakeBuilder:   fakeBuilder{path: "file3.go"},
+						codeFragments: CodeFragmentsMap{NewMarkerFor("file3.go", "-"): {fragment}},
+					})
+				}
+
+				Expect(s.Execute(builders...)).Error().To(Succeed())
+
+				for i := 0; i < 10; i++ {
+					Expect(afero.ReadFile(s.fs, fmt.Sprintf("file%d.go", i))).
+						To(ContainSubstring(fmt.Sprintf("package file%d\n", i)))
+				}
+				Expect(afero.ReadFile(s.fs, "file3.go")).To(BeEquivalentTo(
+					"package file3\n\n// 1\n// 2\n// +kubebuilder:scaffold:-\n"))
+			})
+
+			It("should report the error of the first failing builder", func() {
+				for i := 0; i < 10; i++ {
+					_, err := s.Execute(
+						&fakeTemplate{fakeBuilder: fakeBuilder{path: "a"}, body: content},
+						&fakeTemplate{fakeBuilder: fakeBuilder{path: "b"}, body: "{{ .Field }"},
+						&fakeTemplate{fakeBuilder: fakeBuilder{path: "c"}, err: testErr},
+						fakeRequiresValidation{validateErr: testErr},
+					)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("template: "))
+				}
+			})
+
+			It("should report the error of the first failing file when formatting", func() {
+				for i := 0; i < 10; i++ {
+					_, err := s.Execute(
+						&fakeTemplate{fakeBuilder: fakeBuilder{path: "d.go"}, body: "package d"},
+						&fakeTemplate{fakeBuilder: fakeBuilder{path: "c.go"}, body: content},
+						&fakeTemplate{fakeBuilder: fakeBuilder{path: "b.go"}, body: content},
+						&fakeTemplate{fakeBuilder: fakeBuilder{path: "a.go"}, body: "package a"},
+					)
+					Expect(errors.As(err, &FormatError{})).To(BeTrue())
+					Expect(err.Error()).To(ContainSubstring("b.go"))
+				}
+			})
+
+			It("should ignore the rendering errors of skipped templates", func() {
+				Expect(s.Execute(
+					&fakeTemplate{fakeBuilder: fakeBuilder{path: path}, body: content},
+					&fakeTemplate{fakeBuilder: fakeBuilder{path: path}, body: "{{ .Field }"},
+				)).Error().To(Succeed())
+
+				Expect(afero.ReadFile(s.fs, path)).To(BeEquivalentTo(content))
+			})
 		})
 	})
 })
 
+// BenchmarkScaffoldExecute measures how long it takes to scaffold a project with many Go files, which are
+// rendered and formatted one after another or concurrently
+func BenchmarkScaffoldExecute(b *testing.B) {
+	const body = `{{ .Boilerplate }}
+
+package {{ .TestField }}
+
+import (
+	"fmt"
+	"strings"
+)
+
+// {{ .TestField }}Reconciler reconciles a {{ .TestField }} object
+type {{ .TestField }}Reconciler struct {
+	Name string
+}
+
+// Reconcile prints the name of the object
+func (r *{{ .TestField }}Reconciler) Reconcile() {
+	fmt.Println(strings.ToUpper(r.Name))
+}
+`
+
+	for _, parallelism := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("parallelism=%d", parallelism), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				s := NewScaffold(Filesystem{FS: afero.NewMemMapFs()},
+					WithChecksumsPath(""),
+					WithTemplateOverrideDirs(),
+					WithBoilerplate("// Copyright 2026"),
+					WithParallelism(parallelism),
+				)
+
+				builders := make([]Builder, 0, 50)
+				for i := 0; i < 50; i++ {
+					builders = append(builders, &fakeBenchmarkTemplate{fakeTemplate: fakeTemplate{
+						fakeBuilder: fakeBuilder{path: fmt.Sprintf("pkg%d/file.go", i), TestField: fmt.Sprintf("pkg%d", i)},
+						body:        body,
+					}})
+				}
+
+				if _, err := s.Execute(builders...); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
 var _ Builder = fakeBuilder{}
 
 // fakeBuilder is used to mock a Builder
@@ -554,3 +1404,67 @@ func (f fakeInserter) GetMarkers() []Marker {
 func (f fakeInserter) GetCodeFragments() CodeFragmentsMap {
 	return f.codeFragments
 }
+
+var _ HasBoilerplate = &fakeBenchmarkTemplate{}
+
+// fakeBenchmarkTemplate is used to mock a Template with a boilerplate in order to benchmark Scaffold
+type fakeBenchmarkTemplate struct {
+	fakeTemplate
+	BoilerplateMixin
+}
+
+var _ GoASTInserter = fakeGoASTInserter{}
+
+// fakeGoASTInserter is used to mock a GoASTInserter in order to test Scaffold
+type fakeGoASTInserter struct {
+	fakeBuilder
+
+	edits []GoEdit
+}
+
+// GetGoEdits implements GoASTInserter
+func (f fakeGoASTInserter) GetGoEdits() []GoEdit {
+	return f.edits
+}
+
+var _ YAMLInserter = fakeYAMLInserter{}
+
+// fakeYAMLInserter is used to mock a YAMLInserter in order to test Scaffold
+type fakeYAMLInserter struct {
+	fakeBuilder
+
+	edits []YAMLEdit
+}
+
+// GetYAMLEdits implements YAMLInserter
+func (f fakeYAMLInserter) GetYAMLEdits() []YAMLEdit {
+	return f.edits
+}
+
+var _ Remover = fakeRemover{}
+
+// fakeRemover is used to mock a Remover in order to test Scaffold
+type fakeRemover struct {
+	fakeBuilder
+
+	optional bool
+}
+
+// IsOptional implements Remover
+func (f fakeRemover) IsOptional() bool {
+	return f.optional
+}
+
+var _ Mover = fakeMover{}
+
+// fakeMover is used to mock a Mover in order to test Scaffold
+type fakeMover struct {
+	fakeBuilder
+
+	destination string
+}
+
+// GetDestination implements Mover
+func (f fakeMover) GetDestination() string {
+	return f.destination
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinery
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+// RequiredMarker is a marker that a file must contain exactly once for code to be inserted at it
+type RequiredMarker struct {
+	// Path is the path of the file
+	Path string
+	// Marker is the marker that the file must contain
+	Marker Marker
+}
+
+// RequiredMarkersOf returns the markers of the files updated by the inserters
+func RequiredMarkersOf(inserters ...Inserter) []RequiredMarker {
+	var markers []RequiredMarker
+	for _, i := range inserters {
+		for _, marker := range i.GetMarkers() {
+			markers = append(markers, RequiredMarker{Path: i.GetPath(), Marker: marker})
+		}
+	}
+	return markers
+}
+
+// MarkerIssueKind describes what is wrong with a required marker
+type MarkerIssueKind string
+
+const (
+	// MissingMarker means that the file doesn't contain the marker
+	MissingMarker MarkerIssueKind = "missing"
+	// DuplicatedMarker means that the file contains the marker more than once
+	DuplicatedMarker MarkerIssueKind = "duplicated"
+	// MalformedMarker means that the file contains the marker, but it is written in a way that doesn't match it
+	MalformedMarker MarkerIssueKind = "malformed"
+)
+
+// MarkerIssue describes a problem with a required marker
+type MarkerIssue struct {
+	// Kind is what is wrong with the marker
+	Kind MarkerIssueKind `json:"kind"`
+	// Path is the path of the file
+	Path string `json:"path"`
+	// Line is the number of the line with the problem, starting at 1, and is 0 for missing markers
+	Line int `json:"line,omitempty"`
+	// Marker is the expected marker
+	Marker string `json:"marker"`
+}
+
+// String implements fmt.Stringer
+func (i MarkerIssue) String() string {
+	location := i.Path
+	if i.Line > 0 {
+		location = fmt.Sprintf("%s:%d", i.Path, i.Line)
+	}
+	return fmt.Sprintf("%s: %s marker %q", location, i.Kind, i.Marker)
+}
+
+// VerifyMarkers checks that the files contain each of their required markers exactly once and returns the issues
+// found, sorted by path and line. Files that don't exist are not checked, as they are scaffolded with their markers.
+func VerifyMarkers(fs Filesystem, markers ...RequiredMarker) ([]MarkerIssue, error) {
+	byPath := make(map[string][]Marker)
+	for _, required := range markers {
+		if !containsMarker(byPath[required.Path], required.Marker) {
+			byPath[required.Path] = append(byPath[required.Path], required.Marker)
+		}
+	}
+
+	issues := make([]MarkerIssue, 0)
+	for path, pathMarkers := range byPath {
+		content, err := afero.ReadFile(fs.FS, path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, ReadFileError{err}
+		}
+
+		for _, marker := range pathMarkers {
+			markerIssues, err := verifyMarker(path, string(content), marker)
+			if err != nil {
+				return nil, err
+			}
+			issues = append(issues, markerIssues...)
+		}
+	}
+
+	sort.Slice(issues, func(i, j int) bool {
+		if issues[i].Path != issues[j].Path {
+			return issues[i].Path < issues[j].Path
+		}
+		if issues[i].Line != issues[j].Line {
+			return issues[i].Line < issues[j].Line
+		}
+		return issues[i].Marker < issues[j].Marker
+	})
+	return issues, nil
+}
+
+// CheckMarkers checks that the files contain each of their required markers exactly once, and returns a
+// MarkerIssuesError with the issues found if any
+func CheckMarkers(fs Filesystem, markers ...RequiredMarker) error {
+	issues, err := VerifyMarkers(fs, markers...)
+	if err != nil {
+		return err
+	}
+	if len(issues) != 0 {
+		return MarkerIssuesError{issues}
+	}
+	return nil
+}
+
+// verifyMarker returns the issues with a marker in the content of the file at path
+func verifyMarker(path, content string, marker Marker) ([]MarkerIssue, error) {
+	var issues []MarkerIssue
+	found := 0
+	mention := markerPattern(marker)
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		switch {
+		case marker.EqualsLine(text):
+			found++
+			if found > 1 {
+				issues = append(issues, MarkerIssue{DuplicatedMarker, path, line, marker.String()})
+			}
+		case mention.MatchString(text):
+			issues = append(issues, MarkerIssue{MalformedMarker, path, line, marker.String()})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, ReadFileError{err}
+	}
+
+	// Malformed markers already explain why the marker wasn't found
+	if found == 0 && len(issues) == 0 {
+		issues = append(issues, MarkerIssue{MissingMarker, path, 0, marker.String()})
+	}
+	return issues, nil
+}
+
+// markerPattern returns the regular expression that matches any mention of the prefix and value of the marker,
+// regardless of the whitespace around their separators, the leading "+" and the comment tokens
+func markerPattern(marker Marker) *regexp.Regexp {
+	parts := strings.Split(strings.TrimSuffix(strings.TrimPrefix(marker.prefix, "+"), ":"), ":")
+	parts = append(parts, marker.value)
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(strings.TrimSpace(part))
+	}
+	return regexp.MustCompile(strings.Join(parts, `\s*:\s*`) + `(?:[^\w.:-]|$)`)
+}
+
+// containsMarker returns true if markers contains marker
+func containsMarker(markers []Marker, marker Marker) bool {
+	for _, m := range markers {
+		if m == marker {
+			return true
+		}
+	}
+	return false
+}
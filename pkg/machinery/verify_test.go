@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinery
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+)
+
+var _ = Describe("VerifyMarkers", func() {
+	const (
+		pathGo   = "main.go"
+		pathYaml = "kustomization.yaml"
+	)
+
+	var (
+		fs      Filesystem
+		imports RequiredMarker
+		scheme  RequiredMarker
+		crd     RequiredMarker
+	)
+
+	BeforeEach(func() {
+		fs = Filesystem{FS: afero.NewMemMapFs()}
+		imports = RequiredMarker{Path: pathGo, Marker: NewMarkerFor(pathGo, "imports")}
+		scheme = RequiredMarker{Path: pathGo, Marker: NewMarkerFor(pathGo, "scheme")}
+		crd = RequiredMarker{Path: pathYaml, Marker: NewMarkerFor(pathYaml, "crdkustomizeresource")}
+	})
+
+	write := func(path, content string) {
+		Expect(afero.WriteFile(fs.FS, path, []byte(content), 0o600)).To(Succeed())
+	}
+
+	It("should not report any issue for well-formed markers", func() {
+		write(pathGo, "package main\n\n\t// +kubebuilder:scaffold:imports\n//+kubebuilder:scaffold:scheme\n")
+		write(pathYaml, "resources:\n# +kubebuilder:scaffold:crdkustomizeresource\n")
+
+		Expect(VerifyMarkers(fs, imports, scheme, crd, imports)).To(BeEmpty())
+		Expect(CheckMarkers(fs, imports, scheme, crd)).To(Succeed())
+	})
+
+	It("should not check files that don't exist", func() {
+		Expect(VerifyMarkers(fs, imports, crd)).To(BeEmpty())
+	})
+
+	It("should report missing, duplicated and malformed markers sorted by file and line", func() {
+		write(pathGo, `package main
+
+// +kubebuilder:scaffold:imports
+// +kubebuilder:scaffold:imports
+// + kubebuilder:scaffold:scheme
+// +kubebuilder:scaffold:schemes
+`)
+		write(pathYaml, "resources:\n# +kubebuilder:scaffold:crdkustomizeresources\n")
+
+		Expect(VerifyMarkers(fs, crd, scheme, imports)).To(Equal([]MarkerIssue{
+			{Kind: MissingMarker, Path: pathYaml, Marker: "# +kubebuilder:scaffold:crdkustomizeresource"},
+			{Kind: DuplicatedMarker, Path: pathGo, Line: 4, Marker: "// +kubebuilder:scaffold:imports"},
+			{Kind: MalformedMarker, Path: pathGo, Line: 5, Marker: "// +kubebuilder:scaffold:scheme"},
+		}))
+	})
+
+	It("should report markers with trailing text or the wrong comment syntax as malformed", func() {
+		write(pathGo, "package main\n\n// +kubebuilder:scaffold:imports done\n# +kubebuilder:scaffold:scheme\n")
+
+		Expect(VerifyMarkers(fs, imports, scheme)).To(Equal([]MarkerIssue{
+			{Kind: MalformedMarker, Path: pathGo, Line: 3, Marker: "// +kubebuilder:scaffold:imports"},
+			{Kind: MalformedMarker, Path: pathGo, Line: 4, Marker: "// +kubebuilder:scaffold:scheme"},
+		}))
+	})
+
+	It("should return the issues as an error", func() {
+		write(pathGo, "package main\n")
+
+		err := CheckMarkers(fs, imports)
+		Expect(err).To(HaveOccurred())
+		Expect(errors.As(err, &MarkerIssuesError{})).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring(`main.go: missing marker "// +kubebuilder:scaffold:imports"`))
+	})
+
+	It("should return the markers of inserters", func() {
+		i := fakeInserter{
+			fakeBuilder: fakeBuilder{path: pathGo},
+			markers:     []Marker{imports.Marker, scheme.Marker},
+		}
+		Expect(RequiredMarkersOf(i)).To(Equal([]RequiredMarker{imports, scheme}))
+	})
+})
@@ -0,0 +1,425 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinery
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	yamlv3 "sigs.k8s.io/yaml/goyaml.v3"
+)
+
+// YAMLEdit is an edit of the node tree of a YAML file.
+// Edits only rewrite the lines of the changed values, so that the formatting and comments are preserved.
+type YAMLEdit interface {
+	// apply returns the source of the file at path after applying the edit
+	apply(path string, src []byte) ([]byte, error)
+}
+
+var (
+	_ YAMLEdit = YAMLAppend{}
+	_ YAMLEdit = YAMLSet{}
+	_ YAMLEdit = YAMLRemove{}
+)
+
+// YAMLAppend appends values to the sequence at Path, skipping the ones that are already present.
+// Missing keys are created.
+type YAMLAppend struct {
+	// Path is the list of keys that lead to the sequence, starting from the root mapping
+	Path []string
+	// Values are the values to append
+	Values []interface{}
+}
+
+// apply implements YAMLEdit
+func (e YAMLAppend) apply(path string, src []byte) ([]byte, error) {
+	doc, err := newYAMLDocument(path, src)
+	if err != nil {
+		return nil, err
+	}
+
+	parentKey, parent, key, value, missing := doc.lookup(e.Path)
+	if len(missing) != 0 {
+		return doc.addKeys(parentKey, parent, missing, e.Values)
+	}
+
+	values := make([]interface{}, 0, len(e.Values))
+	for _, v := range e.Values {
+		if !containsYAMLValue(value, v) {
+			values = append(values, v)
+		}
+	}
+	if len(values) == 0 {
+		return src, nil
+	}
+
+	switch {
+	case value.Tag == "!!null":
+		// Items are added after the comments that follow the key, but before any scaffold marker
+		line := key.Line
+		for line < len(doc.lines) && strings.HasPrefix(strings.TrimSpace(doc.lines[line]), "#") &&
+			!strings.Contains(doc.lines[line], kbPrefix) {
+			line++
+		}
+		return doc.insert(line, renderYAML(values, strings.Repeat(" ", key.Column-1)))
+	case value.Kind == yamlv3.SequenceNode && value.Style&yamlv3.FlowStyle == 0:
+		return doc.insert(lastYAMLLine(value), renderYAML(values, strings.Repeat(" ", value.Column-1)))
+	case value.Kind == yamlv3.SequenceNode:
+		var existing []interface{}
+		if err := value.Decode(&existing); err != nil {
+			return nil, YAMLEditError{path, err}
+		}
+		return doc.replace(key, value, append(existing, values...))
+	default:
+		return nil, YAMLEditError{path, fmt.Errorf("%s is not a sequence", strings.Join(e.Path, "."))}
+	}
+}
+
+// YAMLSet sets the value at Path, creating the missing keys
+type YAMLSet struct {
+	// Path is the list of keys that lead to the value, starting from the root mapping
+	Path []string
+	// Value is the value to set
+	Value interface{}
+}
+
+// apply implements YAMLEdit
+func (e YAMLSet) apply(path string, src []byte) ([]byte, error) {
+	doc, err := newYAMLDocument(path, src)
+	if err != nil {
+		return nil, err
+	}
+
+	parentKey, parent, key, value, missing := doc.lookup(e.Path)
+	if len(missing) != 0 {
+		return doc.addKeys(parentKey, parent, missing, e.Value)
+	}
+
+	if equalYAMLValues(value, e.Value) {
+		return src, nil
+	}
+	return doc.replace(key, value, e.Value)
+}
+
+// YAMLRemove removes values from the sequence at Path, ignoring the ones that are not present.
+// Sequences left empty are kept as keys without value.
+type YAMLRemove struct {
+	// Path is the list of keys that lead to the sequence, starting from the root mapping
+	Path []string
+	// Values are the values to remove
+	Values []interface{}
+}
+
+// apply implements YAMLEdit
+func (e YAMLRemove) apply(path string, src []byte) ([]byte, error) {
+	doc, err := newYAMLDocument(path, src)
+	if err != nil {
+		return nil, err
+	}
+
+	_, _, key, value, missing := doc.lookup(e.Path)
+	if len(missing) != 0 || value.Kind != yamlv3.SequenceNode {
+		return src, nil
+	}
+
+	var kept, removed []*yamlv3.Node
+	for _, item := range value.Content {
+		if slices.ContainsFunc(e.Values, func(v interface{}) bool { return equalYAMLValues(item, v) }) {
+			removed = append(removed, item)
+		} else {
+			kept = append(kept, item)
+		}
+	}
+	if len(removed) == 0 {
+		return src, nil
+	}
+
+	// Flow sequences are rewritten, while the lines of the removed items of block sequences are dropped
+	if value.Style&yamlv3.FlowStyle != 0 {
+		remaining := make([]interface{}, 0, len(kept))
+		for _, item := range kept {
+			var decoded interface{}
+			if err := item.Decode(&decoded); err != nil {
+				return nil, YAMLEditError{path, err}
+			}
+			remaining = append(remaining, decoded)
+		}
+		return doc.replace(key, value, remaining)
+	}
+
+	drop := make(map[int]bool)
+	for _, item := range removed {
+		for line := item.Line - 1; line < lastYAMLLine(item); line++ {
+			drop[line] = true
+		}
+	}
+	var sb strings.Builder
+	for i, line := range doc.lines {
+		if !drop[i] {
+			_, _ = sb.WriteString(line)
+		}
+	}
+	return doc.replaceLines(0, len(doc.lines), sb.String())
+}
+
+// yamlDocument is the parsed source of a YAML file
+type yamlDocument struct {
+	path  string
+	lines []string
+	// root is the root mapping, or nil if the document is empty
+	root *yamlv3.Node
+}
+
+// newYAMLDocument parses the source of a YAML file
+func newYAMLDocument(path string, src []byte) (*yamlDocument, error) {
+	var node yamlv3.Node
+	if err := yamlv3.Unmarshal(src, &node); err != nil {
+		return nil, YAMLEditError{path, err}
+	}
+
+	doc := &yamlDocument{path: path, lines: splitLines(string(src))}
+	if len(node.Content) != 0 {
+		doc.root = node.Content[0]
+		if doc.root.Kind != yamlv3.MappingNode {
+			return nil, YAMLEditError{path, fmt.Errorf("the root node is not a mapping")}
+		}
+	}
+	return doc, nil
+}
+
+// lookup walks the path from the root mapping, returning the key and value nodes it leads to.
+// If any key is missing, it returns the last value found along with its key and the missing keys instead.
+func (d *yamlDocument) lookup(path []string) (parentKey, parent, key, value *yamlv3.Node, missing []string) {
+	parent = d.root
+	for i, k := range path {
+		if parent == nil || parent.Kind != yamlv3.MappingNode {
+			return parentKey, parent, nil, nil, path[i:]
+		}
+
+		key, value = nil, nil
+		for j := 0; j+1 < len(parent.Content); j += 2 {
+			if parent.Content[j].Value == k {
+				key, value = parent.Content[j], parent.Content[j+1]
+				break
+			}
+		}
+		if key == nil {
+			return parentKey, parent, nil, nil, path[i:]
+		}
+		if i < len(path)-1 {
+			parentKey, parent = key, value
+		}
+	}
+	return parentKey, parent, key, value, nil
+}
+
+// addKeys adds the missing keys with the provided value to the parent mapping
+func (d *yamlDocument) addKeys(parentKey, parent *yamlv3.Node, keys []string, value interface{}) ([]byte, error) {
+	for i := len(keys) - 1; i >= 0; i-- {
+		value = map[string]interface{}{keys[i]: value}
+	}
+
+	switch {
+	case parent == nil:
+		return d.insert(len(d.lines), renderYAML(value, ""))
+	case parent.Kind == yamlv3.MappingNode && len(parent.Content) != 0 && parent.Style&yamlv3.FlowStyle == 0:
+		return d.insert(lastYAMLLine(parent), renderYAML(value, strings.Repeat(" ", parent.Content[0].Column-1)))
+	case parent.Kind == yamlv3.MappingNode && len(parent.Content) == 0 && parentKey == nil:
+		return d.replaceLines(parent.Line-1, lastYAMLLine(parent), renderYAML(value, ""))
+	case parent.Kind == yamlv3.MappingNode && parentKey != nil:
+		var existing map[string]interface{}
+		if err := parent.Decode(&existing); err != nil {
+			return nil, YAMLEditError{d.path, err}
+		}
+		for k, v := range value.(map[string]interface{}) {
+			existing[k] = v
+		}
+		return d.replace(parentKey, parent, existing)
+	case parent.Tag == "!!null" && parentKey != nil:
+		return d.replace(parentKey, parent, value)
+	default:
+		return nil, YAMLEditError{d.path, fmt.Errorf("unable to add %s to a non-mapping value", keys[0])}
+	}
+}
+
+// replace replaces the value of a key
+func (d *yamlDocument) replace(key, value *yamlv3.Node, newValue interface{}) ([]byte, error) {
+	keyLine := d.lines[key.Line-1]
+	colon := strings.Index(keyLine[key.Column-1:], ":")
+	if colon == -1 {
+		return nil, YAMLEditError{d.path, fmt.Errorf("unable to find the value of %s", key.Value)}
+	}
+	prefix := keyLine[:key.Column+colon]
+	indent := strings.Repeat(" ", key.Column-1)
+
+	var replacement string
+	switch normalized := normalizeYAMLValue(newValue); {
+	case reflect.ValueOf(normalized).Kind() == reflect.Map && reflect.ValueOf(normalized).Len() != 0:
+		replacement = prefix + "\n" + renderYAML(newValue, indent+"  ")
+	case reflect.ValueOf(normalized).Kind() == reflect.Slice && reflect.ValueOf(normalized).Len() != 0:
+		replacement = prefix + "\n" + renderYAML(newValue, indent)
+	default:
+		// Scalars and empty collections start in the key line, keeping its comment
+		lines := splitLines(renderYAML(newValue, indent))
+		replacement = prefix + " " + strings.TrimSpace(lines[0])
+		if value.LineComment != "" {
+			replacement += " " + value.LineComment
+		}
+		replacement += "\n" + strings.Join(lines[1:], "")
+	}
+
+	end := lastYAMLLine(value)
+	if end < key.Line {
+		end = key.Line
+	}
+	return d.replaceLines(key.Line-1, end, replacement)
+}
+
+// insert inserts text before the line with the provided index
+func (d *yamlDocument) insert(line int, text string) ([]byte, error) {
+	return d.replaceLines(line, line, text)
+}
+
+// replaceLines replaces the lines with indexes in [start, end) by text, checking that the result is valid
+func (d *yamlDocument) replaceLines(start, end int, text string) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, line := range d.lines[:start] {
+		_, _ = buf.WriteString(line)
+	}
+	if start > 0 && !strings.HasSuffix(d.lines[start-1], "\n") {
+		_ = buf.WriteByte('\n')
+	}
+	_, _ = buf.WriteString(text)
+	for _, line := range d.lines[end:] {
+		_, _ = buf.WriteString(line)
+	}
+
+	var node yamlv3.Node
+	if err := yamlv3.Unmarshal(buf.Bytes(), &node); err != nil {
+		return nil, YAMLEditError{d.path, err}
+	}
+	return buf.Bytes(), nil
+}
+
+// renderYAML renders a value in block style, indenting every line.
+// Sequences are not indented inside mappings, following the style of kustomization files.
+func renderYAML(value interface{}, indent string) string {
+	var n yamlv3.Node
+	if err := n.Encode(value); err != nil {
+		return indent + fmt.Sprint(value) + "\n"
+	}
+
+	var sb strings.Builder
+	renderYAMLNode(&sb, &n, indent)
+	return sb.String()
+}
+
+// renderYAMLNode renders a node in block style, indenting every line
+func renderYAMLNode(sb *strings.Builder, n *yamlv3.Node, indent string) {
+	switch {
+	case n.Kind == yamlv3.MappingNode && len(n.Content) != 0:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, value := n.Content[i], n.Content[i+1]
+			_, _ = sb.WriteString(indent + renderYAMLScalar(key) + ":")
+			switch {
+			case value.Kind == yamlv3.MappingNode && len(value.Content) != 0:
+				_ = sb.WriteByte('\n')
+				renderYAMLNode(sb, value, indent+"  ")
+			case value.Kind == yamlv3.SequenceNode && len(value.Content) != 0:
+				_ = sb.WriteByte('\n')
+				renderYAMLNode(sb, value, indent)
+			default:
+				_, _ = sb.WriteString(" " + renderYAMLScalar(value) + "\n")
+			}
+		}
+	case n.Kind == yamlv3.SequenceNode && len(n.Content) != 0:
+		for _, item := range n.Content {
+			// Items are rendered as if they were indented and their first indentation is replaced by the dash
+			var itemSb strings.Builder
+			renderYAMLNode(&itemSb, item, indent+"  ")
+			_, _ = sb.WriteString(indent + "- " + strings.TrimPrefix(itemSb.String(), indent+"  "))
+		}
+	default:
+		_, _ = sb.WriteString(indent + renderYAMLScalar(n) + "\n")
+	}
+}
+
+// renderYAMLScalar renders a scalar or an empty collection in a single line
+func renderYAMLScalar(n *yamlv3.Node) string {
+	if n.Kind == yamlv3.ScalarNode && strings.Contains(n.Value, "\n") {
+		n.Style = yamlv3.DoubleQuotedStyle
+	}
+	if n.Kind != yamlv3.ScalarNode {
+		n.Style = yamlv3.FlowStyle
+	}
+
+	b, err := yamlv3.Marshal(n)
+	if err != nil {
+		return n.Value
+	}
+	return strings.TrimSuffix(string(b), "\n")
+}
+
+// lastYAMLLine returns the last line of a node, including its children
+func lastYAMLLine(n *yamlv3.Node) int {
+	last := n.Line
+	if n.Kind == yamlv3.ScalarNode && n.Style&(yamlv3.LiteralStyle|yamlv3.FoldedStyle) != 0 {
+		last += strings.Count(strings.TrimSuffix(n.Value, "\n"), "\n") + 1
+	}
+	for _, child := range n.Content {
+		if l := lastYAMLLine(child); l > last {
+			last = l
+		}
+	}
+	return last
+}
+
+// containsYAMLValue checks if any item of the sequence is equal to the value
+func containsYAMLValue(seq *yamlv3.Node, value interface{}) bool {
+	for _, item := range seq.Content {
+		if equalYAMLValues(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// equalYAMLValues checks if a node is semantically equal to a value
+func equalYAMLValues(n *yamlv3.Node, value interface{}) bool {
+	var decoded interface{}
+	if err := n.Decode(&decoded); err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(decoded, normalizeYAMLValue(value))
+}
+
+// normalizeYAMLValue round-trips a value so that it is represented with the same types as decoded values
+func normalizeYAMLValue(value interface{}) interface{} {
+	b, err := yamlv3.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var normalized interface{}
+	if err := yamlv3.Unmarshal(b, &normalized); err != nil {
+		return value
+	}
+	return normalized
+}
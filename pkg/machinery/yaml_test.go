@@ -0,0 +1,197 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinery
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("YAML edits", func() {
+	const (
+		path = "kustomization.yaml"
+		src  = `# This is a kustomization
+resources:
+- bases/a.yaml # first
+# +kubebuilder:scaffold:crdkustomizeresource
+
+patches:
+# patches here are for enabling the conversion webhook
+# +kubebuilder:scaffold:crdkustomizewebhookpatch
+
+labels:
+  app: test # the app
+`
+	)
+
+	DescribeTable("should edit the file preserving its comments",
+		func(edit YAMLEdit, expected string) {
+			content, err := edit.apply(path, []byte(src))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal(expected))
+		},
+		Entry("when appending to a sequence",
+			YAMLAppend{Path: []string{"resources"}, Values: []interface{}{"bases/b.yaml"}},
+			`# This is a kustomization
+resources:
+- bases/a.yaml # first
+- bases/b.yaml
+# +kubebuilder:scaffold:crdkustomizeresource
+
+patches:
+# patches here are for enabling the conversion webhook
+# +kubebuilder:scaffold:crdkustomizewebhookpatch
+
+labels:
+  app: test # the app
+`),
+		Entry("when appending to a null value",
+			YAMLAppend{Path: []string{"patches"}, Values: []interface{}{
+				map[string]interface{}{
+					"path":   "patches/webhook_in_as.yaml",
+					"target": map[string]interface{}{"kind": "Deployment"},
+				},
+			}},
+			`# This is a kustomization
+resources:
+- bases/a.yaml # first
+# +kubebuilder:scaffold:crdkustomizeresource
+
+patches:
+# patches here are for enabling the conversion webhook
+- path: patches/webhook_in_as.yaml
+  target:
+    kind: Deployment
+# +kubebuilder:scaffold:crdkustomizewebhookpatch
+
+labels:
+  app: test # the app
+`),
+		Entry("when appending to a missing key",
+			YAMLAppend{Path: []string{"components"}, Values: []interface{}{"../components/a"}},
+			src+"components:\n- ../components/a\n"),
+		Entry("when appending values that already exist",
+			YAMLAppend{Path: []string{"resources"}, Values: []interface{}{"bases/a.yaml"}},
+			src),
+		Entry("when setting a scalar",
+			YAMLSet{Path: []string{"labels", "app"}, Value: "other"},
+			`# This is a kustomization
+resources:
+- bases/a.yaml # first
+# +kubebuilder:scaffold:crdkustomizeresource
+
+patches:
+# patches here are for enabling the conversion webhook
+# +kubebuilder:scaffold:crdkustomizewebhookpatch
+
+labels:
+  app: other # the app
+`),
+		Entry("when setting a missing nested key",
+			YAMLSet{Path: []string{"labels", "tier", "name"}, Value: "backend"},
+			`# This is a kustomization
+resources:
+- bases/a.yaml # first
+# +kubebuilder:scaffold:crdkustomizeresource
+
+patches:
+# patches here are for enabling the conversion webhook
+# +kubebuilder:scaffold:crdkustomizewebhookpatch
+
+labels:
+  app: test # the app
+  tier:
+    name: backend
+`),
+		Entry("when setting a key of a null value",
+			YAMLSet{Path: []string{"patches", "name"}, Value: "a"},
+			`# This is a kustomization
+resources:
+- bases/a.yaml # first
+# +kubebuilder:scaffold:crdkustomizeresource
+
+patches:
+  name: a
+# patches here are for enabling the conversion webhook
+# +kubebuilder:scaffold:crdkustomizewebhookpatch
+
+labels:
+  app: test # the app
+`),
+		Entry("when setting a value to the same value",
+			YAMLSet{Path: []string{"labels"}, Value: map[string]interface{}{"app": "test"}},
+			src),
+		Entry("when removing from a sequence",
+			YAMLRemove{Path: []string{"resources"}, Values: []interface{}{"bases/a.yaml"}},
+			`# This is a kustomization
+resources:
+# +kubebuilder:scaffold:crdkustomizeresource
+
+patches:
+# patches here are for enabling the conversion webhook
+# +kubebuilder:scaffold:crdkustomizewebhookpatch
+
+labels:
+  app: test # the app
+`),
+		Entry("when removing values that don't exist",
+			YAMLRemove{Path: []string{"resources"}, Values: []interface{}{"bases/b.yaml"}},
+			src),
+		Entry("when removing from a null value",
+			YAMLRemove{Path: []string{"patches"}, Values: []interface{}{"bases/a.yaml"}},
+			src),
+		Entry("when removing from a missing key",
+			YAMLRemove{Path: []string{"components"}, Values: []interface{}{"../components/a"}},
+			src),
+	)
+
+	It("should append to flow sequences", func() {
+		content, err := YAMLAppend{Path: []string{"resources"}, Values: []interface{}{"b"}}.
+			apply(path, []byte("resources: [a] # resources\n"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(Equal("resources:\n- a\n- b\n"))
+	})
+
+	It("should remove multi-line items from block sequences", func() {
+		content, err := YAMLRemove{Path: []string{"patches"}, Values: []interface{}{
+			map[string]interface{}{"path": "a.yaml", "target": map[string]interface{}{"kind": "A"}},
+		}}.apply(path, []byte("patches:\n- path: a.yaml\n  target:\n    kind: A\n- path: b.yaml\n"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(Equal("patches:\n- path: b.yaml\n"))
+	})
+
+	It("should remove from flow sequences", func() {
+		content, err := YAMLRemove{Path: []string{"resources"}, Values: []interface{}{"b"}}.
+			apply(path, []byte("resources: [a, b]\n"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(Equal("resources:\n- a\n"))
+	})
+
+	It("should fail to append to values that are not sequences", func() {
+		_, err := YAMLAppend{Path: []string{"labels"}, Values: []interface{}{"b"}}.apply(path, []byte(src))
+		Expect(err).To(HaveOccurred())
+		Expect(errors.As(err, &YAMLEditError{})).To(BeTrue())
+	})
+
+	It("should fail if the file can not be parsed", func() {
+		_, err := YAMLSet{Path: []string{"a"}, Value: "b"}.apply(path, []byte("a: [b"))
+		Expect(err).To(HaveOccurred())
+		Expect(errors.As(err, &YAMLEditError{})).To(BeTrue())
+	})
+})
@@ -84,6 +84,15 @@ func NewBundleWithOptions(opts ...BundleOption) (Bundle, error) {
 		}
 	}
 
+	// Bundled plugins may require plugins from outside the bundle, so only their conflicts and order are checked here
+	if err := checkConflicts(allPlugins); err != nil {
+		return nil, fmt.Errorf("unable to bundle plugins: %w", err)
+	}
+	allPlugins, err := SortChain(allPlugins)
+	if err != nil {
+		return nil, fmt.Errorf("unable to bundle plugins: %w", err)
+	}
+
 	return bundle{
 		name:                     bundleOpts.name,
 		version:                  bundleOpts.version,
@@ -0,0 +1,294 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"fmt"
+	"strings"
+)
+
+// VersionOperator compares the version of a plugin with the version of a VersionRequirement.
+type VersionOperator string
+
+const (
+	// VersionEqual requires the same version.
+	VersionEqual VersionOperator = "="
+	// VersionGreater requires a greater version.
+	VersionGreater VersionOperator = ">"
+	// VersionGreaterOrEqual requires the same or a greater version.
+	VersionGreaterOrEqual VersionOperator = ">="
+	// VersionLess requires a lesser version.
+	VersionLess VersionOperator = "<"
+	// VersionLessOrEqual requires the same or a lesser version.
+	VersionLessOrEqual VersionOperator = "<="
+)
+
+// VersionRequirement is a requirement that the version of a plugin must meet.
+type VersionRequirement struct {
+	Operator VersionOperator
+	Version  Version
+}
+
+// ParseVersionRequirement parses a requirement with format: (=|>|>=|<|<=)?version. No operator means VersionEqual.
+func ParseVersionRequirement(requirement string) (VersionRequirement, error) {
+	var r VersionRequirement
+
+	// Two-character operators have to be checked first as they start with a one-character operator
+	for _, op := range []VersionOperator{
+		VersionGreaterOrEqual, VersionLessOrEqual, VersionEqual, VersionGreater, VersionLess,
+	} {
+		if strings.HasPrefix(requirement, string(op)) {
+			r.Operator = op
+			break
+		}
+	}
+	version := strings.TrimSpace(strings.TrimPrefix(requirement, string(r.Operator)))
+	if r.Operator == "" {
+		r.Operator = VersionEqual
+	}
+
+	if err := r.Version.Parse(version); err != nil {
+		return r, fmt.Errorf("invalid version requirement %q: %w", requirement, err)
+	}
+	if err := r.Version.Validate(); err != nil {
+		return r, fmt.Errorf("invalid version requirement %q: %w", requirement, err)
+	}
+	return r, nil
+}
+
+// String returns the string representation of r.
+func (r VersionRequirement) String() string {
+	if r.Operator == VersionEqual {
+		return r.Version.String()
+	}
+	return string(r.Operator) + r.Version.String()
+}
+
+// MetBy returns true if version meets the requirement.
+func (r VersionRequirement) MetBy(version Version) bool {
+	switch cmp := version.Compare(r.Version); r.Operator {
+	case VersionEqual:
+		return cmp == 0
+	case VersionGreater:
+		return cmp > 0
+	case VersionGreaterOrEqual:
+		return cmp >= 0
+	case VersionLess:
+		return cmp < 0
+	case VersionLessOrEqual:
+		return cmp <= 0
+	default:
+		return false
+	}
+}
+
+// Constraint matches the plugins with a fully-qualified name whose version meets all the requirements.
+type Constraint struct {
+	// Name is the fully-qualified name of the plugin, e.g. "base.go.kubebuilder.io".
+	Name string
+	// Versions are the requirements that the version of the plugin must meet. Any version matches if empty.
+	Versions []VersionRequirement
+}
+
+// ParseConstraint parses a constraint with format: name(/requirement(,requirement)*)?,
+// e.g. "base.go.kubebuilder.io/>=v4,<v5".
+func ParseConstraint(constraint string) (Constraint, error) {
+	name, versions := SplitKey(strings.TrimSpace(constraint))
+	if err := validateName(name); err != nil {
+		return Constraint{}, fmt.Errorf("invalid plugin constraint %q: %w", constraint, err)
+	}
+
+	c := Constraint{Name: name}
+	if versions == "" {
+		return c, nil
+	}
+	for _, requirement := range strings.Split(versions, ",") {
+		r, err := ParseVersionRequirement(strings.TrimSpace(requirement))
+		if err != nil {
+			return Constraint{}, fmt.Errorf("invalid plugin constraint %q: %w", constraint, err)
+		}
+		c.Versions = append(c.Versions, r)
+	}
+	return c, nil
+}
+
+// MustParseConstraint is like ParseConstraint but panics if the constraint cannot be parsed.
+// It is meant to be used by plugins to declare constant constraints.
+func MustParseConstraint(constraint string) Constraint {
+	c, err := ParseConstraint(constraint)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// String returns the string representation of c.
+func (c Constraint) String() string {
+	if len(c.Versions) == 0 {
+		return c.Name
+	}
+	requirements := make([]string, 0, len(c.Versions))
+	for _, r := range c.Versions {
+		requirements = append(requirements, r.String())
+	}
+	return c.Name + "/" + strings.Join(requirements, ",")
+}
+
+// Matches returns true if p has the name of the constraint and its version meets all the requirements.
+func (c Constraint) Matches(p Plugin) bool {
+	if p.Name() != c.Name {
+		return false
+	}
+	for _, r := range c.Versions {
+		if !r.MetBy(p.Version()) {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateChain checks that no two plugins of chain conflict with each other and that the plugins required by
+// each of them are part of chain or installed. Bundles are checked through the plugins they contain.
+func ValidateChain(chain []Plugin, installed ...Plugin) error {
+	plugins := flatten(chain)
+	if err := checkConflicts(plugins); err != nil {
+		return err
+	}
+
+	available := append(flatten(installed), plugins...)
+	for _, p := range plugins {
+		withDependencies, hasDependencies := p.(HasDependencies)
+		if !hasDependencies {
+			continue
+		}
+		for _, required := range withDependencies.RequiredPlugins() {
+			if !anyMatches(required, available) {
+				return MissingPluginError{Plugin: KeyFor(p), Required: required}
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkConflicts checks that no two plugins conflict with each other
+func checkConflicts(plugins []Plugin) error {
+	for _, p := range plugins {
+		withConflicts, hasConflicts := p.(HasConflicts)
+		if !hasConflicts {
+			continue
+		}
+		for _, conflict := range withConflicts.ConflictingPlugins() {
+			for _, other := range plugins {
+				// A plugin never conflicts with itself
+				if KeyFor(other) != KeyFor(p) && conflict.Matches(other) {
+					return ConflictingPluginError{Plugin: KeyFor(p), Conflicting: KeyFor(other), Constraint: conflict}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// SortChain returns the plugins of chain ordered so that each of them comes after the plugins it must run after,
+// keeping the provided order otherwise. Bundles are ordered as a whole, after every plugin that any of the plugins
+// they contain must run after.
+func SortChain(chain []Plugin) ([]Plugin, error) {
+	// after[i] contains the indexes of the plugins that must run before chain[i]
+	after := make([][]int, len(chain))
+	for i, p := range chain {
+		for _, constraint := range runsAfter(p) {
+			for j, other := range chain {
+				if j != i && anyMatches(constraint, flatten([]Plugin{other})) {
+					after[i] = append(after[i], j)
+				}
+			}
+		}
+	}
+
+	// Stable topological sort: the first plugin whose predecessors have already been sorted goes next
+	sorted := make([]Plugin, 0, len(chain))
+	done := make([]bool, len(chain))
+	for len(sorted) < len(chain) {
+		next := -1
+		for i := range chain {
+			if !done[i] && allDone(after[i], done) {
+				next = i
+				break
+			}
+		}
+
+		if next == -1 {
+			var cycle []string
+			for i, p := range chain {
+				if !done[i] {
+					cycle = append(cycle, KeyFor(p))
+				}
+			}
+			return nil, PluginCycleError{Plugins: cycle}
+		}
+
+		done[next] = true
+		sorted = append(sorted, chain[next])
+	}
+
+	return sorted, nil
+}
+
+// flatten returns the plugins with their bundles replaced by the plugins they contain
+func flatten(plugins []Plugin) []Plugin {
+	flat := make([]Plugin, 0, len(plugins))
+	for _, p := range plugins {
+		if pluginBundle, isBundle := p.(Bundle); isBundle {
+			flat = append(flat, pluginBundle.Plugins()...)
+		} else {
+			flat = append(flat, p)
+		}
+	}
+	return flat
+}
+
+// runsAfter returns the constraints of the plugins that p, or any of the plugins it contains, must run after
+func runsAfter(p Plugin) []Constraint {
+	var constraints []Constraint
+	for _, member := range flatten([]Plugin{p}) {
+		if withOrder, hasOrder := member.(HasOrder); hasOrder {
+			constraints = append(constraints, withOrder.RunsAfter()...)
+		}
+	}
+	return constraints
+}
+
+// anyMatches returns true if any of the plugins matches the constraint
+func anyMatches(constraint Constraint, plugins []Plugin) bool {
+	for _, p := range plugins {
+		if constraint.Matches(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// allDone returns true if all the indexes are marked as done
+func allDone(indexes []int, done []bool) bool {
+	for _, i := range indexes {
+		if !done[i] {
+			return false
+		}
+	}
+	return true
+}
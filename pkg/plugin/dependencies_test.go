@@ -0,0 +1,211 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/stage"
+)
+
+var _ = Describe("Constraint", func() {
+	DescribeTable("ParseConstraint should succeed for valid constraints",
+		func(constraint string, expected Constraint) {
+			c, err := ParseConstraint(constraint)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c).To(Equal(expected))
+			Expect(c.String()).To(Equal(constraint))
+		},
+		Entry("without versions", "go.kubebuilder.io", Constraint{Name: "go.kubebuilder.io"}),
+		Entry("with an exact version", "go.kubebuilder.io/v4", Constraint{
+			Name:     "go.kubebuilder.io",
+			Versions: []VersionRequirement{{VersionEqual, Version{Number: 4}}},
+		}),
+		Entry("with a version range", "go.kubebuilder.io/>=v3-alpha,<v5", Constraint{
+			Name: "go.kubebuilder.io",
+			Versions: []VersionRequirement{
+				{VersionGreaterOrEqual, Version{Number: 3, Stage: stage.Alpha}},
+				{VersionLess, Version{Number: 5}},
+			},
+		}),
+	)
+
+	DescribeTable("ParseConstraint should fail for invalid constraints",
+		func(constraint string) {
+			_, err := ParseConstraint(constraint)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("for an invalid name", "go_kubebuilder"),
+		Entry("for an empty version", "go.kubebuilder.io/>="),
+		Entry("for an invalid version", "go.kubebuilder.io/~v4"),
+		Entry("for an invalid stage", "go.kubebuilder.io/v4-gamma"),
+	)
+
+	DescribeTable("Matches",
+		func(constraint string, version Version, matches bool) {
+			p := mockPlugin{name: "go.kubebuilder.io", version: version}
+			Expect(MustParseConstraint(constraint).Matches(p)).To(Equal(matches))
+		},
+		Entry("any version", "go.kubebuilder.io", Version{Number: 1}, true),
+		Entry("another name", "go.example.com", Version{Number: 1}, false),
+		Entry("exact version", "go.kubebuilder.io/v4", Version{Number: 4}, true),
+		Entry("exact version with another stage", "go.kubebuilder.io/v4", Version{Number: 4, Stage: stage.Beta}, false),
+		Entry("greater version", "go.kubebuilder.io/>v3", Version{Number: 4}, true),
+		Entry("not greater version", "go.kubebuilder.io/>v4", Version{Number: 4}, false),
+		Entry("version in range", "go.kubebuilder.io/>=v4-alpha,<=v5", Version{Number: 4}, true),
+		Entry("version out of range", "go.kubebuilder.io/>=v4-alpha,<v5", Version{Number: 5}, false),
+	)
+
+	It("MustParseConstraint should panic for invalid constraints", func() {
+		Expect(func() { MustParseConstraint("go.kubebuilder.io/vX") }).To(Panic())
+	})
+})
+
+var _ = Describe("Plugin chains", func() {
+	var (
+		projectVersions = []config.Version{{Number: 3}}
+
+		base = mockPlugin{
+			name:                     "base.kubebuilder.io",
+			version:                  Version{Number: 4},
+			supportedProjectVersions: projectVersions,
+		}
+		kustomize = mockPlugin{
+			name:                     "kustomize.kubebuilder.io",
+			version:                  Version{Number: 2},
+			supportedProjectVersions: projectVersions,
+		}
+		layout = mockDependentPlugin{
+			mockPlugin: mockPlugin{
+				name:                     "layout.kubebuilder.io",
+				version:                  Version{Number: 1},
+				supportedProjectVersions: projectVersions,
+			},
+			conflicting: []Constraint{MustParseConstraint("base.kubebuilder.io")},
+		}
+		extension = mockDependentPlugin{
+			mockPlugin: mockPlugin{
+				name:                     "extension.kubebuilder.io",
+				version:                  Version{Number: 1, Stage: stage.Alpha},
+				supportedProjectVersions: projectVersions,
+			},
+			required: []Constraint{MustParseConstraint("base.kubebuilder.io/>=v4")},
+			after: []Constraint{
+				MustParseConstraint("base.kubebuilder.io"),
+				MustParseConstraint("kustomize.kubebuilder.io"),
+			},
+		}
+	)
+
+	Context("ValidateChain", func() {
+		It("should succeed for chains without declarations", func() {
+			Expect(ValidateChain(nil)).To(Succeed())
+			Expect(ValidateChain([]Plugin{base, kustomize})).To(Succeed())
+		})
+
+		It("should succeed if the required plugins are in the chain, bundled or installed", func() {
+			Expect(ValidateChain([]Plugin{base, extension})).To(Succeed())
+
+			b, err := NewBundleWithOptions(WithName("bundle.kubebuilder.io"), WithPlugins(kustomize, base))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ValidateChain([]Plugin{b, extension})).To(Succeed())
+
+			Expect(ValidateChain([]Plugin{extension}, b)).To(Succeed())
+		})
+
+		It("should fail if a required plugin is missing", func() {
+			err := ValidateChain([]Plugin{kustomize, extension})
+			Expect(err).To(HaveOccurred())
+			var missingErr MissingPluginError
+			Expect(errors.As(err, &missingErr)).To(BeTrue())
+			Expect(missingErr.Plugin).To(Equal("extension.kubebuilder.io/v1-alpha"))
+			Expect(missingErr.Required.String()).To(Equal("base.kubebuilder.io/>=v4"))
+		})
+
+		It("should fail if a required plugin has an unsupported version", func() {
+			old := base
+			old.version = Version{Number: 3}
+			Expect(ValidateChain([]Plugin{old, extension})).NotTo(Succeed())
+		})
+
+		It("should fail if two plugins conflict", func() {
+			err := ValidateChain([]Plugin{base, layout})
+			Expect(err).To(HaveOccurred())
+			var conflictErr ConflictingPluginError
+			Expect(errors.As(err, &conflictErr)).To(BeTrue())
+			Expect(conflictErr.Plugin).To(Equal("layout.kubebuilder.io/v1"))
+			Expect(conflictErr.Conflicting).To(Equal("base.kubebuilder.io/v4"))
+		})
+
+		It("should not check conflicts with the installed plugins", func() {
+			Expect(ValidateChain([]Plugin{layout}, base)).To(Succeed())
+		})
+	})
+
+	Context("SortChain", func() {
+		It("should keep the order of plugins without ordering declarations", func() {
+			Expect(SortChain([]Plugin{kustomize, base})).To(Equal([]Plugin{kustomize, base}))
+		})
+
+		It("should move plugins after the plugins they must run after", func() {
+			Expect(SortChain([]Plugin{extension, base, kustomize})).To(Equal([]Plugin{base, kustomize, extension}))
+			Expect(SortChain([]Plugin{kustomize, extension, base})).To(Equal([]Plugin{kustomize, base, extension}))
+		})
+
+		It("should ignore the plugins that are not in the chain", func() {
+			Expect(SortChain([]Plugin{extension, layout})).To(Equal([]Plugin{extension, layout}))
+		})
+
+		It("should order bundles as a whole", func() {
+			b, err := NewBundleWithOptions(WithName("bundle.kubebuilder.io"), WithPlugins(extension, layout))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(SortChain([]Plugin{b, base})).To(Equal([]Plugin{base, b}))
+		})
+
+		It("should fail for plugins that must run after each other", func() {
+			first := mockDependentPlugin{mockPlugin: base, after: []Constraint{MustParseConstraint(kustomize.name)}}
+			second := mockDependentPlugin{mockPlugin: kustomize, after: []Constraint{MustParseConstraint(base.name)}}
+			_, err := SortChain([]Plugin{layout, first, second})
+			Expect(err).To(HaveOccurred())
+			var cycleErr PluginCycleError
+			Expect(errors.As(err, &cycleErr)).To(BeTrue())
+			Expect(cycleErr.Plugins).To(Equal([]string{"base.kubebuilder.io/v4", "kustomize.kubebuilder.io/v2"}))
+		})
+	})
+
+	Context("NewBundleWithOptions", func() {
+		It("should order the bundled plugins", func() {
+			b, err := NewBundleWithOptions(WithName("bundle.kubebuilder.io"), WithPlugins(extension, kustomize, base))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(b.Plugins()).To(Equal([]Plugin{kustomize, base, extension}))
+		})
+
+		It("should fail for conflicting plugins", func() {
+			_, err := NewBundleWithOptions(WithName("bundle.kubebuilder.io"), WithPlugins(base, layout))
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should not check the required plugins", func() {
+			_, err := NewBundleWithOptions(WithName("bundle.kubebuilder.io"), WithPlugins(extension))
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
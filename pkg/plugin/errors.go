@@ -18,6 +18,7 @@ package plugin
 
 import (
 	"fmt"
+	"strings"
 )
 
 // ExitError is a typed error that is returned by a plugin when no further steps should be executed for itself.
@@ -30,3 +31,41 @@ type ExitError struct {
 func (e ExitError) Error() string {
 	return fmt.Sprintf("plugin %q exit early: %s", e.Plugin, e.Reason)
 }
+
+// MissingPluginError is returned when a plugin of the chain requires a plugin that is not available.
+type MissingPluginError struct {
+	Plugin   string
+	Required Constraint
+}
+
+// Error implements error
+func (e MissingPluginError) Error() string {
+	return fmt.Sprintf("plugin %q requires a plugin matching %q", e.Plugin, e.Required)
+}
+
+// ConflictingPluginError is returned when two plugins of the chain cannot be used together.
+type ConflictingPluginError struct {
+	Plugin      string
+	Conflicting string
+	Constraint  Constraint
+}
+
+// Error implements error
+func (e ConflictingPluginError) Error() string {
+	return fmt.Sprintf("plugin %q conflicts with plugin %q (matching %q)", e.Plugin, e.Conflicting, e.Constraint)
+}
+
+// PluginCycleError is returned when the plugins of the chain cannot be ordered because they must run after
+// each other.
+type PluginCycleError struct {
+	Plugins []string
+}
+
+// Error implements error
+func (e PluginCycleError) Error() string {
+	plugins := make([]string, 0, len(e.Plugins))
+	for _, p := range e.Plugins {
+		plugins = append(plugins, fmt.Sprintf("%q", p))
+	}
+	return fmt.Sprintf("unable to order plugins %s as they must run after each other", strings.Join(plugins, ", "))
+}
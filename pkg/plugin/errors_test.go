@@ -33,3 +33,22 @@ var _ = Describe("PluginKeyNotFoundError", func() {
 		})
 	})
 })
+
+var _ = Describe("Plugin chain errors", func() {
+	It("should return the correct error messages", func() {
+		constraint := Constraint{
+			Name:     "go.kubebuilder.io",
+			Versions: []VersionRequirement{{VersionGreaterOrEqual, Version{Number: 4}}},
+		}
+		Expect(MissingPluginError{Plugin: "deploy-image.go.kubebuilder.io/v1", Required: constraint}.Error()).To(Equal(
+			`plugin "deploy-image.go.kubebuilder.io/v1" requires a plugin matching "go.kubebuilder.io/>=v4"`))
+		Expect(ConflictingPluginError{
+			Plugin:      "go.kubebuilder.io/v4",
+			Conflicting: "go.kubebuilder.io/v3",
+			Constraint:  Constraint{Name: "go.kubebuilder.io"},
+		}.Error()).To(Equal(
+			`plugin "go.kubebuilder.io/v4" conflicts with plugin "go.kubebuilder.io/v3" (matching "go.kubebuilder.io")`))
+		Expect(PluginCycleError{Plugins: []string{"a.kubebuilder.io/v1", "b.kubebuilder.io/v1"}}.Error()).To(Equal(
+			`unable to order plugins "a.kubebuilder.io/v1", "b.kubebuilder.io/v1" as they must run after each other`))
+	})
+})
@@ -0,0 +1,79 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+// ManifestFileName is the name of the manifest file found next to the executable of an external plugin.
+const ManifestFileName = "plugin.yaml"
+
+// Manifest declares what an external plugin provides, so that Kubebuilder can use it without running the plugin.
+// Every field is optional.
+type Manifest struct {
+	// Name is the name of the plugin, which has to match the name of its directory.
+	Name string `json:"name,omitempty"`
+
+	// Version is the version of the plugin, which has to match the name of its directory.
+	Version string `json:"version,omitempty"`
+
+	// SupportedProjectVersions lists the project configuration versions supported by the plugin.
+	// Defaults to project version 3.
+	SupportedProjectVersions []string `json:"supportedProjectVersions,omitempty"`
+
+	// Subcommands are the subcommands implemented by the plugin by command, i.e. "init", "create api",
+	// "create webhook" or "edit". If empty, the plugin implements every subcommand and is requested their
+	// metadata and flags.
+	Subcommands map[string]SubcommandManifest `json:"subcommands,omitempty"`
+
+	// Requires are the constraints that have to be matched by plugins of the chain or of the project,
+	// e.g. "go.kubebuilder.io/>=v4".
+	Requires []string `json:"requires,omitempty"`
+
+	// Conflicts are the constraints that cannot be matched by any other plugin of the chain.
+	Conflicts []string `json:"conflicts,omitempty"`
+
+	// RunsAfter are the constraints of the plugins that have to run before this one if they are in the chain.
+	RunsAfter []string `json:"runsAfter,omitempty"`
+
+	// Deprecation is the deprecation warning shown when the plugin is used, if not empty.
+	Deprecation string `json:"deprecation,omitempty"`
+
+	// Session runs the plugin in session mode.
+	Session bool `json:"session,omitempty"`
+
+	// Timeout is the maximum duration of a request to the plugin, e.g. "30s", after which the plugin is killed.
+	// Defaults to 10 minutes, and a negative duration disables the timeout.
+	Timeout string `json:"timeout,omitempty"`
+
+	// MaxResponseSize is the maximum size in bytes of a response of the plugin. Defaults to 64MiB.
+	MaxResponseSize int `json:"maxResponseSize,omitempty"`
+
+	// Env are the environment variables passed to the plugin in addition to the default ones, i.e. the system,
+	// network and Go toolchain variables and the ones prefixed with "KUBEBUILDER_".
+	Env []string `json:"env,omitempty"`
+}
+
+// SubcommandManifest declares the help text and the flags of a subcommand of an external plugin,
+// which are used instead of requesting them to the plugin.
+type SubcommandManifest struct {
+	// Description is the description of the subcommand. Defaults to a generic description.
+	Description string `json:"description,omitempty"`
+
+	// Examples are the examples of the subcommand.
+	Examples string `json:"examples,omitempty"`
+
+	// Flags are the flags supported by the subcommand.
+	Flags []Flag `json:"flags,omitempty"`
+}
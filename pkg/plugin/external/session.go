@@ -0,0 +1,167 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// In session mode, Kubebuilder starts the plugin once per CLI invocation with SessionEnvVar set, and exchanges
+// JSON-RPC 2.0 messages with it over its standard input and output. Every message is framed with a
+// Content-Length header, as in the Language Server Protocol. The first request of a session is a handshake,
+// followed by an execute request for every PluginRequest, and a shutdown request before the standard input
+// of the plugin is closed.
+const (
+	// SessionEnvVar is the environment variable set to "true" when the plugin is started in session mode.
+	SessionEnvVar = "KUBEBUILDER_PLUGIN_SESSION"
+
+	// JSONRPCVersion is the version of JSON-RPC used in session mode.
+	JSONRPCVersion = "2.0"
+
+	// HandshakeMethod is the method of the first request of a session, with HandshakeRequest params and
+	// a HandshakeResponse result.
+	HandshakeMethod = "handshake"
+	// ExecuteMethod is the method of the requests with PluginRequest params and a PluginResponse result.
+	ExecuteMethod = "execute"
+	// ShutdownMethod is the method of the last request of a session, without params nor result.
+	ShutdownMethod = "shutdown"
+
+	// CapabilityFlags is the capability of plugins that answer the "flags" requests.
+	CapabilityFlags = "flags"
+	// CapabilityMetadata is the capability of plugins that answer the "metadata" requests.
+	CapabilityMetadata = "metadata"
+)
+
+const (
+	contentLengthHeader = "Content-Length"
+
+	// maxHeaderSize is the maximum size of the headers of a message, line terminators included
+	maxHeaderSize = 4096
+)
+
+// HandshakeRequest is sent by Kubebuilder to negotiate the APIVersion and the capabilities of a session.
+type HandshakeRequest struct {
+	// APIVersions are the versions of PluginRequest and PluginResponse supported by Kubebuilder.
+	APIVersions []string `json:"apiVersions"`
+
+	// Capabilities are the optional requests that Kubebuilder may send.
+	Capabilities []string `json:"capabilities"`
+}
+
+// HandshakeResponse is returned to Kubebuilder by the plugin to complete the handshake.
+type HandshakeResponse struct {
+	// APIVersion is the version chosen by the plugin among the ones supported by Kubebuilder.
+	APIVersion string `json:"apiVersion"`
+
+	// Capabilities are the optional requests that the plugin answers.
+	Capabilities []string `json:"capabilities,omitempty"`
+}
+
+// RPCRequest is a JSON-RPC request.
+type RPCRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      int             `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// RPCResponse is a JSON-RPC response. Either Result or Error is set.
+type RPCResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      int             `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *RPCError       `json:"error,omitempty"`
+}
+
+// RPCError is the error of a failed JSON-RPC request.
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Error implements error interface
+func (e RPCError) Error() string {
+	return fmt.Sprintf("%s (code %d)", e.Message, e.Code)
+}
+
+// WriteMessage writes msg encoded as JSON and framed with a Content-Length header.
+func WriteMessage(w io.Writer, msg interface{}) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(w, "%s: %d\r\n\r\n", contentLengthHeader, len(body)); err != nil {
+		return err
+	}
+	_, err = w.Write(body)
+	return err
+}
+
+// ReadMessage reads a message framed with a Content-Length header and decodes it from JSON into msg.
+func ReadMessage(r *bufio.Reader, msg interface{}) error {
+	return ReadLimitedMessage(r, msg, -1)
+}
+
+// ReadLimitedMessage reads a message like ReadMessage, failing if its Content-Length is larger than maxSize.
+// A negative maxSize disables the limit.
+func ReadLimitedMessage(r *bufio.Reader, msg interface{}, maxSize int) error {
+	length, headerSize := -1, 0
+	for {
+		// Headers are read without buffering more than their maximum size, whatever the plugin sends
+		slice, err := r.ReadSlice('\n')
+		headerSize += len(slice)
+		if errors.Is(err, bufio.ErrBufferFull) || headerSize > maxHeaderSize {
+			return fmt.Errorf("message headers exceed the maximum size of %d bytes", maxHeaderSize)
+		}
+		if err != nil {
+			return err
+		}
+		line := strings.TrimRight(string(slice), "\r\n")
+		if line == "" {
+			break
+		}
+
+		name, value, found := strings.Cut(line, ":")
+		if !found {
+			return fmt.Errorf("invalid message header %q", line)
+		}
+		if strings.EqualFold(strings.TrimSpace(name), contentLengthHeader) {
+			if length, err = strconv.Atoi(strings.TrimSpace(value)); err != nil || length < 0 {
+				return fmt.Errorf("invalid %s header %q", contentLengthHeader, line)
+			}
+		}
+	}
+	if length < 0 {
+		return fmt.Errorf("missing %s header", contentLengthHeader)
+	}
+	if maxSize >= 0 && length > maxSize {
+		return fmt.Errorf("message of %d bytes exceeds the maximum size of %d bytes", length, maxSize)
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return err
+	}
+	return json.Unmarshal(body, msg)
+}
@@ -16,7 +16,12 @@ limitations under the License.
 
 package external
 
-import "sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+import (
+	"encoding/json"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+)
 
 // PluginRequest contains all information kubebuilder received from the CLI
 // and plugins executed before it.
@@ -35,6 +40,17 @@ type PluginRequest struct {
 	// Universe represents the modified file contents that gets updated over a series of plugin runs
 	// across the plugin chain. Initially, it starts out as empty.
 	Universe map[string]string `json:"universe"`
+
+	// Config is the project configuration, i.e. the JSON representation of the PROJECT file, including the
+	// changes made by the plugins executed before this one.
+	Config json.RawMessage `json:"config,omitempty"`
+
+	// Resource is the resource model of the "create api" and "create webhook" commands, resolved from the
+	// CLI flags and updated by the plugins executed before this one.
+	Resource *resource.Resource `json:"resource,omitempty"`
+
+	// PluginChain is the plugin chain of the project.
+	PluginChain []string `json:"pluginChain,omitempty"`
 }
 
 // PluginResponse is returned to kubebuilder by the plugin and contains all files
@@ -54,6 +70,21 @@ type PluginResponse struct {
 	// Universe in the PluginResponse represents the updated file contents that was written by the plugin.
 	Universe map[string]string `json:"universe"`
 
+	// Deletions are the paths of the files of the request universe that were deleted by the plugin.
+	Deletions []string `json:"deletions,omitempty"`
+
+	// Renames are the files of the request universe that were renamed by the plugin, from their old path to
+	// their new one. The contents of a renamed file are the ones of its new path in Universe, if any.
+	Renames map[string]string `json:"renames,omitempty"`
+
+	// PluginConfig is the configuration of the plugin, which replaces the one stored in the project
+	// configuration under the plugin key if not null. It has to be a JSON object.
+	PluginConfig json.RawMessage `json:"pluginConfig,omitempty"`
+
+	// Resources are the resources merged into the project configuration, i.e. added if they are not tracked
+	// yet and updated otherwise.
+	Resources []resource.Resource `json:"resources,omitempty"`
+
 	// Error is a boolean type that indicates whether there were any errors due to plugin failures.
 	Error bool `json:"error,omitempty"`
 
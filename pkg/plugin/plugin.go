@@ -18,6 +18,7 @@ package plugin
 
 import (
 	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
 )
 
 // Plugin is an interface that defines the common base for all plugins.
@@ -69,6 +70,20 @@ type Edit interface {
 	GetEditSubcommand() EditSubcommand
 }
 
+// DeleteAPI is an interface for plugins that provide a `delete api` subcommand.
+type DeleteAPI interface {
+	Plugin
+	// GetDeleteAPISubcommand returns the underlying DeleteAPISubcommand interface.
+	GetDeleteAPISubcommand() DeleteAPISubcommand
+}
+
+// DeleteWebhook is an interface for plugins that provide a `delete webhook` subcommand.
+type DeleteWebhook interface {
+	Plugin
+	// GetDeleteWebhookSubcommand returns the underlying DeleteWebhookSubcommand interface.
+	GetDeleteWebhookSubcommand() DeleteWebhookSubcommand
+}
+
 // Full is an interface for plugins that provide `init`, `create api`, `create webhook` and `edit` subcommands.
 type Full interface {
 	Init
@@ -77,6 +92,85 @@ type Full interface {
 	Edit
 }
 
+// CommandParent is the command under which a custom subcommand is added.
+type CommandParent string
+
+const (
+	// RootCommand adds the subcommand at the root, e.g. `kubebuilder <name>`.
+	RootCommand CommandParent = ""
+	// CreateCommand adds the subcommand under create, e.g. `kubebuilder create <name>`.
+	CreateCommand CommandParent = "create"
+)
+
+// CustomSubcommand is a subcommand other than init, create api, create webhook and edit provided by a plugin.
+type CustomSubcommand struct {
+	// Parent is the command under which the subcommand is added.
+	Parent CommandParent
+	// Name is the name of the subcommand, e.g. "controller" for `create controller`.
+	Name string
+	// Short is the description shown in the help of the parent command.
+	Short string
+	// Long is the description shown in the help of the subcommand. Short is used if empty.
+	Long string
+	// Subcommand runs through the same hooks as the built-in subcommands against an initialized project.
+	// A resource is created from the resource flags if it implements RequiresResource.
+	Subcommand Subcommand
+}
+
+// HasCustomSubcommands is an interface for plugins that provide their own subcommands.
+type HasCustomSubcommands interface {
+	Plugin
+	// GetCustomSubcommands returns the subcommands provided by the plugin. Plugins of the chain that provide
+	// a subcommand with the same parent and name run it together, as they do for the built-in subcommands.
+	GetCustomSubcommands() []CustomSubcommand
+}
+
+// HasTemplates is an interface for plugins whose templates can be overridden.
+type HasTemplates interface {
+	Plugin
+	// GetTemplates returns the templates scaffolded by the plugin, so that their bodies can be dumped
+	// as a starting point for overrides.
+	GetTemplates() []machinery.Template
+}
+
+// HasRequiredMarkers is an interface for plugins that insert code at the scaffold markers of existing files.
+type HasRequiredMarkers interface {
+	Plugin
+	// GetRequiredMarkers returns the markers that the files of the project must contain for the plugin to update
+	// them, so that they can be verified before creating APIs and webhooks.
+	GetRequiredMarkers(config.Config) ([]machinery.RequiredMarker, error)
+}
+
+// HasDependencies is an interface for plugins that can only be used together with other plugins.
+type HasDependencies interface {
+	Plugin
+	// RequiredPlugins returns the constraints that have to be matched by plugins of the chain or of the project.
+	RequiredPlugins() []Constraint
+}
+
+// HasConflicts is an interface for plugins that cannot be used together with other plugins.
+type HasConflicts interface {
+	Plugin
+	// ConflictingPlugins returns the constraints that cannot be matched by any other plugin of the chain.
+	ConflictingPlugins() []Constraint
+}
+
+// HasOrder is an interface for plugins that have to run after other plugins of the chain.
+type HasOrder interface {
+	Plugin
+	// RunsAfter returns the constraints of the plugins that have to run before this one if they are in the chain.
+	RunsAfter() []Constraint
+}
+
+// Migrator is an interface for plugins that can upgrade projects scaffolded with a previous version of themselves.
+type Migrator interface {
+	Plugin
+	// Migrate rewrites the files and the plugin configuration scaffolded by the provided previous version of the
+	// plugin, so that they match the ones this version scaffolds. The plugin configuration is already stored under
+	// the key of this version, and the plugin chain is updated once every plugin was migrated.
+	Migrate(from Version, fs machinery.Filesystem, cfg config.Config) error
+}
+
 // Bundle allows to group plugins under a single key.
 type Bundle interface {
 	Plugin
@@ -17,6 +17,8 @@ limitations under the License.
 package plugin
 
 import (
+	"io"
+
 	"github.com/spf13/pflag"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/config"
@@ -48,6 +50,12 @@ type RequiresResource interface {
 	InjectResource(*resource.Resource) error
 }
 
+// RequiresOutput is an interface that implements the optional inject output method.
+type RequiresOutput interface {
+	// InjectOutput injects the writer where a subcommand prints its messages and the output of the commands it runs.
+	InjectOutput(io.Writer) error
+}
+
 // HasPreScaffold is an interface that implements the optional pre-scaffold method.
 type HasPreScaffold interface {
 	// PreScaffold executes tasks before the main scaffolding.
@@ -92,3 +100,15 @@ type CreateWebhookSubcommand interface {
 type EditSubcommand interface {
 	Subcommand
 }
+
+// DeleteAPISubcommand is an interface that represents a `delete api` subcommand.
+type DeleteAPISubcommand interface {
+	Subcommand
+	RequiresResource
+}
+
+// DeleteWebhookSubcommand is an interface that represents a `delete webhook` subcommand.
+type DeleteWebhookSubcommand interface {
+	Subcommand
+	RequiresResource
+}
@@ -39,3 +39,14 @@ type mockPlugin struct {
 func (p mockPlugin) Name() string                               { return p.name }
 func (p mockPlugin) Version() Version                           { return p.version }
 func (p mockPlugin) SupportedProjectVersions() []config.Version { return p.supportedProjectVersions }
+
+type mockDependentPlugin struct {
+	mockPlugin
+	required    []Constraint
+	conflicting []Constraint
+	after       []Constraint
+}
+
+func (p mockDependentPlugin) RequiredPlugins() []Constraint    { return p.required }
+func (p mockDependentPlugin) ConflictingPlugins() []Constraint { return p.conflicting }
+func (p mockDependentPlugin) RunsAfter() []Constraint          { return p.after }
@@ -17,6 +17,7 @@ limitations under the License.
 package util
 
 import (
+	"io"
 	"os"
 	"os/exec"
 	"strings"
@@ -26,8 +27,13 @@ import (
 
 // RunCmd prints the provided message and command and then executes it binding stdout and stderr
 func RunCmd(msg, cmd string, args ...string) error {
+	return RunCmdWithOutput(os.Stdout, msg, cmd, args...)
+}
+
+// RunCmdWithOutput prints the provided message and command and then executes it binding out and stderr
+func RunCmdWithOutput(out io.Writer, msg, cmd string, args ...string) error {
 	c := exec.Command(cmd, args...) //nolint:gosec
-	c.Stdout = os.Stdout
+	c.Stdout = out
 	c.Stderr = os.Stderr
 	log.Println(msg + ":\n$ " + strings.Join(c.Args, " "))
 	return c.Run()
@@ -40,6 +40,11 @@ var _ = Describe("RunCmd", func() {
 		Expect(err).ToNot(HaveOccurred())
 		Expect(strings.TrimSpace(output.String())).To(Equal("test"))
 	})
+	It("executes the command and redirects output to the provided writer", func() {
+		err = RunCmdWithOutput(output, "echo test", "echo", "test")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(strings.TrimSpace(output.String())).To(Equal("test"))
+	})
 	It("returns an error if the command fails", func() {
 		err = RunCmd("unknown command", "unknowncommand")
 		Expect(err).To(HaveOccurred())
@@ -26,6 +26,10 @@ import (
 	"os"
 	"regexp"
 	"strings"
+
+	"github.com/spf13/afero"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
 )
 
 const (
@@ -64,9 +68,12 @@ func GetNonEmptyLines(output string) []string {
 
 // InsertCode searches target content in the file and insert `toInsert` after the target.
 func InsertCode(filename, target, code string) error {
-	// false positive
-	// nolint:gosec
-	contents, err := os.ReadFile(filename)
+	return InsertCodeFS(osFilesystem(), filename, target, code)
+}
+
+// InsertCodeFS searches target content in the file of the provided filesystem and insert `toInsert` after the target.
+func InsertCodeFS(fs machinery.Filesystem, filename, target, code string) error {
+	contents, err := afero.ReadFile(fs.FS, filename)
 	if err != nil {
 		return err
 	}
@@ -75,16 +82,17 @@ func InsertCode(filename, target, code string) error {
 		return fmt.Errorf("string %s not found in %s", target, string(contents))
 	}
 	out := string(contents[:idx+len(target)]) + code + string(contents[idx+len(target):])
-	// false positive
-	// nolint:gosec
-	return os.WriteFile(filename, []byte(out), 0644)
+	return afero.WriteFile(fs.FS, filename, []byte(out), 0644)
 }
 
 // InsertCodeIfNotExist insert code if it does not already exists
 func InsertCodeIfNotExist(filename, target, code string) error {
-	// false positive
-	// nolint:gosec
-	contents, err := os.ReadFile(filename)
+	return InsertCodeIfNotExistFS(osFilesystem(), filename, target, code)
+}
+
+// InsertCodeIfNotExistFS insert code in the file of the provided filesystem if it does not already exists
+func InsertCodeIfNotExistFS(fs machinery.Filesystem, filename, target, code string) error {
+	contents, err := afero.ReadFile(fs.FS, filename)
 	if err != nil {
 		return err
 	}
@@ -94,12 +102,18 @@ func InsertCodeIfNotExist(filename, target, code string) error {
 		return nil
 	}
 
-	return InsertCode(filename, target, code)
+	return InsertCodeFS(fs, filename, target, code)
 }
 
 // AppendCodeIfNotExist checks if the code does not already exist in the file, and if not, appends it to the end.
 func AppendCodeIfNotExist(filename, code string) error {
-	contents, err := os.ReadFile(filename)
+	return AppendCodeIfNotExistFS(osFilesystem(), filename, code)
+}
+
+// AppendCodeIfNotExistFS checks if the code does not already exist in the file of the provided filesystem,
+// and if not, appends it to the end.
+func AppendCodeIfNotExistFS(fs machinery.Filesystem, filename, code string) error {
+	contents, err := afero.ReadFile(fs.FS, filename)
 	if err != nil {
 		return err
 	}
@@ -108,12 +122,17 @@ func AppendCodeIfNotExist(filename, code string) error {
 		return nil // Code already exists, no need to append.
 	}
 
-	return AppendCodeAtTheEnd(filename, code)
+	return AppendCodeAtTheEndFS(fs, filename, code)
 }
 
 // AppendCodeAtTheEnd appends the given code at the end of the file.
 func AppendCodeAtTheEnd(filename, code string) error {
-	f, err := os.OpenFile(filename, os.O_APPEND|os.O_WRONLY, 0644)
+	return AppendCodeAtTheEndFS(osFilesystem(), filename, code)
+}
+
+// AppendCodeAtTheEndFS appends the given code at the end of the file of the provided filesystem.
+func AppendCodeAtTheEndFS(fs machinery.Filesystem, filename, code string) error {
+	f, err := fs.FS.OpenFile(filename, os.O_APPEND|os.O_WRONLY, 0644)
 	if err != nil {
 		return err
 	}
@@ -130,9 +149,13 @@ func AppendCodeAtTheEnd(filename, code string) error {
 // UncommentCode searches for target in the file and remove the comment prefix
 // of the target content. The target content may span multiple lines.
 func UncommentCode(filename, target, prefix string) error {
-	// false positive
-	// nolint:gosec
-	content, err := os.ReadFile(filename)
+	return UncommentCodeFS(osFilesystem(), filename, target, prefix)
+}
+
+// UncommentCodeFS searches for target in the file of the provided filesystem and remove the comment prefix
+// of the target content. The target content may span multiple lines.
+func UncommentCodeFS(fs machinery.Filesystem, filename, target, prefix string) error {
+	content, err := afero.ReadFile(fs.FS, filename)
 	if err != nil {
 		return err
 	}
@@ -171,16 +194,20 @@ func UncommentCode(filename, target, prefix string) error {
 	if err != nil {
 		return err
 	}
-	// false positive
-	// nolint:gosec
-	return os.WriteFile(filename, out.Bytes(), 0644)
+	return afero.WriteFile(fs.FS, filename, out.Bytes(), 0644)
 }
 
 // CommentCode searches for target in the file and adds the comment prefix
 // to the target content. The target content may span multiple lines.
 func CommentCode(filename, target, prefix string) error {
+	return CommentCodeFS(osFilesystem(), filename, target, prefix)
+}
+
+// CommentCodeFS searches for target in the file of the provided filesystem and adds the comment prefix
+// to the target content. The target content may span multiple lines.
+func CommentCodeFS(fs machinery.Filesystem, filename, target, prefix string) error {
 	// Read the file content
-	content, err := os.ReadFile(filename)
+	content, err := afero.ReadFile(fs.FS, filename)
 	if err != nil {
 		return err
 	}
@@ -215,7 +242,7 @@ func CommentCode(filename, target, prefix string) error {
 	}
 
 	// Write the modified content back to the file
-	return os.WriteFile(filename, out.Bytes(), 0644)
+	return afero.WriteFile(fs.FS, filename, out.Bytes(), 0644)
 }
 
 // EnsureExistAndReplace check if the content exists and then do the replace
@@ -228,13 +255,16 @@ func EnsureExistAndReplace(input, match, replace string) (string, error) {
 
 // ReplaceInFile replaces all instances of old with new in the file at path.
 func ReplaceInFile(path, old, new string) error {
-	info, err := os.Stat(path)
+	return ReplaceInFileFS(osFilesystem(), path, old, new)
+}
+
+// ReplaceInFileFS replaces all instances of old with new in the file at path of the provided filesystem.
+func ReplaceInFileFS(fs machinery.Filesystem, path, old, new string) error {
+	info, err := fs.FS.Stat(path)
 	if err != nil {
 		return err
 	}
-	// false positive
-	// nolint:gosec
-	b, err := os.ReadFile(path)
+	b, err := afero.ReadFile(fs.FS, path)
 	if err != nil {
 		return err
 	}
@@ -242,7 +272,7 @@ func ReplaceInFile(path, old, new string) error {
 		return errors.New("unable to find the content to be replaced")
 	}
 	s := strings.Replace(string(b), old, new, -1)
-	err = os.WriteFile(path, []byte(s), info.Mode())
+	err = afero.WriteFile(fs.FS, path, []byte(s), info.Mode())
 	if err != nil {
 		return err
 	}
@@ -252,17 +282,21 @@ func ReplaceInFile(path, old, new string) error {
 // ReplaceRegexInFile finds all strings that match `match` and replaces them
 // with `replace` in the file at path.
 func ReplaceRegexInFile(path, match, replace string) error {
+	return ReplaceRegexInFileFS(osFilesystem(), path, match, replace)
+}
+
+// ReplaceRegexInFileFS finds all strings that match `match` and replaces them
+// with `replace` in the file at path of the provided filesystem.
+func ReplaceRegexInFileFS(fs machinery.Filesystem, path, match, replace string) error {
 	matcher, err := regexp.Compile(match)
 	if err != nil {
 		return err
 	}
-	info, err := os.Stat(path)
+	info, err := fs.FS.Stat(path)
 	if err != nil {
 		return err
 	}
-	// false positive
-	// nolint:gosec
-	b, err := os.ReadFile(path)
+	b, err := afero.ReadFile(fs.FS, path)
 	if err != nil {
 		return err
 	}
@@ -270,7 +304,7 @@ func ReplaceRegexInFile(path, match, replace string) error {
 	if s == string(b) {
 		return errors.New("unable to find the content to be replaced")
 	}
-	err = os.WriteFile(path, []byte(s), info.Mode())
+	err = afero.WriteFile(fs.FS, path, []byte(s), info.Mode())
 	if err != nil {
 		return err
 	}
@@ -279,11 +313,20 @@ func ReplaceRegexInFile(path, match, replace string) error {
 
 // HasFileContentWith check if given `text` can be found in file
 func HasFileContentWith(path, text string) (bool, error) {
-	// nolint:gosec
-	contents, err := os.ReadFile(path)
+	return HasFileContentWithFS(osFilesystem(), path, text)
+}
+
+// HasFileContentWithFS check if given `text` can be found in file of the provided filesystem
+func HasFileContentWithFS(fs machinery.Filesystem, path, text string) (bool, error) {
+	contents, err := afero.ReadFile(fs.FS, path)
 	if err != nil {
 		return false, err
 	}
 
 	return strings.Contains(string(contents), text), nil
 }
+
+// osFilesystem returns the filesystem used by the helpers that do not receive one
+func osFilesystem() machinery.Filesystem {
+	return machinery.Filesystem{FS: afero.NewOsFs()}
+}
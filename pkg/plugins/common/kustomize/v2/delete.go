@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds"
+)
+
+var (
+	_ plugin.DeleteAPISubcommand     = &deleteAPISubcommand{}
+	_ plugin.DeleteWebhookSubcommand = &deleteWebhookSubcommand{}
+)
+
+type deleteSubcommand struct {
+	config   config.Config
+	resource *resource.Resource
+
+	flagSet *pflag.FlagSet
+
+	// force indicates whether to remove the manifests modified since they were scaffolded
+	force bool
+}
+
+func (p *deleteSubcommand) BindFlags(fs *pflag.FlagSet) { p.flagSet = fs }
+
+func (p *deleteSubcommand) InjectConfig(c config.Config) error {
+	p.config = c
+	return nil
+}
+
+func (p *deleteSubcommand) InjectResource(res *resource.Resource) error {
+	// Remove the manifests of the resource that was scaffolded, not of the one the flags describe
+	stored, err := plugins.LookupResource(p.config, res.GVK)
+	if err != nil {
+		return fmt.Errorf("unable to find the resource to delete: %w", err)
+	}
+	p.resource = &stored
+	return nil
+}
+
+func (p *deleteSubcommand) configure() (err error) {
+	if forceFlag := p.flagSet.Lookup("force"); forceFlag != nil {
+		if p.force, err = strconv.ParseBool(forceFlag.Value.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type deleteAPISubcommand struct {
+	deleteSubcommand
+}
+
+func (p *deleteAPISubcommand) Scaffold(fs machinery.Filesystem) error {
+	if err := p.configure(); err != nil {
+		return err
+	}
+	scaffolder := scaffolds.NewDeleteAPIScaffolder(p.config, *p.resource, p.force)
+	scaffolder.InjectFS(fs)
+	return scaffolder.Scaffold()
+}
+
+type deleteWebhookSubcommand struct {
+	deleteSubcommand
+}
+
+func (p *deleteWebhookSubcommand) Scaffold(fs machinery.Filesystem) error {
+	if err := p.configure(); err != nil {
+		return err
+	}
+	scaffolder := scaffolds.NewDeleteWebhookScaffolder(p.config, *p.resource, p.force)
+	scaffolder.InjectFS(fs)
+	return scaffolder.Scaffold()
+}
@@ -19,9 +19,11 @@ package v2
 import (
 	"sigs.k8s.io/kubebuilder/v4/pkg/config"
 	cfgv3 "sigs.k8s.io/kubebuilder/v4/pkg/config/v3"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
 	"sigs.k8s.io/kubebuilder/v4/pkg/model/stage"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds"
 )
 
 // KustomizeVersion is the nholuongut/kustomize version to be used in the project
@@ -32,12 +34,18 @@ const pluginName = "kustomize.common." + plugins.DefaultNameQualifier
 var (
 	pluginVersion            = plugin.Version{Number: 2, Stage: stage.Stable}
 	supportedProjectVersions = []config.Version{cfgv3.Version}
+	// Other versions of the plugin scaffold a different layout for the same manifests
+	conflictingPlugins = []plugin.Constraint{{Name: pluginName}}
 )
 
 var (
-	_ plugin.Init          = Plugin{}
-	_ plugin.CreateAPI     = Plugin{}
-	_ plugin.CreateWebhook = Plugin{}
+	_ plugin.Init               = Plugin{}
+	_ plugin.CreateAPI          = Plugin{}
+	_ plugin.CreateWebhook      = Plugin{}
+	_ plugin.DeleteAPI          = Plugin{}
+	_ plugin.DeleteWebhook      = Plugin{}
+	_ plugin.HasRequiredMarkers = Plugin{}
+	_ plugin.HasConflicts       = Plugin{}
 )
 
 // Plugin implements the plugin.Full interface
@@ -45,6 +53,8 @@ type Plugin struct {
 	initSubcommand
 	createAPISubcommand
 	createWebhookSubcommand
+	deleteAPISubcommand
+	deleteWebhookSubcommand
 }
 
 // Name returns the name of the plugin
@@ -56,6 +66,9 @@ func (Plugin) Version() plugin.Version { return pluginVersion }
 // SupportedProjectVersions returns an array with all project versions supported by the plugin
 func (Plugin) SupportedProjectVersions() []config.Version { return supportedProjectVersions }
 
+// ConflictingPlugins returns the plugins that cannot be used together with this one
+func (Plugin) ConflictingPlugins() []plugin.Constraint { return conflictingPlugins }
+
 // GetInitSubcommand will return the subcommand which is responsible for scaffolding init project
 func (p Plugin) GetInitSubcommand() plugin.InitSubcommand { return &p.initSubcommand }
 
@@ -67,6 +80,19 @@ func (p Plugin) GetCreateWebhookSubcommand() plugin.CreateWebhookSubcommand {
 	return &p.createWebhookSubcommand
 }
 
+// GetDeleteAPISubcommand will return the subcommand which is responsible for removing the manifests of apis
+func (p Plugin) GetDeleteAPISubcommand() plugin.DeleteAPISubcommand { return &p.deleteAPISubcommand }
+
+// GetDeleteWebhookSubcommand will return the subcommand which is responsible for removing the manifests of webhooks
+func (p Plugin) GetDeleteWebhookSubcommand() plugin.DeleteWebhookSubcommand {
+	return &p.deleteWebhookSubcommand
+}
+
+// GetRequiredMarkers returns the markers of the files updated by the plugin
+func (Plugin) GetRequiredMarkers(cfg config.Config) ([]machinery.RequiredMarker, error) {
+	return scaffolds.RequiredMarkers(cfg)
+}
+
 func (p Plugin) DeprecationWarning() string {
 	return ""
 }
@@ -22,6 +22,7 @@ import (
 
 	pluginutil "sigs.k8s.io/kubebuilder/v4/pkg/plugin/util"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates/config/crd"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates/config/kustomization"
 
 	log "github.com/sirupsen/logrus"
 	"sigs.k8s.io/kubebuilder/v4/pkg/config"
@@ -73,7 +74,7 @@ func (s *apiScaffolder) Scaffold() error {
 
 	// Keep track of these values before the update
 	if s.resource.HasAPI() {
-		if err := scaffold.Execute(
+		if _, err := scaffold.Execute(
 			&samples.CRDSample{Force: s.force},
 			&rbac.CRDEditorRole{},
 			&rbac.CRDViewerRole{},
@@ -85,24 +86,24 @@ func (s *apiScaffolder) Scaffold() error {
 
 		// If the gvk is non-empty
 		if s.resource.Group != "" || s.resource.Version != "" || s.resource.Kind != "" {
-			if err := scaffold.Execute(&samples.Kustomization{}); err != nil {
+			if _, err := scaffold.Execute(&samples.Kustomization{}); err != nil {
 				return fmt.Errorf("error scaffolding manifests: %v", err)
 			}
 		}
 
+		// Uncomment the scaffolded entry if present, so that it keeps its position in the list
 		kustomizeFilePath := "config/default/kustomization.yaml"
-		err := pluginutil.UncommentCode(kustomizeFilePath, "#- ../crd", `#`)
-		if err != nil {
-			hasCRUncommented, err := pluginutil.HasFileContentWith(kustomizeFilePath, "- ../crd")
-			if !hasCRUncommented || err != nil {
-				log.Errorf("Unable to find the target #- ../crd to uncomment in the file "+
-					"%s.", kustomizeFilePath)
-			}
+		uncommentCode(s.fs, kustomizeFilePath, "#- ../crd")
+		if _, err := scaffold.Execute(&kustomization.Updater{
+			Path:      kustomizeFilePath,
+			Resources: []string{"../crd"},
+		}); err != nil {
+			log.Errorf("Unable to add ../crd to the resources in the file %s: %v", kustomizeFilePath, err)
 		}
 
 		// Add scaffolded CRD Editor and Viewer roles in config/rbac/kustomization.yaml
 		rbacKustomizeFilePath := "config/rbac/kustomization.yaml"
-		err = pluginutil.AppendCodeIfNotExist(rbacKustomizeFilePath,
+		err := pluginutil.AppendCodeIfNotExistFS(s.fs, rbacKustomizeFilePath,
 			editViewRulesCommentFragment)
 		if err != nil {
 			log.Errorf("Unable to append the edit/view roles comment in the file "+
@@ -112,14 +113,14 @@ func (s *apiScaffolder) Scaffold() error {
 		if s.config.IsMultiGroup() && s.resource.Group != "" {
 			crdName = strings.ToLower(s.resource.Group) + "_" + crdName
 		}
-		err = pluginutil.InsertCodeIfNotExist(rbacKustomizeFilePath, editViewRulesCommentFragment,
+		err = pluginutil.InsertCodeIfNotExistFS(s.fs, rbacKustomizeFilePath, editViewRulesCommentFragment,
 			fmt.Sprintf("\n- %[1]s_editor_role.yaml\n- %[1]s_viewer_role.yaml", crdName))
 		if err != nil {
 			log.Errorf("Unable to add Editor and Viewer roles in the file "+
 				"%s.", rbacKustomizeFilePath)
 		}
 		// Add an empty line at the end of the file
-		err = pluginutil.AppendCodeIfNotExist(rbacKustomizeFilePath,
+		err = pluginutil.AppendCodeIfNotExistFS(s.fs, rbacKustomizeFilePath,
 			`
 
 `)
@@ -136,3 +137,15 @@ const editViewRulesCommentFragment = `# For each CRD, "Editor" and "Viewer" role
 # default, aiding admins in cluster management. Those roles are
 # not used by the Project itself. You can comment the following lines
 # if you do not want those helpers be installed with your Project.`
+
+// uncommentCode uncomments the target code in the file, logging an error if it is found neither commented
+// nor uncommented. Missing entries are still added by the kustomization updaters.
+func uncommentCode(fs machinery.Filesystem, filename, target string) {
+	if err := pluginutil.UncommentCodeFS(fs, filename, target, `#`); err != nil {
+		uncommented := strings.ReplaceAll(strings.TrimPrefix(target, "#"), "\n#", "\n")
+		hasUncommented, hasErr := pluginutil.HasFileContentWithFS(fs, filename, uncommented)
+		if !hasUncommented || hasErr != nil {
+			log.Errorf("Unable to find the target %s to uncomment in the file %s: %v", target, filename, err)
+		}
+	}
+}
@@ -0,0 +1,251 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates/config/crd"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates/config/crd/patches"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates/config/kustomization"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates/config/rbac"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates/config/samples"
+)
+
+var (
+	_ plugins.Scaffolder = &deleteAPIScaffolder{}
+	_ plugins.Scaffolder = &deleteWebhookScaffolder{}
+)
+
+// deleteAPIScaffolder contains configuration for removing the kustomize manifests of an API.
+type deleteAPIScaffolder struct {
+	config   config.Config
+	resource resource.Resource
+
+	// force indicates whether to remove the files and code modified since they were scaffolded
+	force bool
+
+	// fs is the filesystem that will be used by the scaffolder
+	fs machinery.Filesystem
+}
+
+// NewDeleteAPIScaffolder returns a new Scaffolder for API deletion operations
+func NewDeleteAPIScaffolder(config config.Config, res resource.Resource, force bool) plugins.Scaffolder {
+	return &deleteAPIScaffolder{
+		config:   config,
+		resource: res,
+		force:    force,
+	}
+}
+
+// InjectFS implements cmdutil.Scaffolder
+func (s *deleteAPIScaffolder) InjectFS(fs machinery.Filesystem) {
+	s.fs = fs
+}
+
+// Scaffold implements cmdutil.Scaffolder
+func (s *deleteAPIScaffolder) Scaffold() error {
+	log.Println("Removing kustomize manifests...")
+
+	if !s.resource.HasAPI() {
+		return nil
+	}
+
+	remaining, err := remainingResources(s.config, s.resource.GVK)
+	if err != nil {
+		return fmt.Errorf("error deleting kustomize API manifests: %w", err)
+	}
+
+	scaffold := machinery.NewScaffold(s.fs,
+		machinery.WithConfig(s.config),
+		machinery.WithResource(&s.resource),
+	)
+
+	builders := []machinery.Builder{
+		machinery.Remove(&samples.CRDSample{}, s.force),
+		machinery.Eject(&samples.Kustomization{}, s.force),
+	}
+
+	// The CRD and its roles are shared by the versions of the kind and only removed along with the last of them
+	kept := make([]machinery.Builder, 0)
+	sameKind, anyAPI := false, false
+	for i := range remaining {
+		res := &remaining[i]
+		if !res.HasAPI() {
+			continue
+		}
+		anyAPI = true
+		sameKind = sameKind || (res.Group == s.resource.Group && res.Domain == s.resource.Domain &&
+			res.Kind == s.resource.Kind)
+		kept = append(kept, &crd.Kustomization{ResourceMixin: machinery.ResourceMixin{Resource: res}})
+	}
+	builders = append(builders, machinery.Eject(&crd.Kustomization{}, s.force, kept...))
+
+	if !sameKind {
+		crdName := strings.ToLower(s.resource.Kind)
+		if s.config.IsMultiGroup() && s.resource.Group != "" {
+			crdName = strings.ToLower(s.resource.Group) + "_" + crdName
+		}
+
+		base := &machinery.RemoverMixin{Optional: true}
+		base.Path = filepath.Join("config", "crd", "bases",
+			fmt.Sprintf("%s_%s.yaml", s.resource.QualifiedGroup(), s.resource.Plural))
+
+		builders = append(builders,
+			machinery.Remove(&rbac.CRDEditorRole{}, s.force),
+			machinery.Remove(&rbac.CRDViewerRole{}, s.force),
+			base,
+			machinery.Eject(&kustomization.Updater{
+				Path:      "config/rbac/kustomization.yaml",
+				Resources: []string{crdName + "_editor_role.yaml", crdName + "_viewer_role.yaml"},
+			}, s.force),
+		)
+	}
+
+	if !anyAPI {
+		builders = append(builders, machinery.Eject(&kustomization.Updater{
+			Path:      "config/default/kustomization.yaml",
+			Resources: []string{"../crd"},
+		}, s.force))
+	}
+
+	if _, err := scaffold.Execute(builders...); err != nil {
+		return fmt.Errorf("error deleting kustomize API manifests: %w", err)
+	}
+
+	return nil
+}
+
+// deleteWebhookScaffolder contains configuration for removing the kustomize manifests of the webhooks of a resource.
+type deleteWebhookScaffolder struct {
+	config   config.Config
+	resource resource.Resource
+
+	// force indicates whether to remove the files and code modified since they were scaffolded
+	force bool
+
+	// fs is the filesystem that will be used by the scaffolder
+	fs machinery.Filesystem
+}
+
+// NewDeleteWebhookScaffolder returns a new Scaffolder for webhook deletion operations
+func NewDeleteWebhookScaffolder(config config.Config, res resource.Resource, force bool) plugins.Scaffolder {
+	return &deleteWebhookScaffolder{
+		config:   config,
+		resource: res,
+		force:    force,
+	}
+}
+
+// InjectFS implements cmdutil.Scaffolder
+func (s *deleteWebhookScaffolder) InjectFS(fs machinery.Filesystem) {
+	s.fs = fs
+}
+
+// Scaffold implements cmdutil.Scaffolder
+func (s *deleteWebhookScaffolder) Scaffold() error {
+	log.Println("Removing kustomize manifests...")
+
+	// Resources that were only tracked because of their webhooks are removed
+	updated := s.resource.Copy()
+	updated.Webhooks = nil
+	if updated.HasAPI() || updated.HasController() {
+		if err := s.config.SetResource(updated); err != nil {
+			return fmt.Errorf("error updating resource: %w", err)
+		}
+	} else if err := s.config.RemoveResource(s.resource.GVK); err != nil &&
+		!errors.As(err, &config.ResourceNotFoundError{}) {
+		return fmt.Errorf("error removing resource: %w", err)
+	}
+
+	remaining, err := s.config.GetResources()
+	if err != nil {
+		return fmt.Errorf("error deleting kustomize webhook manifests: %w", err)
+	}
+
+	scaffold := machinery.NewScaffold(s.fs,
+		machinery.WithConfig(s.config),
+		machinery.WithResource(&s.resource),
+	)
+
+	builders := make([]machinery.Builder, 0)
+	if !s.resource.External && !s.resource.Core {
+		kept := make([]machinery.Builder, 0)
+		for i := range remaining {
+			if res := &remaining[i]; !res.External && !res.Core {
+				kept = append(kept, &crd.Kustomization{ResourceMixin: machinery.ResourceMixin{Resource: res}})
+			}
+		}
+		builders = append(builders, machinery.Eject(&crd.Kustomization{}, s.force, kept...))
+	}
+
+	// The conversion patches are shared by the versions of the kind and only removed along with the last of them
+	if s.resource.HasConversionWebhook() {
+		sameKind := false
+		for _, res := range remaining {
+			sameKind = sameKind || (res.HasConversionWebhook() && res.Group == s.resource.Group &&
+				res.Domain == s.resource.Domain && res.Kind == s.resource.Kind)
+		}
+		if !sameKind {
+			builders = append(builders,
+				machinery.Remove(&patches.EnableWebhookPatch{}, s.force),
+				machinery.Remove(&patches.EnableCAInjectionPatch{}, s.force),
+			)
+		}
+	}
+
+	if _, err := scaffold.Execute(builders...); err != nil {
+		return fmt.Errorf("error deleting kustomize webhook manifests: %w", err)
+	}
+
+	// The webhook server is set up for the whole project, so it is kept even if no webhook remains
+	anyWebhook := false
+	for _, res := range remaining {
+		anyWebhook = anyWebhook || (res.Webhooks != nil && !res.Webhooks.IsEmpty())
+	}
+	if !anyWebhook {
+		log.Println("The manifests of the webhook server and cert-manager are kept in config/, " +
+			"remove them from config/default/kustomization.yaml if they are no longer needed.")
+	}
+
+	return nil
+}
+
+// remainingResources returns the resources of the configuration other than the one with the provided GVK
+func remainingResources(cfg config.Config, gvk resource.GVK) ([]resource.Resource, error) {
+	resources, err := cfg.GetResources()
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := make([]resource.Resource, 0, len(resources))
+	for _, res := range resources {
+		if !res.GVK.IsEqualTo(gvk) {
+			remaining = append(remaining, res)
+		}
+	}
+	return remaining, nil
+}
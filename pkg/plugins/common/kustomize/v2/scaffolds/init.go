@@ -86,5 +86,6 @@ func (s *initScaffolder) Scaffold() error {
 		&prometheus.Monitor{},
 	}
 
-	return scaffold.Execute(templates...)
+	_, err := scaffold.Execute(templates...)
+	return err
 }
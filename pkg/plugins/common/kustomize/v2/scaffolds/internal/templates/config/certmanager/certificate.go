@@ -20,6 +20,7 @@ import (
 	"path/filepath"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates"
 )
 
 var _ machinery.Template = &Certificate{}
@@ -36,47 +37,14 @@ func (f *Certificate) SetTemplateDefaults() error {
 		f.Path = filepath.Join("config", "certmanager", "certificate.yaml")
 	}
 
-	f.TemplateBody = certManagerTemplate
+	body, err := machinery.ReadTemplateBody(templates.FS, "config/certmanager/certificate.yaml.tmpl")
+	if err != nil {
+		return err
+	}
+	f.TemplateBody = body
 
 	// If file exists (ex. because a webhook was already created), skip creation.
 	f.IfExistsAction = machinery.SkipFile
 
 	return nil
 }
-
-const certManagerTemplate = `# The following manifests contain a self-signed issuer CR and a certificate CR.
-# More document can be found at https://docs.cert-manager.io
-# WARNING: Targets CertManager v1.0. Check https://cert-manager.io/docs/installation/upgrading/ for breaking changes.
-apiVersion: cert-manager.io/v1
-kind: Issuer
-metadata:
-  labels:
-    app.kubernetes.io/name: {{ .ProjectName }}
-    app.kubernetes.io/managed-by: kustomize
-  name: selfsigned-issuer
-  namespace: system
-spec:
-  selfSigned: {}
----
-apiVersion: cert-manager.io/v1
-kind: Certificate
-metadata:
-  labels:
-    app.kubernetes.io/name: certificate
-    app.kubernetes.io/instance: serving-cert
-    app.kubernetes.io/component: certificate
-    app.kubernetes.io/created-by: {{ .ProjectName }}
-    app.kubernetes.io/part-of: {{ .ProjectName }}
-    app.kubernetes.io/managed-by: kustomize
-  name: serving-cert  # this name should match the one appeared in kustomizeconfig.yaml
-  namespace: system
-spec:
-  # SERVICE_NAME and SERVICE_NAMESPACE will be substituted by kustomize
-  dnsNames:
-  - SERVICE_NAME.SERVICE_NAMESPACE.svc
-  - SERVICE_NAME.SERVICE_NAMESPACE.svc.cluster.local
-  issuerRef:
-    kind: Issuer
-    name: selfsigned-issuer
-  secretName: webhook-server-cert # this secret will not be prefixed, since it's not managed by kustomize
-`
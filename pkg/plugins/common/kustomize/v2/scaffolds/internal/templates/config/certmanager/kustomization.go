@@ -20,6 +20,7 @@ import (
 	"path/filepath"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates"
 )
 
 var _ machinery.Template = &Kustomization{}
@@ -35,17 +36,14 @@ func (f *Kustomization) SetTemplateDefaults() error {
 		f.Path = filepath.Join("config", "certmanager", "kustomization.yaml")
 	}
 
-	f.TemplateBody = kustomizationTemplate
+	body, err := machinery.ReadTemplateBody(templates.FS, "config/certmanager/kustomization.yaml.tmpl")
+	if err != nil {
+		return err
+	}
+	f.TemplateBody = body
 
 	// If file exists (ex. because a webhook was already created), skip creation.
 	f.IfExistsAction = machinery.SkipFile
 
 	return nil
 }
-
-const kustomizationTemplate = `resources:
-- certificate.yaml
-
-configurations:
-- kustomizeconfig.yaml
-`
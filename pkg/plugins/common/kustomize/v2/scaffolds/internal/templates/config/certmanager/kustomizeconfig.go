@@ -20,6 +20,7 @@ import (
 	"path/filepath"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates"
 )
 
 var _ machinery.Template = &KustomizeConfig{}
@@ -35,21 +36,14 @@ func (f *KustomizeConfig) SetTemplateDefaults() error {
 		f.Path = filepath.Join("config", "certmanager", "kustomizeconfig.yaml")
 	}
 
-	f.TemplateBody = kustomizeConfigTemplate
+	body, err := machinery.ReadTemplateBody(templates.FS, "config/certmanager/kustomizeconfig.yaml.tmpl")
+	if err != nil {
+		return err
+	}
+	f.TemplateBody = body
 
 	// If file exists (ex. because a webhook was already created), skip creation.
 	f.IfExistsAction = machinery.SkipFile
 
 	return nil
 }
-
-//nolint:lll
-const kustomizeConfigTemplate = `# This configuration is for teaching kustomize how to update name ref substitution
-nameReference:
-- kind: Issuer
-  group: cert-manager.io
-  fieldSpecs:
-  - kind: Certificate
-    group: cert-manager.io
-    path: spec/issuerRef/name
-`
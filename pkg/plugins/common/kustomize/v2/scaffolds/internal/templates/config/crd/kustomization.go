@@ -21,32 +21,48 @@ import (
 	"path/filepath"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates"
 )
 
 var (
-	_ machinery.Template = &Kustomization{}
-	_ machinery.Inserter = &Kustomization{}
+	_ machinery.Template     = &Kustomization{}
+	_ machinery.Inserter     = &Kustomization{}
+	_ machinery.YAMLInserter = &Kustomization{}
 )
 
+// DefaultKustomizationPath is the path of the kustomization scheme for the crd folder
+var DefaultKustomizationPath = filepath.Join("config", "crd", "kustomization.yaml")
+
 // Kustomization scaffolds a file that defines the kustomization scheme for the crd folder
 type Kustomization struct {
 	machinery.TemplateMixin
 	machinery.MultiGroupMixin
 	machinery.ResourceMixin
+
+	// ResourceMarker is the marker after the CRD resources, which are appended as YAML values
+	ResourceMarker machinery.Marker
+	// WebhookPatchMarker is the marker after the webhook patches, which are appended as YAML values
+	WebhookPatchMarker machinery.Marker
+	// CAInjectionPatchMarker is the marker where the commented CA injection patches are inserted
+	CAInjectionPatchMarker machinery.Marker
 }
 
 // SetTemplateDefaults implements file.Template
 func (f *Kustomization) SetTemplateDefaults() error {
 	if f.Path == "" {
-		f.Path = filepath.Join("config", "crd", "kustomization.yaml")
+		f.Path = DefaultKustomizationPath
 	}
 	f.Path = f.Resource.Replacer().Replace(f.Path)
 
-	f.TemplateBody = fmt.Sprintf(kustomizationTemplate,
-		machinery.NewMarkerFor(f.Path, resourceMarker),
-		machinery.NewMarkerFor(f.Path, webhookPatchMarker),
-		machinery.NewMarkerFor(f.Path, caInjectionPatchMarker),
-	)
+	body, err := machinery.ReadTemplateBody(templates.FS, "config/crd/kustomization.yaml.tmpl")
+	if err != nil {
+		return err
+	}
+	f.TemplateBody = body
+
+	f.ResourceMarker = machinery.NewMarkerFor(f.Path, resourceMarker)
+	f.WebhookPatchMarker = machinery.NewMarkerFor(f.Path, webhookPatchMarker)
+	f.CAInjectionPatchMarker = machinery.NewMarkerFor(f.Path, caInjectionPatchMarker)
 
 	return nil
 }
@@ -61,78 +77,54 @@ const (
 // GetMarkers implements file.Inserter
 func (f *Kustomization) GetMarkers() []machinery.Marker {
 	return []machinery.Marker{
-		machinery.NewMarkerFor(f.Path, resourceMarker),
-		machinery.NewMarkerFor(f.Path, webhookPatchMarker),
 		machinery.NewMarkerFor(f.Path, caInjectionPatchMarker),
 	}
 }
 
 const (
-	resourceCodeFragment = `- bases/%s_%s.yaml
-`
-	webhookPatchCodeFragment = `- path: patches/webhook_in_%s.yaml
-`
+	resourcePath                 = "bases/%s_%s.yaml"
+	webhookPatchPath             = "patches/webhook_in_%s.yaml"
 	caInjectionPatchCodeFragment = `#- path: patches/cainjection_in_%s.yaml
 `
 )
 
 // GetCodeFragments implements file.Inserter
 func (f *Kustomization) GetCodeFragments() machinery.CodeFragmentsMap {
-	fragments := make(machinery.CodeFragmentsMap, 3)
-
-	// Generate resource code fragments
-	res := make([]string, 0)
-	res = append(res, fmt.Sprintf(resourceCodeFragment, f.Resource.QualifiedGroup(), f.Resource.Plural))
-
-	suffix := f.Resource.Plural
-	if f.MultiGroup && f.Resource.Group != "" {
-		suffix = f.Resource.Group + "_" + f.Resource.Plural
-	}
-
-	if !f.Resource.Webhooks.IsEmpty() && f.Resource.Webhooks.Conversion {
-		webhookPatch := fmt.Sprintf(webhookPatchCodeFragment, suffix)
+	fragments := make(machinery.CodeFragmentsMap, 1)
 
-		marker := machinery.NewMarkerFor(f.Path, webhookPatchMarker)
-		if _, exists := fragments[marker]; !exists {
-			fragments[marker] = []string{webhookPatch}
+	// The CA injection patch is scaffolded commented out, so it can not be added as a YAML value
+	if f.Resource.HasConversionWebhook() {
+		fragments[machinery.NewMarkerFor(f.Path, caInjectionPatchMarker)] = []string{
+			fmt.Sprintf(caInjectionPatchCodeFragment, f.suffix()),
 		}
 	}
 
-	// Generate resource code fragments
-	caInjectionPatch := make([]string, 0)
-	if !f.Resource.Webhooks.IsEmpty() && f.Resource.Webhooks.Conversion {
-		caInjectionPatch = append(caInjectionPatch, fmt.Sprintf(caInjectionPatchCodeFragment, suffix))
-	}
+	return fragments
+}
 
-	// Only store code fragments in the map if the slices are non-empty
-	if len(res) != 0 {
-		fragments[machinery.NewMarkerFor(f.Path, resourceMarker)] = res
+// GetYAMLEdits implements machinery.YAMLInserter
+func (f *Kustomization) GetYAMLEdits() []machinery.YAMLEdit {
+	edits := []machinery.YAMLEdit{
+		machinery.YAMLAppend{
+			Path:   []string{"resources"},
+			Values: []interface{}{fmt.Sprintf(resourcePath, f.Resource.QualifiedGroup(), f.Resource.Plural)},
+		},
 	}
 
-	if len(caInjectionPatch) != 0 {
-		fragments[machinery.NewMarkerFor(f.Path, caInjectionPatchMarker)] = caInjectionPatch
+	if f.Resource.HasConversionWebhook() {
+		edits = append(edits, machinery.YAMLAppend{
+			Path:   []string{"patches"},
+			Values: []interface{}{map[string]interface{}{"path": fmt.Sprintf(webhookPatchPath, f.suffix())}},
+		})
 	}
 
-	return fragments
+	return edits
 }
 
-var kustomizationTemplate = `# This kustomization.yaml is not intended to be run by itself,
-# since it depends on service name and namespace that are out of this kustomize package.
-# It should be run by config/default
-resources:
-%s
-
-patches:
-# [WEBHOOK] To enable webhook, uncomment all the sections with [WEBHOOK] prefix.
-# patches here are for enabling the conversion webhook for each CRD
-%s
-
-# [CERTMANAGER] To enable cert-manager, uncomment all the sections with [CERTMANAGER] prefix.
-# patches here are for enabling the CA injection for each CRD
-%s
-
-# [WEBHOOK] To enable webhook, uncomment the following section
-# the following config is for teaching kustomize how to do kustomization for CRDs.
-#configurations:
-#- kustomizeconfig.yaml
-`
+// suffix returns the suffix of the patches for the resource
+func (f *Kustomization) suffix() string {
+	if f.MultiGroup && f.Resource.Group != "" {
+		return f.Resource.Group + "_" + f.Resource.Plural
+	}
+	return f.Resource.Plural
+}
@@ -20,6 +20,7 @@ import (
 	"path/filepath"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates"
 )
 
 var _ machinery.Template = &KustomizeConfig{}
@@ -36,29 +37,11 @@ func (f *KustomizeConfig) SetTemplateDefaults() error {
 		f.Path = filepath.Join("config", "crd", "kustomizeconfig.yaml")
 	}
 
-	f.TemplateBody = kustomizeConfigTemplate
+	body, err := machinery.ReadTemplateBody(templates.FS, "config/crd/kustomizeconfig.yaml.tmpl")
+	if err != nil {
+		return err
+	}
+	f.TemplateBody = body
 
 	return nil
 }
-
-//nolint:lll
-const kustomizeConfigTemplate = `# This file is for teaching kustomize how to substitute name and namespace reference in CRD
-nameReference:
-- kind: Service
-  version: v1
-  fieldSpecs:
-  - kind: CustomResourceDefinition
-    version: v1
-    group: apiextensions.k8s.io
-    path: spec/conversion/webhook/clientConfig/service/name
-
-namespace:
-- kind: CustomResourceDefinition
-  version: v1
-  group: apiextensions.k8s.io
-  path: spec/conversion/webhook/clientConfig/service/namespace
-  create: false
-
-varReference:
-- path: metadata/annotations
-`
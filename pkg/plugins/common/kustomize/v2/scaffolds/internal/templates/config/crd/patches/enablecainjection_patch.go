@@ -20,6 +20,7 @@ import (
 	"path/filepath"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates"
 )
 
 var _ machinery.Template = &EnableCAInjectionPatch{}
@@ -42,17 +43,11 @@ func (f *EnableCAInjectionPatch) SetTemplateDefaults() error {
 	}
 	f.Path = f.Resource.Replacer().Replace(f.Path)
 
-	f.TemplateBody = enableCAInjectionPatchTemplate
+	body, err := machinery.ReadTemplateBody(templates.FS, "config/crd/patches/enablecainjection_patch.yaml.tmpl")
+	if err != nil {
+		return err
+	}
+	f.TemplateBody = body
 
 	return nil
 }
-
-//nolint:lll
-const enableCAInjectionPatchTemplate = `# The following patch adds a directive for certmanager to inject CA into the CRD
-apiVersion: apiextensions.k8s.io/v1
-kind: CustomResourceDefinition
-metadata:
-  annotations:
-    cert-manager.io/inject-ca-from: CERTIFICATE_NAMESPACE/CERTIFICATE_NAME
-  name: {{ .Resource.Plural }}.{{ .Resource.QualifiedGroup }}
-`
@@ -20,6 +20,7 @@ import (
 	"path/filepath"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates"
 )
 
 var _ machinery.Template = &ManagerMetricsPatch{}
@@ -35,15 +36,13 @@ func (f *ManagerMetricsPatch) SetTemplateDefaults() error {
 		f.Path = filepath.Join("config", "default", "manager_metrics_patch.yaml")
 	}
 
-	f.TemplateBody = kustomizeMetricsPatchTemplate
+	body, err := machinery.ReadTemplateBody(templates.FS, "config/kdefault/manager_metrics_patch.yaml.tmpl")
+	if err != nil {
+		return err
+	}
+	f.TemplateBody = body
 
 	f.IfExistsAction = machinery.Error
 
 	return nil
 }
-
-const kustomizeMetricsPatchTemplate = `# This patch adds the args to allow exposing the metrics endpoint using HTTPS
-- op: add
-  path: /spec/template/spec/containers/0/args/0
-  value: --metrics-bind-address=:8443
-`
@@ -20,6 +20,7 @@ import (
 	"path/filepath"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates"
 )
 
 var _ machinery.Template = &MetricsService{}
@@ -36,26 +37,11 @@ func (f *MetricsService) SetTemplateDefaults() error {
 		f.Path = filepath.Join("config", "default", "metrics_service.yaml")
 	}
 
-	f.TemplateBody = metricsServiceTemplate
+	body, err := machinery.ReadTemplateBody(templates.FS, "config/kdefault/metrics_service.yaml.tmpl")
+	if err != nil {
+		return err
+	}
+	f.TemplateBody = body
 
 	return nil
 }
-
-const metricsServiceTemplate = `apiVersion: v1
-kind: Service
-metadata:
-  labels:
-    control-plane: controller-manager
-    app.kubernetes.io/name: {{ .ProjectName }}
-    app.kubernetes.io/managed-by: kustomize
-  name: controller-manager-metrics-service
-  namespace: system
-spec:
-  ports:
-  - name: https
-    port: 8443
-    protocol: TCP
-    targetPort: 8443
-  selector:
-    control-plane: controller-manager
-`
@@ -20,6 +20,7 @@ import (
 	"path/filepath"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates"
 )
 
 var _ machinery.Template = &ManagerWebhookPatch{}
@@ -38,10 +39,14 @@ func (f *ManagerWebhookPatch) SetTemplateDefaults() error {
 		f.Path = filepath.Join("config", "default", "manager_webhook_patch.yaml")
 	}
 
-	f.TemplateBody = managerWebhookPatchTemplate
+	body, err := machinery.ReadTemplateBody(templates.FS, "config/kdefault/webhook_manager_patch.yaml.tmpl")
+	if err != nil {
+		return err
+	}
+	f.TemplateBody = body
 
 	if f.Force {
-		f.IfExistsAction = machinery.OverwriteFile
+		f.IfExistsAction = machinery.WriteSidecarIfModified
 	} else {
 		// If file exists (ex. because a webhook was already created), skip creation.
 		f.IfExistsAction = machinery.SkipFile
@@ -49,31 +54,3 @@ func (f *ManagerWebhookPatch) SetTemplateDefaults() error {
 
 	return nil
 }
-
-const managerWebhookPatchTemplate = `apiVersion: apps/v1
-kind: Deployment
-metadata:
-  name: controller-manager
-  namespace: system
-  labels:
-    app.kubernetes.io/name: {{ .ProjectName }}
-    app.kubernetes.io/managed-by: kustomize
-spec:
-  template:
-    spec:
-      containers:
-      - name: manager
-        ports:
-        - containerPort: 9443
-          name: webhook-server
-          protocol: TCP
-        volumeMounts:
-        - mountPath: /tmp/k8s-webhook-server/serving-certs
-          name: cert
-          readOnly: true
-      volumes:
-      - name: cert
-        secret:
-          defaultMode: 420
-          secretName: webhook-server-cert
-`
@@ -0,0 +1,79 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kustomization
+
+import (
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.YAMLInserter = &Updater{}
+
+// Updater appends entries to the lists of an existing kustomization.yaml file
+type Updater struct {
+	// Path is the path of the kustomization.yaml file
+	Path string
+
+	// Resources are appended to the resources list
+	Resources []string
+	// Patches are appended to the patches list
+	Patches []string
+	// Components are appended to the components list
+	Components []string
+	// Configurations are appended to the configurations list
+	Configurations []string
+}
+
+// GetPath implements machinery.Builder
+func (f *Updater) GetPath() string {
+	return f.Path
+}
+
+// GetIfExistsAction implements machinery.Builder
+func (*Updater) GetIfExistsAction() machinery.IfExistsAction {
+	return machinery.OverwriteFile
+}
+
+// GetYAMLEdits implements machinery.YAMLInserter
+func (f *Updater) GetYAMLEdits() []machinery.YAMLEdit {
+	var edits []machinery.YAMLEdit
+
+	appendEdit := func(key string, values []interface{}) {
+		if len(values) != 0 {
+			edits = append(edits, machinery.YAMLAppend{Path: []string{key}, Values: values})
+		}
+	}
+
+	appendEdit("resources", toValues(f.Resources))
+	patches := make([]interface{}, 0, len(f.Patches))
+	for _, patch := range f.Patches {
+		patches = append(patches, map[string]interface{}{"path": patch})
+	}
+	appendEdit("patches", patches)
+	appendEdit("components", toValues(f.Components))
+	appendEdit("configurations", toValues(f.Configurations))
+
+	return edits
+}
+
+// toValues converts a list of strings into a list of YAML values
+func toValues(values []string) []interface{} {
+	result := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		result = append(result, v)
+	}
+	return result
+}
@@ -20,6 +20,7 @@ import (
 	"path/filepath"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates"
 )
 
 var _ machinery.Template = &Kustomization{}
@@ -35,13 +36,13 @@ func (f *Kustomization) SetTemplateDefaults() error {
 		f.Path = filepath.Join("config", "manager", "kustomization.yaml")
 	}
 
-	f.TemplateBody = kustomizeManagerTemplate
+	body, err := machinery.ReadTemplateBody(templates.FS, "config/manager/kustomization.yaml.tmpl")
+	if err != nil {
+		return err
+	}
+	f.TemplateBody = body
 
 	f.IfExistsAction = machinery.Error
 
 	return nil
 }
-
-const kustomizeManagerTemplate = `resources:
-- manager.yaml
-`
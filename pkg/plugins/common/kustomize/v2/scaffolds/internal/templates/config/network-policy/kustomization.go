@@ -20,6 +20,7 @@ import (
 	"path/filepath"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates"
 )
 
 var _ machinery.Template = &Kustomization{}
@@ -35,11 +36,11 @@ func (f *Kustomization) SetTemplateDefaults() error {
 		f.Path = filepath.Join("config", "network-policy", "kustomization.yaml")
 	}
 
-	f.TemplateBody = kustomizationTemplate
+	body, err := machinery.ReadTemplateBody(templates.FS, "config/network-policy/kustomization.yaml.tmpl")
+	if err != nil {
+		return err
+	}
+	f.TemplateBody = body
 
 	return nil
 }
-
-const kustomizationTemplate = `resources:
-- allow-metrics-traffic.yaml
-`
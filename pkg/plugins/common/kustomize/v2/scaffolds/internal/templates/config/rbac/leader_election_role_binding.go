@@ -20,6 +20,7 @@ import (
 	"path/filepath"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates"
 )
 
 var _ machinery.Template = &LeaderElectionRoleBinding{}
@@ -36,24 +37,11 @@ func (f *LeaderElectionRoleBinding) SetTemplateDefaults() error {
 		f.Path = filepath.Join("config", "rbac", "leader_election_role_binding.yaml")
 	}
 
-	f.TemplateBody = leaderElectionRoleBindingTemplate
+	body, err := machinery.ReadTemplateBody(templates.FS, "config/rbac/leader_election_role_binding.yaml.tmpl")
+	if err != nil {
+		return err
+	}
+	f.TemplateBody = body
 
 	return nil
 }
-
-const leaderElectionRoleBindingTemplate = `apiVersion: rbac.authorization.k8s.io/v1
-kind: RoleBinding
-metadata:
-  labels:
-    app.kubernetes.io/name: {{ .ProjectName }}
-    app.kubernetes.io/managed-by: kustomize
-  name: leader-election-rolebinding
-roleRef:
-  apiGroup: rbac.authorization.k8s.io
-  kind: Role
-  name: leader-election-role
-subjects:
-- kind: ServiceAccount
-  name: controller-manager
-  namespace: system
-`
@@ -17,6 +17,7 @@ import (
 	"path/filepath"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates"
 )
 
 var _ machinery.Template = &MetricsAuthRole{}
@@ -33,26 +34,11 @@ func (f *MetricsAuthRole) SetTemplateDefaults() error {
 		f.Path = filepath.Join("config", "rbac", "metrics_auth_role.yaml")
 	}
 
-	f.TemplateBody = metricsAuthRoleTemplate
+	body, err := machinery.ReadTemplateBody(templates.FS, "config/rbac/metrics_auth_role.yaml.tmpl")
+	if err != nil {
+		return err
+	}
+	f.TemplateBody = body
 
 	return nil
 }
-
-const metricsAuthRoleTemplate = `apiVersion: rbac.authorization.k8s.io/v1
-kind: ClusterRole
-metadata:
-  name: metrics-auth-role
-rules:
-- apiGroups:
-  - authentication.k8s.io
-  resources:
-  - tokenreviews
-  verbs:
-  - create
-- apiGroups:
-  - authorization.k8s.io
-  resources:
-  - subjectaccessreviews
-  verbs:
-  - create
-`
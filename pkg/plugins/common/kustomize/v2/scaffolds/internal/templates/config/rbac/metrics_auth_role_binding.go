@@ -17,6 +17,7 @@ import (
 	"path/filepath"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates"
 )
 
 var _ machinery.Template = &MetricsAuthRole{}
@@ -33,21 +34,11 @@ func (f *MetricsAuthRoleBinding) SetTemplateDefaults() error {
 		f.Path = filepath.Join("config", "rbac", "metrics_auth_role_binding.yaml")
 	}
 
-	f.TemplateBody = metricsAuthRoleBindingTemplate
+	body, err := machinery.ReadTemplateBody(templates.FS, "config/rbac/metrics_auth_role_binding.yaml.tmpl")
+	if err != nil {
+		return err
+	}
+	f.TemplateBody = body
 
 	return nil
 }
-
-const metricsAuthRoleBindingTemplate = `apiVersion: rbac.authorization.k8s.io/v1
-kind: ClusterRoleBinding
-metadata:
-  name: metrics-auth-rolebinding
-roleRef:
-  apiGroup: rbac.authorization.k8s.io
-  kind: ClusterRole
-  name: metrics-auth-role
-subjects:
-- kind: ServiceAccount
-  name: controller-manager
-  namespace: system
-`
@@ -17,6 +17,7 @@ import (
 	"path/filepath"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates"
 )
 
 var _ machinery.Template = &MetricsReaderRole{}
@@ -33,18 +34,11 @@ func (f *MetricsReaderRole) SetTemplateDefaults() error {
 		f.Path = filepath.Join("config", "rbac", "metrics_reader_role.yaml")
 	}
 
-	f.TemplateBody = metricsReaderRoleTemplate
+	body, err := machinery.ReadTemplateBody(templates.FS, "config/rbac/metrics_reader_role.yaml.tmpl")
+	if err != nil {
+		return err
+	}
+	f.TemplateBody = body
 
 	return nil
 }
-
-const metricsReaderRoleTemplate = `apiVersion: rbac.authorization.k8s.io/v1
-kind: ClusterRole
-metadata:
-  name: metrics-reader
-rules:
-- nonResourceURLs:
-  - "/metrics"
-  verbs:
-  - get
-`
@@ -20,6 +20,7 @@ import (
 	"path/filepath"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates"
 )
 
 var _ machinery.Template = &Role{}
@@ -36,22 +37,13 @@ func (f *Role) SetTemplateDefaults() error {
 		f.Path = filepath.Join("config", "rbac", "role.yaml")
 	}
 
-	f.TemplateBody = managerRoleTemplate
+	body, err := machinery.ReadTemplateBody(templates.FS, "config/rbac/role.yaml.tmpl")
+	if err != nil {
+		return err
+	}
+	f.TemplateBody = body
 
 	f.IfExistsAction = machinery.SkipFile
 
 	return nil
 }
-
-const managerRoleTemplate = `apiVersion: rbac.authorization.k8s.io/v1
-kind: ClusterRole
-metadata:
-  labels:
-    app.kubernetes.io/name: {{ .ProjectName }}
-    app.kubernetes.io/managed-by: kustomize
-  name: manager-role
-rules:
-- apiGroups: [""]
-  resources: ["pods"]
-  verbs: ["get", "list", "watch"]
-`
@@ -20,6 +20,7 @@ import (
 	"path/filepath"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates"
 )
 
 var _ machinery.Template = &ServiceAccount{}
@@ -36,17 +37,11 @@ func (f *ServiceAccount) SetTemplateDefaults() error {
 		f.Path = filepath.Join("config", "rbac", "service_account.yaml")
 	}
 
-	f.TemplateBody = serviceAccountTemplate
+	body, err := machinery.ReadTemplateBody(templates.FS, "config/rbac/service_account.yaml.tmpl")
+	if err != nil {
+		return err
+	}
+	f.TemplateBody = body
 
 	return nil
 }
-
-const serviceAccountTemplate = `apiVersion: v1
-kind: ServiceAccount
-metadata:
-  labels:
-    app.kubernetes.io/name: {{ .ProjectName }}
-    app.kubernetes.io/managed-by: kustomize
-  name: controller-manager
-  namespace: system
-`
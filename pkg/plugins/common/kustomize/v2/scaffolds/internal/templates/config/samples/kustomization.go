@@ -17,21 +17,24 @@ limitations under the License.
 package samples
 
 import (
-	"fmt"
 	"path/filepath"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates"
 )
 
 var (
-	_ machinery.Template = &Kustomization{}
-	_ machinery.Inserter = &Kustomization{}
+	_ machinery.Template     = &Kustomization{}
+	_ machinery.YAMLInserter = &Kustomization{}
 )
 
 // Kustomization scaffolds a kustomization.yaml for the manifests overlay folder.
 type Kustomization struct {
 	machinery.TemplateMixin
 	machinery.ResourceMixin
+
+	// SamplesMarker is the marker after the samples, which are appended as YAML values
+	SamplesMarker machinery.Marker
 }
 
 // SetTemplateDefaults implements machinery.Template
@@ -39,22 +42,19 @@ func (f *Kustomization) SetTemplateDefaults() error {
 	if f.Path == "" {
 		f.Path = filepath.Join("config", "samples", "kustomization.yaml")
 	}
-	f.TemplateBody = fmt.Sprintf(kustomizationTemplate, machinery.NewMarkerFor(f.Path, samplesMarker))
-
-	return nil
-}
+	body, err := machinery.ReadTemplateBody(templates.FS, "config/samples/kustomization.yaml.tmpl")
+	if err != nil {
+		return err
+	}
+	f.TemplateBody = body
 
-const (
-	samplesMarker = "manifestskustomizesamples"
-)
+	f.SamplesMarker = machinery.NewMarkerFor(f.Path, samplesMarker)
 
-// GetMarkers implements file.Inserter
-func (f *Kustomization) GetMarkers() []machinery.Marker {
-	return []machinery.Marker{machinery.NewMarkerFor(f.Path, samplesMarker)}
+	return nil
 }
 
-const samplesCodeFragment = `- %s
-`
+// samplesMarker is scaffolded so that other plugins can insert code fragments at it
+const samplesMarker = "manifestskustomizesamples"
 
 // makeCRFileName returns a Custom Resource example file name in the same format
 // as kubebuilder's CreateAPI plugin for a gvk.
@@ -66,14 +66,9 @@ func (f Kustomization) makeCRFileName() string {
 
 }
 
-// GetCodeFragments implements file.Inserter
-func (f *Kustomization) GetCodeFragments() machinery.CodeFragmentsMap {
-	return machinery.CodeFragmentsMap{
-		machinery.NewMarkerFor(f.Path, samplesMarker): []string{fmt.Sprintf(samplesCodeFragment, f.makeCRFileName())},
+// GetYAMLEdits implements machinery.YAMLInserter
+func (f *Kustomization) GetYAMLEdits() []machinery.YAMLEdit {
+	return []machinery.YAMLEdit{
+		machinery.YAMLAppend{Path: []string{"resources"}, Values: []interface{}{f.makeCRFileName()}},
 	}
 }
-
-const kustomizationTemplate = `## Append samples of your project ##
-resources:
-%s
-`
@@ -20,6 +20,7 @@ import (
 	"path/filepath"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates"
 )
 
 var _ machinery.Template = &Kustomization{}
@@ -38,10 +39,14 @@ func (f *Kustomization) SetTemplateDefaults() error {
 		f.Path = filepath.Join("config", "webhook", "kustomization.yaml")
 	}
 
-	f.TemplateBody = kustomizeWebhookTemplate
+	body, err := machinery.ReadTemplateBody(templates.FS, "config/webhook/kustomization.yaml.tmpl")
+	if err != nil {
+		return err
+	}
+	f.TemplateBody = body
 
 	if f.Force {
-		f.IfExistsAction = machinery.OverwriteFile
+		f.IfExistsAction = machinery.WriteSidecarIfModified
 	} else {
 		// If file exists (ex. because a webhook was already created), skip creation.
 		f.IfExistsAction = machinery.SkipFile
@@ -49,11 +54,3 @@ func (f *Kustomization) SetTemplateDefaults() error {
 
 	return nil
 }
-
-const kustomizeWebhookTemplate = `resources:
-- manifests{{ if ne .Resource.Webhooks.WebhookVersion "v1" }}.{{ .Resource.Webhooks.WebhookVersion }}{{ end }}.yaml
-- service.yaml
-
-configurations:
-- kustomizeconfig.yaml
-`
@@ -20,6 +20,7 @@ import (
 	"path/filepath"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates"
 )
 
 var _ machinery.Template = &Service{}
@@ -36,27 +37,14 @@ func (f *Service) SetTemplateDefaults() error {
 		f.Path = filepath.Join("config", "webhook", "service.yaml")
 	}
 
-	f.TemplateBody = serviceTemplate
+	body, err := machinery.ReadTemplateBody(templates.FS, "config/webhook/service.yaml.tmpl")
+	if err != nil {
+		return err
+	}
+	f.TemplateBody = body
 
 	// If file exists (ex. because a webhook was already created), skip creation.
 	f.IfExistsAction = machinery.SkipFile
 
 	return nil
 }
-
-const serviceTemplate = `apiVersion: v1
-kind: Service
-metadata:
-  labels:
-    app.kubernetes.io/name: {{ .ProjectName }}
-    app.kubernetes.io/managed-by: kustomize
-  name: webhook-service
-  namespace: system
-spec:
-  ports:
-    - port: 443
-      protocol: TCP
-      targetPort: 9443
-  selector:
-    control-plane: controller-manager
-`
@@ -0,0 +1,31 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates/config/crd"
+)
+
+// RequiredMarkers returns the markers of the files that the plugin updates when creating APIs and webhooks
+func RequiredMarkers(config.Config) ([]machinery.RequiredMarker, error) {
+	kustomization := &crd.Kustomization{}
+	kustomization.Path = crd.DefaultKustomizationPath
+
+	return machinery.RequiredMarkersOf(kustomization), nil
+}
@@ -25,11 +25,11 @@ import (
 	"sigs.k8s.io/kubebuilder/v4/pkg/config"
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
 	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
-	pluginutil "sigs.k8s.io/kubebuilder/v4/pkg/plugin/util"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates/config/certmanager"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates/config/crd"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates/config/kdefault"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates/config/kustomization"
 	network_policy "sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates/config/network-policy"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates/config/webhook"
 )
@@ -94,62 +94,41 @@ func (s *webhookScaffolder) Scaffold() error {
 		buildScaffold = append(buildScaffold, &crd.Kustomization{})
 	}
 
-	if err := scaffold.Execute(buildScaffold...); err != nil {
+	if _, err := scaffold.Execute(buildScaffold...); err != nil {
 		return fmt.Errorf("error scaffolding kustomize webhook manifests: %v", err)
 	}
 
-	policyKustomizeFilePath := "config/network-policy/kustomization.yaml"
-	err := pluginutil.InsertCodeIfNotExist(policyKustomizeFilePath,
-		"resources:", allowWebhookTrafficFragment)
-	if err != nil {
-		log.Errorf("Unable to add the line '- allow-webhook-traffic.yaml' at the end of the file"+
-			"%s to allow webhook traffic.", policyKustomizeFilePath)
-	}
-
+	// Uncomment the scaffolded entries if present, so that they keep their position in the lists
 	kustomizeFilePath := "config/default/kustomization.yaml"
-	err = pluginutil.UncommentCode(kustomizeFilePath, "#- ../webhook", `#`)
-	if err != nil {
-		hasWebHookUncommented, err := pluginutil.HasFileContentWith(kustomizeFilePath, "- ../webhook")
-		if !hasWebHookUncommented || err != nil {
-			log.Errorf("Unable to find the target #- ../webhook to uncomment in the file "+
-				"%s.", kustomizeFilePath)
-		}
-	}
-
-	err = pluginutil.UncommentCode(kustomizeFilePath, "#patches:", `#`)
-	if err != nil {
-		hasWebHookUncommented, err := pluginutil.HasFileContentWith(kustomizeFilePath, "patches:")
-		if !hasWebHookUncommented || err != nil {
-			log.Errorf("Unable to find the line '#patches:' to uncomment in the file "+
-				"%s.", kustomizeFilePath)
-		}
+	uncommentCode(s.fs, kustomizeFilePath, "#- ../webhook")
+	uncommentCode(s.fs, kustomizeFilePath, "#patches:")
+	uncommentCode(s.fs, kustomizeFilePath, "#- path: manager_webhook_patch.yaml")
+
+	crdKustomizationsFilePath := "config/crd/kustomization.yaml"
+	updaters := []machinery.Builder{
+		&kustomization.Updater{
+			Path:      "config/network-policy/kustomization.yaml",
+			Resources: []string{"allow-webhook-traffic.yaml"},
+		},
+		&kustomization.Updater{
+			Path:      kustomizeFilePath,
+			Resources: []string{"../webhook"},
+			Patches:   []string{"manager_webhook_patch.yaml"},
+		},
 	}
-
-	err = pluginutil.UncommentCode(kustomizeFilePath, "#- path: manager_webhook_patch.yaml", `#`)
-	if err != nil {
-		hasWebHookUncommented, err := pluginutil.HasFileContentWith(kustomizeFilePath, "- path: manager_webhook_patch.yaml")
-		if !hasWebHookUncommented || err != nil {
-			log.Errorf("Unable to find the target #- path: manager_webhook_patch.yaml to uncomment in the file "+
-				"%s.", kustomizeFilePath)
-		}
+	if s.resource.Webhooks.Conversion {
+		uncommentCode(s.fs, crdKustomizationsFilePath, "#configurations:\n#- kustomizeconfig.yaml")
+		updaters = append(updaters, &kustomization.Updater{
+			Path:           crdKustomizationsFilePath,
+			Configurations: []string{"kustomizeconfig.yaml"},
+		})
 	}
 
-	if s.resource.Webhooks.Conversion {
-		crdKustomizationsFilePath := "config/crd/kustomization.yaml"
-		err = pluginutil.UncommentCode(crdKustomizationsFilePath, "#configurations:\n#- kustomizeconfig.yaml", `#`)
-		if err != nil {
-			hasWebHookUncommented, err := pluginutil.HasFileContentWith(crdKustomizationsFilePath,
-				"configurations:\n- kustomizeconfig.yaml")
-			if !hasWebHookUncommented || err != nil {
-				log.Warningf("Unable to find the target(s) configurations.kustomizeconfig.yaml "+
-					"to uncomment in the file "+
-					"%s.", crdKustomizationsFilePath)
-			}
+	for _, updater := range updaters {
+		if _, err := scaffold.Execute(updater); err != nil {
+			log.Errorf("Unable to update the file %s: %v", updater.GetPath(), err)
 		}
 	}
 
 	return nil
 }
-
-const allowWebhookTrafficFragment = `
-- allow-webhook-traffic.yaml`
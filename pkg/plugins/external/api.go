@@ -19,6 +19,7 @@ package external
 import (
 	"github.com/spf13/pflag"
 
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
 	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
@@ -32,34 +33,51 @@ const (
 )
 
 type createAPISubcommand struct {
-	Path string
-	Args []string
+	Path     string
+	Args     []string
+	Session  bool
+	Limits   Limits
+	Manifest *external.SubcommandManifest
+	// PluginKey is the key of the plugin configuration in the project configuration
+	PluginKey string
+
+	config   config.Config
+	resource *resource.Resource
+}
+
+func (p *createAPISubcommand) InjectConfig(c config.Config) error {
+	p.config = c
+	return nil
 }
 
-func (p *createAPISubcommand) InjectResource(*resource.Resource) error {
-	// Do nothing since resource flags are passed to the external plugin directly.
+func (p *createAPISubcommand) InjectResource(res *resource.Resource) error {
+	p.resource = res
 	return nil
 }
 
 func (p *createAPISubcommand) UpdateMetadata(_ plugin.CLIMetadata, subcmdMeta *plugin.SubcommandMetadata) {
-	setExternalPluginMetadata("api", p.Path, subcmdMeta)
+	setExternalPluginMetadata("api", p.Path, p.Session, p.Limits, p.Manifest, subcmdMeta)
 }
 
 func (p *createAPISubcommand) BindFlags(fs *pflag.FlagSet) {
-	bindExternalPluginFlags(fs, "api", p.Path, p.Args)
+	bindExternalPluginFlags(fs, "api", p.Path, p.Args, p.Session, p.Limits, p.Manifest)
 }
 
 func (p *createAPISubcommand) Scaffold(fs machinery.Filesystem) error {
 	req := external.PluginRequest{
 		APIVersion: defaultAPIVersion,
-		Command:    "create api",
+		Command:    createAPICommand,
 		Args:       p.Args,
 	}
 
-	err := handlePluginResponse(fs, req, p.Path)
+	if err := setProjectInfo(&req, p.config, p.resource); err != nil {
+		return err
+	}
+
+	res, err := handlePluginResponse(fs, req, p.Path, p.Session, p.Limits)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return updateProject(p.config, p.PluginKey, p.resource, res)
 }
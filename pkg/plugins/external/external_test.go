@@ -28,7 +28,10 @@ import (
 	"github.com/spf13/afero"
 	"github.com/spf13/pflag"
 
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	cfgv3 "sigs.k8s.io/kubebuilder/v4/pkg/config/v3"
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugin/external"
 )
@@ -44,7 +47,7 @@ type mockInValidOutputGetter struct{}
 
 var _ ExecOutputGetter = &mockValidOutputGetter{}
 
-func (m *mockValidOutputGetter) GetExecOutput(_ []byte, _ string) ([]byte, error) {
+func (m *mockValidOutputGetter) GetExecOutput(_ []byte, _ string, _ Limits) ([]byte, error) {
 	return []byte(`{
 		"command": "init", 
 		"error": false, 
@@ -55,7 +58,7 @@ func (m *mockValidOutputGetter) GetExecOutput(_ []byte, _ string) ([]byte, error
 
 var _ ExecOutputGetter = &mockInValidOutputGetter{}
 
-func (m *mockInValidOutputGetter) GetExecOutput(_ []byte, _ string) ([]byte, error) {
+func (m *mockInValidOutputGetter) GetExecOutput(_ []byte, _ string, _ Limits) ([]byte, error) {
 	return nil, fmt.Errorf("error getting exec command output")
 }
 
@@ -75,9 +78,37 @@ func (m *mockInValidOsWdGetter) GetCurrentDir() (string, error) {
 	return "", fmt.Errorf("error getting current directory")
 }
 
+// mockFileOperationsOutputGetter returns its response with the deletions and renames of files
+type mockFileOperationsOutputGetter struct {
+	response external.PluginResponse
+}
+
+func (m *mockFileOperationsOutputGetter) GetExecOutput(_ []byte, _ string, _ Limits) ([]byte, error) {
+	return json.Marshal(m.response)
+}
+
+// mockProjectOutputGetter records the request and returns its response
+type mockProjectOutputGetter struct {
+	request  external.PluginRequest
+	response external.PluginResponse
+}
+
+func (m *mockProjectOutputGetter) GetExecOutput(req []byte, _ string, _ Limits) ([]byte, error) {
+	if err := json.Unmarshal(req, &m.request); err != nil {
+		return nil, err
+	}
+	return json.Marshal(m.response)
+}
+
+type mockRootOsWdGetter struct{}
+
+func (m *mockRootOsWdGetter) GetCurrentDir() (string, error) {
+	return ".", nil
+}
+
 type mockValidFlagOutputGetter struct{}
 
-func (m *mockValidFlagOutputGetter) GetExecOutput(_ []byte, _ string) ([]byte, error) {
+func (m *mockValidFlagOutputGetter) GetExecOutput(_ []byte, _ string, _ Limits) ([]byte, error) {
 	response := external.PluginResponse{
 		Command:  "flag",
 		Error:    false,
@@ -89,7 +120,7 @@ func (m *mockValidFlagOutputGetter) GetExecOutput(_ []byte, _ string) ([]byte, e
 
 type mockValidMEOutputGetter struct{}
 
-func (m *mockValidMEOutputGetter) GetExecOutput(_ []byte, _ string) ([]byte, error) {
+func (m *mockValidMEOutputGetter) GetExecOutput(_ []byte, _ string, _ Limits) ([]byte, error) {
 	response := external.PluginResponse{
 		Command:  "metadata",
 		Error:    false,
@@ -279,6 +310,189 @@ var _ = Describe("Run external plugin using Scaffold", func() {
 		})
 	})
 
+	Context("with deletions and renames of files", func() {
+		var (
+			getter *mockFileOperationsOutputGetter
+			fs     machinery.Filesystem
+		)
+
+		BeforeEach(func() {
+			getter = &mockFileOperationsOutputGetter{}
+			outputGetter = getter
+			currentDirGetter = &mockRootOsWdGetter{}
+			fs = machinery.Filesystem{
+				FS:     afero.NewMemMapFs(),
+				Report: &machinery.ScaffoldReport{},
+			}
+
+			for filename, data := range map[string]string{
+				"obsolete.go": "package obsolete\n",
+				"old.go":      "package old\n",
+				"kept.go":     "package kept\n",
+			} {
+				Expect(afero.WriteFile(fs.FS, filename, []byte(data), 0o644)).To(Succeed())
+			}
+		})
+
+		It("should delete and rename the files and report them", func() {
+			getter.response = external.PluginResponse{
+				Command:   "edit",
+				Universe:  map[string]string{"kept.go": "package kept\n", "new.go": "package new\n"},
+				Deletions: []string{"obsolete.go"},
+				Renames:   map[string]string{"old.go": filepath.Join("pkg", "renamed.go")},
+			}
+
+			Expect((&editSubcommand{Path: externalPlugin}).Scaffold(fs)).To(Succeed())
+
+			for _, filename := range []string{"obsolete.go", "old.go"} {
+				exists, err := afero.Exists(fs.FS, filename)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(exists).To(BeFalse())
+			}
+			b, err := afero.ReadFile(fs.FS, filepath.Join("pkg", "renamed.go"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(b)).To(Equal("package old\n"))
+
+			Expect(fs.Report.Files).To(Equal([]machinery.FileReport{
+				{Action: machinery.FileUnchanged, Path: "kept.go"},
+				{Action: machinery.FileCreated, Path: "new.go", Bytes: len("package new\n")},
+				{Action: machinery.FileRemoved, Path: "obsolete.go"},
+				{Action: machinery.FileMoved, Path: "old.go", MovedTo: filepath.Join("pkg", "renamed.go")},
+			}))
+		})
+
+		It("should swap renamed files", func() {
+			getter.response = external.PluginResponse{
+				Command: "edit",
+				Renames: map[string]string{"old.go": "kept.go", "kept.go": "old.go"},
+			}
+
+			Expect((&editSubcommand{Path: externalPlugin}).Scaffold(fs)).To(Succeed())
+
+			b, err := afero.ReadFile(fs.FS, "kept.go")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(b)).To(Equal("package old\n"))
+		})
+
+		DescribeTable("should fail without changing any file on invalid operations",
+			func(deletions []string, renames map[string]string, message string) {
+				getter.response = external.PluginResponse{
+					Command:   "edit",
+					Universe:  map[string]string{"new.go": "package new\n", "kept.go": "package kept\n"},
+					Deletions: deletions,
+					Renames:   renames,
+				}
+
+				err := (&editSubcommand{Path: externalPlugin}).Scaffold(fs)
+				Expect(err).To(MatchError(ContainSubstring(message)))
+
+				exists, err := afero.Exists(fs.FS, "obsolete.go")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(exists).To(BeTrue())
+				Expect(fs.Report.Files).To(BeEmpty())
+			},
+			Entry("deleting a missing file", []string{"obsolete.go", "missing.go"}, nil,
+				"not found in the request universe"),
+			Entry("deleting a written file", []string{"obsolete.go", "kept.go"}, nil,
+				"file found in the response universe"),
+			Entry("renaming a deleted file", []string{"obsolete.go"}, map[string]string{"obsolete.go": "new.go"},
+				"file also deleted"),
+			Entry("renaming outside the project", []string{"obsolete.go"}, map[string]string{"old.go": "../old.go"},
+				"not a clean path inside the project"),
+			Entry("renaming onto a kept file", []string{"obsolete.go"}, map[string]string{"old.go": "kept.go"},
+				"file already exists"),
+			Entry("renaming two files to the same path", []string{"obsolete.go"},
+				map[string]string{"old.go": "renamed.go", "kept.go": "renamed.go"}, "cannot rename both"),
+		)
+	})
+
+	Context("with the project configuration and resource", func() {
+		const pluginKey = "externalPlugin/v1"
+
+		var (
+			getter *mockProjectOutputGetter
+			fs     machinery.Filesystem
+			cfg    config.Config
+			res    resource.Resource
+			sc     *createAPISubcommand
+		)
+
+		BeforeEach(func() {
+			getter = &mockProjectOutputGetter{response: external.PluginResponse{Command: "api"}}
+			outputGetter = getter
+			currentDirGetter = &mockRootOsWdGetter{}
+			fs = machinery.Filesystem{FS: afero.NewMemMapFs()}
+
+			cfg = cfgv3.New()
+			Expect(cfg.SetDomain("test.io")).To(Succeed())
+			Expect(cfg.SetPluginChain([]string{"go.kubebuilder.io/v4", pluginKey})).To(Succeed())
+
+			res = resource.Resource{
+				GVK:    resource.GVK{Group: "crew", Domain: "test.io", Version: "v1", Kind: "Captain"},
+				Plural: "captains",
+				API:    &resource.API{CRDVersion: "v1", Namespaced: true},
+			}
+			Expect(cfg.AddResource(res)).To(Succeed())
+
+			sc = &createAPISubcommand{Path: externalPlugin, PluginKey: pluginKey}
+			Expect(sc.InjectConfig(cfg)).To(Succeed())
+			Expect(sc.InjectResource(&res)).To(Succeed())
+		})
+
+		It("should send the project configuration, the resource and the plugin chain", func() {
+			Expect(sc.Scaffold(fs)).To(Succeed())
+
+			Expect(getter.request.Config).NotTo(BeNil())
+			var projectCfg map[string]interface{}
+			Expect(json.Unmarshal(getter.request.Config, &projectCfg)).To(Succeed())
+			Expect(projectCfg).To(HaveKeyWithValue("domain", "test.io"))
+			Expect(getter.request.Resource).To(Equal(&res))
+			Expect(getter.request.PluginChain).To(Equal([]string{"go.kubebuilder.io/v4", pluginKey}))
+		})
+
+		It("should merge the plugin configuration and the resources into the project configuration", func() {
+			updated := res.Copy()
+			updated.Controller = true
+			other := resource.Resource{
+				GVK:    resource.GVK{Group: "crew", Domain: "test.io", Version: "v1", Kind: "FirstMate"},
+				Plural: "firstmates",
+			}
+			getter.response.PluginConfig = json.RawMessage(`{"captain":"jack"}`)
+			getter.response.Resources = []resource.Resource{updated, other}
+
+			Expect(sc.Scaffold(fs)).To(Succeed())
+
+			var pluginConfig map[string]string
+			Expect(cfg.DecodePluginConfig(pluginKey, &pluginConfig)).To(Succeed())
+			Expect(pluginConfig).To(Equal(map[string]string{"captain": "jack"}))
+
+			stored, err := cfg.GetResource(res.GVK)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored.Controller).To(BeTrue())
+			Expect(res.Controller).To(BeTrue())
+			Expect(cfg.HasResource(other.GVK)).To(BeTrue())
+		})
+
+		It("should fail if the plugin configuration is not an object", func() {
+			getter.response.PluginConfig = json.RawMessage(`["captain"]`)
+
+			Expect(sc.Scaffold(fs)).To(MatchError(ContainSubstring("invalid plugin configuration")))
+		})
+
+		It("should fail if a resource is invalid", func() {
+			getter.response.Resources = []resource.Resource{{GVK: res.GVK}}
+
+			Expect(sc.Scaffold(fs)).To(MatchError(ContainSubstring("invalid resource")))
+		})
+
+		It("should fail to update the project configuration if it was not injected", func() {
+			sc = &createAPISubcommand{Path: externalPlugin, PluginKey: pluginKey}
+			getter.response.PluginConfig = json.RawMessage(`{"captain":"jack"}`)
+
+			Expect(sc.Scaffold(fs)).To(MatchError(ContainSubstring("was not injected")))
+		})
+	})
+
 	Context("with successfully getting flags from external plugin", func() {
 		var (
 			pluginFileName string
@@ -18,7 +18,9 @@ package external
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	iofs "io/fs"
@@ -30,7 +32,11 @@ import (
 
 	"github.com/spf13/afero"
 	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugin/external"
 )
@@ -46,21 +52,40 @@ consult the external plugin's documentation.
 
 // ExecOutputGetter is an interface that implements the exec output method.
 type ExecOutputGetter interface {
-	GetExecOutput(req []byte, path string) ([]byte, error)
+	GetExecOutput(req []byte, path string, limits Limits) ([]byte, error)
 }
 
 type execOutputGetter struct{}
 
-func (e *execOutputGetter) GetExecOutput(request []byte, path string) ([]byte, error) {
-	cmd := exec.Command(path) //nolint:gosec
+func (e *execOutputGetter) GetExecOutput(request []byte, path string, limits Limits) ([]byte, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	timeout := limits.timeout()
+	if timeout > 0 {
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeout(ctx, timeout)
+		defer cancelTimeout()
+	}
+
+	out := &limitedBuffer{limit: limits.maxResponseSize(), stop: cancel}
+	cmd := exec.CommandContext(ctx, path) //nolint:gosec
+	cmd.Env = limits.env()
 	cmd.Stdin = bytes.NewBuffer(request)
+	cmd.Stdout = out
 	cmd.Stderr = os.Stderr
-	out, err := cmd.Output()
-	if err != nil {
-		return nil, err
+	cmd.WaitDelay = waitDelay
+	if err := cmd.Run(); err != nil {
+		switch {
+		case out.exceeded:
+			return nil, responseTooLargeError(out.limit)
+		case errors.Is(ctx.Err(), context.DeadlineExceeded):
+			return nil, fmt.Errorf("external plugin timed out after %s", timeout)
+		default:
+			return nil, err
+		}
 	}
 
-	return out, nil
+	return out.Bytes(), nil
 }
 
 var currentDirGetter OsWdGetter = &osWdGetter{}
@@ -81,13 +106,42 @@ func (o *osWdGetter) GetCurrentDir() (string, error) {
 	return currentDir, nil
 }
 
-func makePluginRequest(req external.PluginRequest, path string) (*external.PluginResponse, error) {
+// makePluginRequest sends a request to the plugin at path within limits, running it for this request only
+// or, if session is set, through the session of the plugin.
+func makePluginRequest(req external.PluginRequest, path string, session bool,
+	limits Limits,
+) (*external.PluginResponse, error) {
+	res, err := getPluginResponse(req, path, session, limits)
+	if err != nil {
+		return nil, err
+	}
+
+	// Error if the plugin failed.
+	if res.Error {
+		return nil, fmt.Errorf("%s", strings.Join(res.ErrorMsgs, "\n"))
+	}
+
+	return res, nil
+}
+
+// getPluginResponse returns the response of the plugin at path to a request, without checking if it failed
+func getPluginResponse(req external.PluginRequest, path string, session bool,
+	limits Limits,
+) (*external.PluginResponse, error) {
+	if session {
+		s, err := getSession(path, limits)
+		if err != nil {
+			return nil, err
+		}
+		return s.execute(req)
+	}
+
 	reqBytes, err := json.Marshal(req)
 	if err != nil {
 		return nil, err
 	}
 
-	out, err := outputGetter.GetExecOutput(reqBytes, path)
+	out, err := outputGetter.GetExecOutput(reqBytes, path, limits)
 	if err != nil {
 		return nil, err
 	}
@@ -96,12 +150,6 @@ func makePluginRequest(req external.PluginRequest, path string) (*external.Plugi
 	if err := json.Unmarshal(out, &res); err != nil {
 		return nil, err
 	}
-
-	// Error if the plugin failed.
-	if res.Error {
-		return nil, fmt.Errorf("%s", strings.Join(res.ErrorMsgs, "\n"))
-	}
-
 	return &res, nil
 }
 
@@ -121,6 +169,13 @@ func getUniverseMap(fs machinery.Filesystem) (map[string]string, error) {
 			return nil
 		}
 
+		// symbolic links are not sent, as the plugin cannot change them
+		if symlink, err := machinery.IsSymlink(fs.FS, path); err != nil {
+			return err
+		} else if symlink {
+			return nil
+		}
+
 		file, err := fs.FS.Open(path)
 		if err != nil {
 			return err
@@ -149,22 +204,68 @@ func getUniverseMap(fs machinery.Filesystem) (map[string]string, error) {
 	return universe, nil
 }
 
-func handlePluginResponse(fs machinery.Filesystem, req external.PluginRequest, path string) error {
+// handlePluginResponse sends the request with the files of the project to the external plugin, applies the
+// changes to the files of its response and returns it
+func handlePluginResponse(fs machinery.Filesystem, req external.PluginRequest, path string,
+	session bool, limits Limits,
+) (*external.PluginResponse, error) {
 	var err error
 
 	req.Universe, err = getUniverseMap(fs)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	res, err := makePluginRequest(req, path)
+	res, err := makePluginRequest(req, path, session, limits)
 	if err != nil {
-		return fmt.Errorf("error making request to external plugin: %w", err)
+		return nil, fmt.Errorf("error making request to external plugin: %w", err)
 	}
 
 	currentDir, err := currentDirGetter.GetCurrentDir()
 	if err != nil {
-		return fmt.Errorf("error getting current directory: %v", err)
+		return nil, fmt.Errorf("error getting current directory: %v", err)
+	}
+
+	if err := validateFileOperations(req.Universe, res); err != nil {
+		return nil, fmt.Errorf("invalid response from external plugin: %w", err)
+	}
+	for filename := range res.Universe {
+		if err := validatePath(fs.FS, currentDir, filename); err != nil {
+			return nil, fmt.Errorf("invalid response from external plugin: cannot write file: %w", err)
+		}
+	}
+	for _, filename := range res.Deletions {
+		if err := validatePath(fs.FS, currentDir, filename); err != nil {
+			return nil, fmt.Errorf("invalid response from external plugin: cannot delete file: %w", err)
+		}
+	}
+	for from, to := range res.Renames {
+		if err := validatePath(fs.FS, currentDir, from); err != nil {
+			return nil, fmt.Errorf("invalid response from external plugin: cannot rename %q: %w", from, err)
+		}
+		if err := validatePath(fs.FS, currentDir, to); err != nil {
+			return nil, fmt.Errorf("invalid response from external plugin: cannot rename %q: %w", from, err)
+		}
+	}
+
+	reports, err := applyFileOperations(fs, currentDir, req.Universe, res)
+	if err != nil {
+		return nil, err
+	}
+
+	// previous are the contents of the files before the universe of the response is written
+	previous := make(map[string]string, len(req.Universe))
+	for filename, data := range req.Universe {
+		previous[filename] = data
+	}
+	for _, filename := range res.Deletions {
+		delete(previous, filename)
+	}
+	for from := range res.Renames {
+		delete(previous, from)
+	}
+	for from, to := range res.Renames {
+		previous[to] = req.Universe[from]
 	}
 
 	for filename, data := range res.Universe {
@@ -172,13 +273,13 @@ func handlePluginResponse(fs machinery.Filesystem, req external.PluginRequest, p
 		dir := filepath.Dir(path)
 
 		// create the directory if it does not exist
-		if err := os.MkdirAll(dir, 0o750); err != nil {
-			return fmt.Errorf("error creating the directory: %v", err)
+		if err := fs.FS.MkdirAll(dir, 0o750); err != nil {
+			return nil, fmt.Errorf("error creating the directory: %v", err)
 		}
 
 		f, err := fs.FS.Create(path)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		defer func() {
@@ -188,19 +289,169 @@ func handlePluginResponse(fs machinery.Filesystem, req external.PluginRequest, p
 		}()
 
 		if _, err := f.Write([]byte(data)); err != nil {
-			return err
+			return nil, err
+		}
+
+		report := machinery.FileReport{Action: machinery.FileCreated, Path: filename, Bytes: len(data)}
+		if previousData, found := previous[filename]; found && previousData == data {
+			report = machinery.FileReport{Action: machinery.FileUnchanged, Path: filename}
+		} else if found {
+			report.Action = machinery.FileOverwritten
+		}
+		reports = append(reports, report)
+	}
+
+	if fs.Report != nil {
+		fs.Report.Add(machinery.ScaffoldReport{Files: reports})
+	}
+
+	return res, nil
+}
+
+// setProjectInfo sets the project configuration, the resource model and the plugin chain of a request
+func setProjectInfo(req *external.PluginRequest, cfg config.Config, res *resource.Resource) error {
+	if cfg != nil {
+		b, err := cfg.MarshalYAML()
+		if err != nil {
+			return fmt.Errorf("error marshaling the project configuration: %w", err)
+		}
+		if req.Config, err = yaml.YAMLToJSON(b); err != nil {
+			return fmt.Errorf("error converting the project configuration to JSON: %w", err)
+		}
+		req.PluginChain = cfg.GetPluginChain()
+	}
+
+	if res != nil {
+		r := res.Copy()
+		req.Resource = &r
+	}
+
+	return nil
+}
+
+// updateProject merges the plugin configuration and the resources returned by the external plugin into the
+// project configuration. The resource model is updated too if it was returned
+func updateProject(cfg config.Config, pluginKey string, res *resource.Resource,
+	pluginRes *external.PluginResponse,
+) error {
+	if len(pluginRes.PluginConfig) == 0 && len(pluginRes.Resources) == 0 {
+		return nil
+	}
+	if cfg == nil {
+		return fmt.Errorf("external plugin returned updates of the project configuration, which was not injected")
+	}
+
+	if len(pluginRes.PluginConfig) != 0 && string(pluginRes.PluginConfig) != "null" {
+		var pluginConfig map[string]interface{}
+		if err := json.Unmarshal(pluginRes.PluginConfig, &pluginConfig); err != nil {
+			return fmt.Errorf("invalid plugin configuration returned by external plugin: %w", err)
+		}
+		if err := cfg.EncodePluginConfig(pluginKey, pluginConfig); err != nil {
+			return fmt.Errorf("error updating the plugin configuration: %w", err)
+		}
+	}
+
+	for _, r := range pluginRes.Resources {
+		if err := r.Validate(); err != nil {
+			return fmt.Errorf("invalid resource (GVK %+v) returned by external plugin: %w", r.GVK, err)
+		}
+		if err := cfg.UpdateResource(r); err != nil {
+			return fmt.Errorf("error updating resource (GVK %+v): %w", r.GVK, err)
+		}
+		if res != nil && res.GVK.IsEqualTo(r.GVK) {
+			if err := res.Update(r); err != nil {
+				return fmt.Errorf("error updating resource (GVK %+v): %w", r.GVK, err)
+			}
 		}
 	}
 
 	return nil
 }
 
+// validateFileOperations checks that the files deleted and renamed by the plugin are files of the request
+// universe, and that the renamed files are not moved outside the project nor onto files that are kept
+func validateFileOperations(universe map[string]string, res *external.PluginResponse) error {
+	removed := make(map[string]bool, len(res.Deletions)+len(res.Renames))
+	for _, filename := range res.Deletions {
+		if _, found := universe[filename]; !found {
+			return fmt.Errorf("cannot delete %q: file not found in the request universe", filename)
+		}
+		if _, found := res.Universe[filename]; found {
+			return fmt.Errorf("cannot delete %q: file found in the response universe", filename)
+		}
+		if removed[filename] {
+			return fmt.Errorf("cannot delete %q: file deleted more than once", filename)
+		}
+		removed[filename] = true
+	}
+	for from := range res.Renames {
+		if _, found := universe[from]; !found {
+			return fmt.Errorf("cannot rename %q: file not found in the request universe", from)
+		}
+		if removed[from] {
+			return fmt.Errorf("cannot rename %q: file also deleted", from)
+		}
+		removed[from] = true
+	}
+
+	targets := make(map[string]string, len(res.Renames))
+	for from, to := range res.Renames {
+		if !filepath.IsLocal(to) || filepath.Clean(to) != to {
+			return fmt.Errorf("cannot rename %q to %q: path is not a clean path inside the project", from, to)
+		}
+		if other, found := targets[to]; found {
+			return fmt.Errorf("cannot rename both %q and %q to %q", other, from, to)
+		}
+		if _, found := universe[to]; found && !removed[to] {
+			return fmt.Errorf("cannot rename %q to %q: file already exists", from, to)
+		}
+		targets[to] = from
+	}
+
+	return nil
+}
+
+// applyFileOperations deletes and renames the files of the request universe as requested by the plugin,
+// and returns their reports. Every file is removed before the renamed ones are written, so that renames
+// can be chained or swapped
+func applyFileOperations(fs machinery.Filesystem, currentDir string, universe map[string]string,
+	res *external.PluginResponse,
+) ([]machinery.FileReport, error) {
+	reports := make([]machinery.FileReport, 0, len(res.Deletions)+len(res.Renames)+len(res.Universe))
+
+	for _, filename := range res.Deletions {
+		if err := fs.FS.Remove(filepath.Join(currentDir, filename)); err != nil {
+			return nil, fmt.Errorf("error deleting %q: %w", filename, err)
+		}
+		reports = append(reports, machinery.FileReport{Action: machinery.FileRemoved, Path: filename})
+	}
+	for from, to := range res.Renames {
+		if err := fs.FS.Remove(filepath.Join(currentDir, from)); err != nil {
+			return nil, fmt.Errorf("error renaming %q: %w", from, err)
+		}
+		reports = append(reports, machinery.FileReport{Action: machinery.FileMoved, Path: from, MovedTo: to})
+	}
+	for from, to := range res.Renames {
+		path := filepath.Join(currentDir, to)
+		if err := fs.FS.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			return nil, fmt.Errorf("error creating the directory: %v", err)
+		}
+		if err := afero.WriteFile(fs.FS, path, []byte(universe[from]), 0o644); err != nil {
+			return nil, fmt.Errorf("error renaming %q to %q: %w", from, to, err)
+		}
+	}
+
+	return reports, nil
+}
+
 // getExternalPluginFlags is a helper function that is used to get a list of flags from an external plugin.
 // It will return []Flag if successful or an error if there is an issue attempting to get the list of flags.
-func getExternalPluginFlags(req external.PluginRequest, path string) ([]external.Flag, error) {
+func getExternalPluginFlags(req external.PluginRequest, path string, session bool,
+	limits Limits,
+) ([]external.Flag, error) {
 	req.Universe = map[string]string{}
 
-	res, err := makePluginRequest(req, path)
+	res, err := makePluginRequest(req, path, session, limits)
 	if err != nil {
 		return nil, fmt.Errorf("error making request to external plugin: %w", err)
 	}
@@ -323,7 +574,19 @@ var (
 	}
 )
 
-func bindExternalPluginFlags(fs *pflag.FlagSet, subcommand string, path string, args []string) {
+// bindExternalPluginFlags binds the flags declared in the manifest of the subcommand if not nil,
+// or else the ones the external plugin returns.
+func bindExternalPluginFlags(fs *pflag.FlagSet, subcommand string, path string, args []string, session bool,
+	limits Limits, manifest *external.SubcommandManifest,
+) {
+	if manifest != nil {
+		bindSpecificFlags(fs, filterFlags(manifest.Flags, []externalFlagFilterFunc{
+			gvkFlagFilter,
+			helpFlagFilter,
+		}))
+		return
+	}
+
 	req := external.PluginRequest{
 		APIVersion: defaultAPIVersion,
 		Command:    "flags",
@@ -333,7 +596,7 @@ func bindExternalPluginFlags(fs *pflag.FlagSet, subcommand string, path string,
 	// Get a list of flags for the init subcommand of the external plugin
 	// If it returns an error, parse all flags passed by the user and let
 	// the external plugin return an unknown flag error.
-	flags, err := getExternalPluginFlags(req, path)
+	flags, err := getExternalPluginFlags(req, path, session, limits)
 
 	// Filter Flags based on a set of filters that we do not want.
 	// can be used to filter out non-overridable flags or other
@@ -353,13 +616,21 @@ func bindExternalPluginFlags(fs *pflag.FlagSet, subcommand string, path string,
 
 // setExternalPluginMetadata is a helper function that sets the subcommand
 // metadata that is used when the help text is shown for a subcommand.
-// It will attempt to get the Metadata from the external plugin. If the
-// external plugin returns no Metadata or an error, a default will be used.
-func setExternalPluginMetadata(subcommand, path string, subcmdMeta *plugin.SubcommandMetadata) {
+// It will use the Metadata declared in the manifest of the subcommand if not nil,
+// or else attempt to get it from the external plugin. If there is no Metadata
+// or the external plugin returns an error, a default will be used.
+func setExternalPluginMetadata(subcommand, path string, session bool, limits Limits,
+	manifest *external.SubcommandManifest, subcmdMeta *plugin.SubcommandMetadata,
+) {
 	fileName := filepath.Base(path)
 	subcmdMeta.Description = fmt.Sprintf(defaultMetadataTemplate, fileName[:len(fileName)-len(filepath.Ext(fileName))])
 
-	res, _ := getExternalPluginMetadata(subcommand, path)
+	var res *plugin.SubcommandMetadata
+	if manifest != nil {
+		res = &plugin.SubcommandMetadata{Description: manifest.Description, Examples: manifest.Examples}
+	} else {
+		res, _ = getExternalPluginMetadata(subcommand, path, session, limits)
+	}
 
 	if res != nil {
 		if res.Description != "" {
@@ -375,7 +646,9 @@ func setExternalPluginMetadata(subcommand, path string, subcmdMeta *plugin.Subco
 // fetchExternalPluginMetadata performs the actual request to the
 // external plugin to get the metadata. It returns the metadata
 // or an error if an error occurs during the fetch process.
-func getExternalPluginMetadata(subcommand, path string) (*plugin.SubcommandMetadata, error) {
+func getExternalPluginMetadata(subcommand, path string, session bool,
+	limits Limits,
+) (*plugin.SubcommandMetadata, error) {
 	req := external.PluginRequest{
 		APIVersion: defaultAPIVersion,
 		Command:    "metadata",
@@ -383,7 +656,7 @@ func getExternalPluginMetadata(subcommand, path string) (*plugin.SubcommandMetad
 		Universe:   map[string]string{},
 	}
 
-	res, err := makePluginRequest(req, path)
+	res, err := makePluginRequest(req, path, session, limits)
 	if err != nil {
 		return nil, fmt.Errorf("error making request to external plugin: %w", err)
 	}
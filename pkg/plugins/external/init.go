@@ -19,6 +19,7 @@ package external
 import (
 	"github.com/spf13/pflag"
 
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugin/external"
@@ -27,29 +28,45 @@ import (
 var _ plugin.InitSubcommand = &initSubcommand{}
 
 type initSubcommand struct {
-	Path string
-	Args []string
+	Path     string
+	Args     []string
+	Session  bool
+	Limits   Limits
+	Manifest *external.SubcommandManifest
+	// PluginKey is the key of the plugin configuration in the project configuration
+	PluginKey string
+
+	config config.Config
+}
+
+func (p *initSubcommand) InjectConfig(c config.Config) error {
+	p.config = c
+	return nil
 }
 
 func (p *initSubcommand) UpdateMetadata(_ plugin.CLIMetadata, subcmdMeta *plugin.SubcommandMetadata) {
-	setExternalPluginMetadata("init", p.Path, subcmdMeta)
+	setExternalPluginMetadata("init", p.Path, p.Session, p.Limits, p.Manifest, subcmdMeta)
 }
 
 func (p *initSubcommand) BindFlags(fs *pflag.FlagSet) {
-	bindExternalPluginFlags(fs, "init", p.Path, p.Args)
+	bindExternalPluginFlags(fs, "init", p.Path, p.Args, p.Session, p.Limits, p.Manifest)
 }
 
 func (p *initSubcommand) Scaffold(fs machinery.Filesystem) error {
 	req := external.PluginRequest{
 		APIVersion: defaultAPIVersion,
-		Command:    "init",
+		Command:    initCommand,
 		Args:       p.Args,
 	}
 
-	err := handlePluginResponse(fs, req, p.Path)
+	if err := setProjectInfo(&req, p.config, nil); err != nil {
+		return err
+	}
+
+	res, err := handlePluginResponse(fs, req, p.Path, p.Session, p.Limits)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return updateProject(p.config, p.PluginKey, nil, res)
 }
@@ -0,0 +1,171 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+const (
+	// DefaultTimeout is the maximum duration of a request to an external plugin if its limits don't set one.
+	DefaultTimeout = 10 * time.Minute
+
+	// DefaultMaxResponseSize is the maximum size in bytes of a response of an external plugin if its limits
+	// don't set one.
+	DefaultMaxResponseSize = 64 << 20
+
+	// waitDelay is how long the output of a killed plugin is waited for, in case it started processes that
+	// keep it open
+	waitDelay = time.Second
+
+	// allowedEnvPrefix is the prefix of the environment variables of Kubebuilder, which are always passed
+	allowedEnvPrefix = "KUBEBUILDER_"
+)
+
+// defaultAllowedEnv are the environment variables always passed to external plugins, in addition to the ones
+// prefixed with allowedEnvPrefix. The rest of the environment of Kubebuilder, which may hold credentials, is not.
+var defaultAllowedEnv = []string{
+	// System
+	"PATH", "HOME", "USER", "LOGNAME", "SHELL", "TERM", "LANG", "LC_ALL", "TMPDIR", "TEMP", "TMP",
+	"XDG_CONFIG_HOME", "XDG_CACHE_HOME", "XDG_DATA_HOME",
+	"SYSTEMROOT", "USERPROFILE", "APPDATA", "LOCALAPPDATA", "PATHEXT", "COMSPEC",
+	// Network
+	"HTTP_PROXY", "HTTPS_PROXY", "NO_PROXY", "http_proxy", "https_proxy", "no_proxy",
+	"SSL_CERT_FILE", "SSL_CERT_DIR",
+	// Go toolchain
+	"GOPATH", "GOROOT", "GOBIN", "GOCACHE", "GOMODCACHE", "GOENV", "GOFLAGS", "GOTOOLCHAIN",
+	"GOPROXY", "GOPRIVATE", "GONOPROXY", "GONOSUMDB", "GOSUMDB", "GOINSECURE", "CGO_ENABLED",
+}
+
+// Limits restrict what an external plugin can use. The zero value uses the defaults.
+type Limits struct {
+	// Timeout is the maximum duration of a request to the plugin, after which the plugin is killed.
+	// Zero uses DefaultTimeout, and a negative duration disables the timeout.
+	Timeout time.Duration
+
+	// MaxResponseSize is the maximum size in bytes of a response of the plugin.
+	// Zero uses DefaultMaxResponseSize.
+	MaxResponseSize int
+
+	// AllowedEnv are the environment variables passed to the plugin in addition to the default ones.
+	AllowedEnv []string
+}
+
+// timeout returns the maximum duration of a request, zero if there is none
+func (l Limits) timeout() time.Duration {
+	switch {
+	case l.Timeout == 0:
+		return DefaultTimeout
+	case l.Timeout < 0:
+		return 0
+	default:
+		return l.Timeout
+	}
+}
+
+// maxResponseSize returns the maximum size in bytes of a response
+func (l Limits) maxResponseSize() int {
+	if l.MaxResponseSize == 0 {
+		return DefaultMaxResponseSize
+	}
+	return l.MaxResponseSize
+}
+
+// env returns the environment of Kubebuilder restricted to the allowed variables
+func (l Limits) env() []string {
+	var env []string
+	for _, kv := range os.Environ() {
+		name, _, _ := strings.Cut(kv, "=")
+		if strings.HasPrefix(name, allowedEnvPrefix) || slices.Contains(defaultAllowedEnv, name) ||
+			slices.Contains(l.AllowedEnv, name) {
+			env = append(env, kv)
+		}
+	}
+	return env
+}
+
+// limitedBuffer is the buffer of a response of an external plugin, which stops the plugin once it wrote
+// more than limit bytes
+type limitedBuffer struct {
+	buf   bytes.Buffer
+	limit int
+
+	// stop kills the plugin
+	stop func()
+	// exceeded is set if the plugin wrote too much
+	exceeded bool
+}
+
+// Write implements io.Writer
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.buf.Len()+len(p) > b.limit {
+		b.exceeded = true
+		b.stop()
+		return 0, responseTooLargeError(b.limit)
+	}
+	return b.buf.Write(p)
+}
+
+// Bytes returns the response
+func (b *limitedBuffer) Bytes() []byte {
+	return b.buf.Bytes()
+}
+
+// responseTooLargeError returns the error of responses larger than limit bytes
+func responseTooLargeError(limit int) error {
+	return fmt.Errorf("response of external plugin exceeds the maximum size of %d bytes", limit)
+}
+
+// validatePath checks that changing the file at filename, relative to the project directory dir, cannot
+// change files outside the project: the path joined to dir has to stay inside dir once cleaned, and
+// neither the file nor its parent directories up to dir can be symbolic links
+func validatePath(fs afero.Fs, dir, filename string) error {
+	if !filepath.IsLocal(filename) {
+		return fmt.Errorf("path %q is not inside the project", filename)
+	}
+
+	root := filepath.Clean(dir)
+	path := filepath.Join(root, filename)
+	rel, err := filepath.Rel(root, path)
+	if err != nil || !filepath.IsLocal(rel) {
+		return fmt.Errorf("path %q is not inside the project", filename)
+	}
+
+	// Check every directory between the project directory and the file, which is what resolving the symbolic
+	// links of the path would follow, through fs so that the files staged by the scaffold are checked as well
+	for current := path; current != root; current = filepath.Dir(current) {
+		symlink, err := machinery.IsSymlink(fs, current)
+		if err != nil {
+			return fmt.Errorf("error checking path %q: %w", filename, err)
+		}
+		if symlink {
+			rel, _ := filepath.Rel(root, current)
+			return fmt.Errorf("path %q goes through the symbolic link %q", filename, rel)
+		}
+	}
+	return nil
+}
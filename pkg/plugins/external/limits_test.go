@@ -0,0 +1,233 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin/external"
+)
+
+var _ = Describe("External plugin limits", func() {
+	It("should only pass the allowed environment variables", func() {
+		GinkgoT().Setenv("SECRET_TOKEN", "secret")
+		GinkgoT().Setenv("KUBEBUILDER_CAPTAIN", "jack")
+		GinkgoT().Setenv("GOPROXY", "direct")
+		GinkgoT().Setenv("REGISTRY", "quay.io")
+
+		env := Limits{AllowedEnv: []string{"REGISTRY"}}.env()
+		Expect(env).To(ContainElements("KUBEBUILDER_CAPTAIN=jack", "GOPROXY=direct", "REGISTRY=quay.io"))
+		Expect(env).NotTo(ContainElement(HavePrefix("SECRET_TOKEN=")))
+		Expect(Limits{}.env()).NotTo(ContainElement(HavePrefix("REGISTRY=")))
+	})
+
+	It("should use the default limits", func() {
+		Expect(Limits{}.timeout()).To(Equal(DefaultTimeout))
+		Expect(Limits{Timeout: -1}.timeout()).To(BeZero())
+		Expect(Limits{}.maxResponseSize()).To(Equal(DefaultMaxResponseSize))
+	})
+
+	Context("running the plugin", func() {
+		var dir string
+
+		BeforeEach(func() {
+			if runtime.GOOS == "windows" {
+				Skip("shell scripts are not supported")
+			}
+			dir = GinkgoT().TempDir()
+		})
+
+		// writeScript writes an executable shell script that reads the request and runs body
+		writeScript := func(body string) string {
+			path := filepath.Join(dir, "externalPlugin.sh")
+			Expect(os.WriteFile(path, []byte("#!/bin/sh\ncat > /dev/null\n"+body+"\n"), 0o700)).To(Succeed())
+			return path
+		}
+
+		It("should not pass the environment variables that are not allowed", func() {
+			GinkgoT().Setenv("SECRET_TOKEN", "secret")
+			GinkgoT().Setenv("KUBEBUILDER_CAPTAIN", "jack")
+			path := writeScript(`printf '%s%s' "$SECRET_TOKEN" "$KUBEBUILDER_CAPTAIN"`)
+
+			out, err := (&execOutputGetter{}).GetExecOutput([]byte("{}"), path, Limits{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(out)).To(Equal("jack"))
+		})
+
+		It("should kill the plugin once it timed out", func() {
+			path := writeScript("exec sleep 10")
+
+			_, err := (&execOutputGetter{}).GetExecOutput([]byte("{}"), path, Limits{Timeout: 100 * time.Millisecond})
+			Expect(err).To(MatchError(ContainSubstring("timed out after 100ms")))
+		})
+
+		It("should fail if the response is too large", func() {
+			path := writeScript("head -c 4096 /dev/zero")
+
+			_, err := (&execOutputGetter{}).GetExecOutput([]byte("{}"), path, Limits{MaxResponseSize: 1024})
+			Expect(err).To(MatchError(ContainSubstring("exceeds the maximum size of 1024 bytes")))
+		})
+	})
+
+	Context("writing the files of the response", func() {
+		var (
+			getter *mockFileOperationsOutputGetter
+			fs     machinery.Filesystem
+		)
+
+		BeforeEach(func() {
+			getter = &mockFileOperationsOutputGetter{}
+			outputGetter = getter
+			currentDirGetter = &mockRootOsWdGetter{}
+			fs = machinery.Filesystem{FS: afero.NewMemMapFs()}
+		})
+
+		DescribeTable("should fail without writing any file if a path is outside the project",
+			func(filename string) {
+				getter.response = external.PluginResponse{
+					Command:  "edit",
+					Universe: map[string]string{"kept.go": "package kept\n", filename: "export SECRET=secret\n"},
+				}
+
+				err := (&editSubcommand{Path: externalPlugin}).Scaffold(fs)
+				Expect(err).To(MatchError(ContainSubstring("is not inside the project")))
+				Expect(afero.Exists(fs.FS, "kept.go")).To(BeFalse())
+			},
+			Entry("parent directory", filepath.Join("..", "..", ".bashrc")),
+			Entry("absolute path", filepath.Join(string(filepath.Separator), "etc", "profile")),
+			Entry("empty path", ""),
+		)
+
+		It("should fail if a path goes through a symbolic link", func() {
+			if runtime.GOOS == "windows" {
+				Skip("symbolic links require privileges")
+			}
+			dir := GinkgoT().TempDir()
+			Expect(os.Mkdir(filepath.Join(dir, "outside"), 0o700)).To(Succeed())
+			Expect(os.Mkdir(filepath.Join(dir, "project"), 0o700)).To(Succeed())
+			Expect(os.Symlink(filepath.Join(dir, "outside"), filepath.Join(dir, "project", "config"))).To(Succeed())
+			fs = machinery.Filesystem{FS: afero.NewBasePathFs(afero.NewOsFs(), filepath.Join(dir, "project"))}
+
+			getter.response = external.PluginResponse{
+				Command:  "edit",
+				Universe: map[string]string{filepath.Join("config", "secret.yaml"): "secret: true\n"},
+			}
+
+			err := (&editSubcommand{Path: externalPlugin}).Scaffold(fs)
+			Expect(err).To(MatchError(ContainSubstring(`goes through the symbolic link "config"`)))
+			Expect(filepath.Join(dir, "outside", "secret.yaml")).NotTo(BeAnExistingFile())
+		})
+	})
+
+	Context("deleting and renaming the files of the response", func() {
+		var (
+			getter  *mockSymlinkOutputGetter
+			fs      machinery.Filesystem
+			dir     string
+			project string
+			outside string
+		)
+
+		BeforeEach(func() {
+			if runtime.GOOS == "windows" {
+				Skip("symbolic links require privileges")
+			}
+			currentDirGetter = &mockRootOsWdGetter{}
+
+			dir = GinkgoT().TempDir()
+			project = filepath.Join(dir, "project")
+			outside = filepath.Join(dir, "outside")
+			for _, path := range []string{filepath.Join(project, "pkg"), outside} {
+				Expect(os.MkdirAll(path, 0o700)).To(Succeed())
+				Expect(os.WriteFile(filepath.Join(path, "old.go"), []byte("package old\n"), 0o600)).To(Succeed())
+			}
+			Expect(os.WriteFile(filepath.Join(project, "kept.go"), []byte("package kept\n"), 0o600)).To(Succeed())
+			Expect(os.Symlink(outside, filepath.Join(project, "config"))).To(Succeed())
+			fs = machinery.Filesystem{FS: afero.NewBasePathFs(afero.NewOsFs(), project)}
+
+			// the plugin replaces the pkg directory of the request universe by a symbolic link before responding
+			getter = &mockSymlinkOutputGetter{
+				replace: func() {
+					Expect(os.RemoveAll(filepath.Join(project, "pkg"))).To(Succeed())
+					Expect(os.Symlink(outside, filepath.Join(project, "pkg"))).To(Succeed())
+				},
+			}
+			outputGetter = getter
+		})
+
+		DescribeTable("should fail without changing any file outside the project",
+			func(deletions []string, renames map[string]string) {
+				getter.response = external.PluginResponse{
+					Command:   "edit",
+					Universe:  map[string]string{"kept.go": "package kept\n"},
+					Deletions: deletions,
+					Renames:   renames,
+				}
+
+				Expect((&editSubcommand{Path: externalPlugin}).Scaffold(fs)).NotTo(Succeed())
+				Expect(filepath.Join(outside, "old.go")).To(BeAnExistingFile())
+				Expect(filepath.Join(outside, "kept.go")).NotTo(BeAnExistingFile())
+				Expect(filepath.Join(dir, "kept.go")).NotTo(BeAnExistingFile())
+			},
+			Entry("deleting a parent directory file", []string{filepath.Join("..", "outside", "old.go")}, nil),
+			Entry("deleting through a symbolic link", []string{filepath.Join("pkg", "old.go")}, nil),
+			Entry("renaming a parent directory file",
+				nil, map[string]string{filepath.Join("..", "outside", "old.go"): "old.go"}),
+			Entry("renaming through a symbolic link", nil, map[string]string{filepath.Join("pkg", "old.go"): "old.go"}),
+			Entry("renaming to a parent directory", nil, map[string]string{"kept.go": filepath.Join("..", "kept.go")}),
+			Entry("renaming to a symbolic link", nil, map[string]string{"kept.go": filepath.Join("config", "kept.go")}),
+		)
+
+		DescribeTable("should check the path joined to the project directory",
+			func(filename, message string) {
+				err := validatePath(afero.NewOsFs(), project+string(filepath.Separator), filename)
+				if message == "" {
+					Expect(err).NotTo(HaveOccurred())
+				} else {
+					Expect(err).To(MatchError(ContainSubstring(message)))
+				}
+			},
+			Entry("file inside the project", "kept.go", ""),
+			Entry("new file inside the project", filepath.Join("pkg", "new.go"), ""),
+			Entry("parent directory", filepath.Join("pkg", "..", "..", "outside", "old.go"), "is not inside the project"),
+			Entry("symbolic link directory", filepath.Join("config", "old.go"), `goes through the symbolic link "config"`),
+			Entry("symbolic link file", "config", `goes through the symbolic link "config"`),
+			Entry("symbolic link after cleaning",
+				filepath.Join("pkg", "..", "config", "old.go"), `goes through the symbolic link "config"`),
+		)
+	})
+})
+
+// mockSymlinkOutputGetter calls replace, as a plugin changing the project would, and returns its response
+type mockSymlinkOutputGetter struct {
+	response external.PluginResponse
+	replace  func()
+}
+
+func (m *mockSymlinkOutputGetter) GetExecOutput(_ []byte, _ string, _ Limits) ([]byte, error) {
+	m.replace()
+	return json.Marshal(m.response)
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/spf13/afero"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin/external"
+)
+
+const (
+	initCommand          = "init"
+	createAPICommand     = "create api"
+	createWebhookCommand = "create webhook"
+	editCommand          = "edit"
+)
+
+// manifestCommands are the commands of the subcommands that can be declared in a manifest
+var manifestCommands = []string{initCommand, createAPICommand, createWebhookCommand, editCommand}
+
+// ReadManifest reads the manifest of an external plugin at path.
+func ReadManifest(fs afero.Fs, path string) (external.Manifest, error) {
+	b, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return external.Manifest{}, err
+	}
+
+	var m external.Manifest
+	if err := yaml.UnmarshalStrict(b, &m); err != nil {
+		return external.Manifest{}, fmt.Errorf("invalid manifest %q: %w", path, err)
+	}
+	return m, nil
+}
+
+// ApplyManifest configures the plugin with the declarations of its manifest.
+// The name and version declared in the manifest, if any, have to match the ones of the plugin.
+func (p *Plugin) ApplyManifest(m external.Manifest) error {
+	if m.Name != "" && m.Name != p.PName {
+		return fmt.Errorf("manifest name %q does not match the plugin name %q", m.Name, p.PName)
+	}
+	if m.Version != "" {
+		var version plugin.Version
+		if err := version.Parse(m.Version); err != nil {
+			return fmt.Errorf("invalid manifest version: %w", err)
+		}
+		if version.Compare(p.PVersion) != 0 {
+			return fmt.Errorf("manifest version %q does not match the plugin version %q", m.Version, p.PVersion)
+		}
+	}
+
+	if len(m.SupportedProjectVersions) != 0 {
+		p.PSupportedProjectVersions = make([]config.Version, 0, len(m.SupportedProjectVersions))
+		for _, v := range m.SupportedProjectVersions {
+			var projectVersion config.Version
+			if err := projectVersion.Parse(v); err != nil {
+				return fmt.Errorf("invalid supported project version: %w", err)
+			}
+			p.PSupportedProjectVersions = append(p.PSupportedProjectVersions, projectVersion)
+		}
+	}
+
+	for command := range m.Subcommands {
+		if !slices.Contains(manifestCommands, command) {
+			return fmt.Errorf("unknown subcommand %q, expected one of %q", command, manifestCommands)
+		}
+	}
+	if len(m.Subcommands) != 0 {
+		p.Subcommands = m.Subcommands
+	}
+
+	var err error
+	if p.Required, err = parseConstraints(m.Requires); err != nil {
+		return err
+	}
+	if p.Conflicting, err = parseConstraints(m.Conflicts); err != nil {
+		return err
+	}
+	if p.After, err = parseConstraints(m.RunsAfter); err != nil {
+		return err
+	}
+
+	p.Deprecation = m.Deprecation
+	p.Session = m.Session
+
+	if m.Timeout != "" {
+		if p.Limits.Timeout, err = time.ParseDuration(m.Timeout); err != nil {
+			return fmt.Errorf("invalid timeout: %w", err)
+		}
+	}
+	if m.MaxResponseSize < 0 {
+		return fmt.Errorf("invalid maximum response size %d: cannot be negative", m.MaxResponseSize)
+	}
+	p.Limits.MaxResponseSize = m.MaxResponseSize
+	p.Limits.AllowedEnv = m.Env
+	return nil
+}
+
+// parseConstraints parses the plugin constraints declared in a manifest
+func parseConstraints(constraints []string) ([]plugin.Constraint, error) {
+	if len(constraints) == 0 {
+		return nil, nil
+	}
+
+	parsed := make([]plugin.Constraint, 0, len(constraints))
+	for _, constraint := range constraints {
+		c, err := plugin.ParseConstraint(constraint)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, c)
+	}
+	return parsed, nil
+}
+
+// subcommandManifest returns the manifest of the subcommand for command, and whether the plugin implements it
+func (p Plugin) subcommandManifest(command string) (*external.SubcommandManifest, bool) {
+	if p.Subcommands == nil {
+		return nil, true
+	}
+
+	m, found := p.Subcommands[command]
+	return &m, found
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin/external"
+)
+
+var _ = Describe("External plugin manifest", func() {
+	var p Plugin
+
+	BeforeEach(func() {
+		p = Plugin{PName: "externalPlugin", Path: filepath.Join("externalPlugin", "v1", "externalPlugin.sh")}
+		Expect(p.PVersion.Parse("v1")).To(Succeed())
+
+		// the plugin fails to answer, so any value comes from the manifest
+		outputGetter = &mockInValidOutputGetter{}
+		currentDirGetter = &mockValidOsWdGetter{}
+	})
+
+	Context("ReadManifest", func() {
+		It("should read a manifest", func() {
+			fs := afero.NewMemMapFs()
+			Expect(afero.WriteFile(fs, external.ManifestFileName, []byte(`name: externalPlugin
+subcommands:
+  create api:
+    description: Create an API
+    flags:
+    - name: crew-count
+      type: int
+      default: "1"
+session: true
+timeout: 30s
+env:
+- REGISTRY
+`), 0o644)).To(Succeed())
+
+			m, err := ReadManifest(fs, external.ManifestFileName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(m.Name).To(Equal("externalPlugin"))
+			Expect(m.Session).To(BeTrue())
+			Expect(m.Timeout).To(Equal("30s"))
+			Expect(m.Env).To(Equal([]string{"REGISTRY"}))
+			Expect(m.Subcommands).To(HaveKey(createAPICommand))
+			Expect(m.Subcommands[createAPICommand].Flags).To(HaveLen(1))
+		})
+
+		It("should fail on unknown fields", func() {
+			fs := afero.NewMemMapFs()
+			Expect(afero.WriteFile(fs, external.ManifestFileName, []byte("sessions: true\n"), 0o644)).To(Succeed())
+
+			_, err := ReadManifest(fs, external.ManifestFileName)
+			Expect(err).To(MatchError(ContainSubstring("invalid manifest")))
+		})
+	})
+
+	Context("ApplyManifest", func() {
+		It("should configure the plugin", func() {
+			Expect(p.ApplyManifest(external.Manifest{
+				Name:                     "externalPlugin",
+				Version:                  "v1",
+				SupportedProjectVersions: []string{"3"},
+				Requires:                 []string{"go.kubebuilder.io/>=v4"},
+				Conflicts:                []string{"helm.kubebuilder.io"},
+				RunsAfter:                []string{"kustomize.common.kubebuilder.io"},
+				Deprecation:              "use v2 instead",
+				Session:                  true,
+				Timeout:                  "30s",
+				MaxResponseSize:          1024,
+				Env:                      []string{"REGISTRY"},
+			})).To(Succeed())
+
+			Expect(p.SupportedProjectVersions()).To(HaveLen(1))
+			Expect(p.RequiredPlugins()).To(HaveLen(1))
+			Expect(p.ConflictingPlugins()).To(HaveLen(1))
+			Expect(p.RunsAfter()).To(HaveLen(1))
+			Expect(p.DeprecationWarning()).To(Equal("use v2 instead"))
+			Expect(p.Session).To(BeTrue())
+			Expect(p.Limits).To(Equal(Limits{
+				Timeout:         30 * time.Second,
+				MaxResponseSize: 1024,
+				AllowedEnv:      []string{"REGISTRY"},
+			}))
+			Expect(p.GetEditSubcommand().(*editSubcommand).Limits).To(Equal(p.Limits))
+		})
+
+		It("should use the default limits if the manifest does not set them", func() {
+			Expect(p.ApplyManifest(external.Manifest{})).To(Succeed())
+			Expect(p.Limits).To(BeZero())
+		})
+
+		It("should fail on invalid limits", func() {
+			Expect(p.ApplyManifest(external.Manifest{Timeout: "10"})).
+				To(MatchError(ContainSubstring("invalid timeout")))
+			Expect(p.ApplyManifest(external.Manifest{MaxResponseSize: -1})).
+				To(MatchError(ContainSubstring("invalid maximum response size")))
+		})
+
+		It("should fail if the name or version do not match the plugin", func() {
+			Expect(p.ApplyManifest(external.Manifest{Name: "otherPlugin"})).
+				To(MatchError(ContainSubstring("does not match the plugin name")))
+			Expect(p.ApplyManifest(external.Manifest{Version: "v2"})).
+				To(MatchError(ContainSubstring("does not match the plugin version")))
+		})
+
+		It("should fail on unknown subcommands", func() {
+			Expect(p.ApplyManifest(external.Manifest{
+				Subcommands: map[string]external.SubcommandManifest{"delete api": {}},
+			})).To(MatchError(ContainSubstring("unknown subcommand")))
+		})
+
+		It("should fail on invalid constraints", func() {
+			Expect(p.ApplyManifest(external.Manifest{Requires: []string{""}})).NotTo(Succeed())
+		})
+	})
+
+	Context("with declared subcommands", func() {
+		BeforeEach(func() {
+			Expect(p.ApplyManifest(external.Manifest{
+				Subcommands: map[string]external.SubcommandManifest{
+					createAPICommand: {
+						Description: "Create an API",
+						Examples:    "kubebuilder create api --plugins externalPlugin/v1",
+						Flags: []external.Flag{
+							{Name: "crew-count", Type: "int", Default: "1"},
+							{Name: "group", Type: "string"},
+						},
+					},
+				},
+			})).To(Succeed())
+		})
+
+		It("should only return the declared subcommands", func() {
+			Expect(p.GetInitSubcommand()).To(BeNil())
+			Expect(p.GetCreateAPISubcommand()).NotTo(BeNil())
+			Expect(p.GetCreateWebhookSubcommand()).To(BeNil())
+			Expect(p.GetEditSubcommand()).To(BeNil())
+		})
+
+		It("should use the declared metadata", func() {
+			subcmdMeta := &plugin.SubcommandMetadata{}
+			p.GetCreateAPISubcommand().(plugin.UpdatesMetadata).UpdateMetadata(plugin.CLIMetadata{}, subcmdMeta)
+			Expect(subcmdMeta.Description).To(Equal("Create an API"))
+			Expect(subcmdMeta.Examples).To(Equal("kubebuilder create api --plugins externalPlugin/v1"))
+		})
+
+		It("should bind the declared flags", func() {
+			flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			p.GetCreateAPISubcommand().(plugin.HasFlags).BindFlags(flagSet)
+			Expect(flagSet.Lookup("crew-count")).NotTo(BeNil())
+			Expect(flagSet.Lookup("group")).To(BeNil())
+		})
+	})
+})
@@ -19,9 +19,15 @@ package external
 import (
 	"sigs.k8s.io/kubebuilder/v4/pkg/config"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin/external"
 )
 
-var _ plugin.Full = Plugin{}
+var (
+	_ plugin.Full            = Plugin{}
+	_ plugin.HasDependencies = Plugin{}
+	_ plugin.HasConflicts    = Plugin{}
+	_ plugin.HasOrder        = Plugin{}
+)
 
 // Plugin implements the plugin.Full interface
 type Plugin struct {
@@ -31,6 +37,24 @@ type Plugin struct {
 
 	Path string
 	Args []string
+
+	// Session starts the plugin once per CLI invocation and exchanges JSON-RPC messages with it over stdio
+	// for every request, instead of running it for each request. The plugin has to implement the session mode,
+	// which it declares with the session field of its manifest when it is discovered.
+	Session bool
+
+	// Limits restrict what the plugin can use, as declared in its manifest.
+	Limits Limits
+
+	// Subcommands are the subcommands implemented by the plugin by command, as declared in its manifest.
+	// If nil, the plugin implements every subcommand and is requested their metadata and flags.
+	Subcommands map[string]external.SubcommandManifest
+	// Required, Conflicting and After are the constraints on the other plugins declared in its manifest.
+	Required    []plugin.Constraint
+	Conflicting []plugin.Constraint
+	After       []plugin.Constraint
+	// Deprecation is the deprecation warning declared in its manifest.
+	Deprecation string
 }
 
 // Name returns the name of the plugin
@@ -44,36 +68,77 @@ func (p Plugin) SupportedProjectVersions() []config.Version { return p.PSupporte
 
 // GetInitSubcommand will return the subcommand which is responsible for initializing and common scaffolding
 func (p Plugin) GetInitSubcommand() plugin.InitSubcommand {
+	m, implemented := p.subcommandManifest(initCommand)
+	if !implemented {
+		return nil
+	}
 	return &initSubcommand{
-		Path: p.Path,
-		Args: p.Args,
+		Path:      p.Path,
+		Args:      p.Args,
+		Session:   p.Session,
+		Limits:    p.Limits,
+		Manifest:  m,
+		PluginKey: plugin.KeyFor(p),
 	}
 }
 
 // GetCreateAPISubcommand will return the subcommand which is responsible for scaffolding apis
 func (p Plugin) GetCreateAPISubcommand() plugin.CreateAPISubcommand {
+	m, implemented := p.subcommandManifest(createAPICommand)
+	if !implemented {
+		return nil
+	}
 	return &createAPISubcommand{
-		Path: p.Path,
-		Args: p.Args,
+		Path:      p.Path,
+		Args:      p.Args,
+		Session:   p.Session,
+		Limits:    p.Limits,
+		Manifest:  m,
+		PluginKey: plugin.KeyFor(p),
 	}
 }
 
 // GetCreateWebhookSubcommand will return the subcommand which is responsible for scaffolding webhooks
 func (p Plugin) GetCreateWebhookSubcommand() plugin.CreateWebhookSubcommand {
+	m, implemented := p.subcommandManifest(createWebhookCommand)
+	if !implemented {
+		return nil
+	}
 	return &createWebhookSubcommand{
-		Path: p.Path,
-		Args: p.Args,
+		Path:      p.Path,
+		Args:      p.Args,
+		Session:   p.Session,
+		Limits:    p.Limits,
+		Manifest:  m,
+		PluginKey: plugin.KeyFor(p),
 	}
 }
 
 // GetEditSubcommand will return the subcommand which is responsible for editing the scaffold of the project
 func (p Plugin) GetEditSubcommand() plugin.EditSubcommand {
+	m, implemented := p.subcommandManifest(editCommand)
+	if !implemented {
+		return nil
+	}
 	return &editSubcommand{
-		Path: p.Path,
-		Args: p.Args,
+		Path:      p.Path,
+		Args:      p.Args,
+		Session:   p.Session,
+		Limits:    p.Limits,
+		Manifest:  m,
+		PluginKey: plugin.KeyFor(p),
 	}
 }
 
 func (p Plugin) DeprecationWarning() string {
-	return ""
+	return p.Deprecation
 }
+
+// RequiredPlugins returns the constraints that have to be matched by plugins of the chain or of the project
+func (p Plugin) RequiredPlugins() []plugin.Constraint { return p.Required }
+
+// ConflictingPlugins returns the constraints that cannot be matched by any other plugin of the chain
+func (p Plugin) ConflictingPlugins() []plugin.Constraint { return p.Conflicting }
+
+// RunsAfter returns the constraints of the plugins that have to run before this one if they are in the chain
+func (p Plugin) RunsAfter() []plugin.Constraint { return p.After }
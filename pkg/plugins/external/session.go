@@ -0,0 +1,301 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"slices"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin/external"
+)
+
+var sessionStarter SessionStarter = &execSessionStarter{}
+
+// SessionStarter is an interface that implements the method starting an external plugin in session mode.
+type SessionStarter interface {
+	// StartSession returns a connection that reads from the standard output of the plugin and writes to
+	// its standard input. Closing it closes the standard input and waits for the plugin to exit.
+	StartSession(path string, limits Limits) (io.ReadWriteCloser, error)
+}
+
+type execSessionStarter struct{}
+
+func (e *execSessionStarter) StartSession(path string, limits Limits) (io.ReadWriteCloser, error) {
+	cmd := exec.Command(path) //nolint:gosec
+	cmd.Env = append(limits.env(), external.SessionEnvVar+"=true")
+	cmd.Stderr = os.Stderr
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	return &processConn{cmd: cmd, stdin: stdin, stdout: stdout}, nil
+}
+
+// processConn is the connection to the standard input and output of a running plugin
+type processConn struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout io.ReadCloser
+}
+
+// Read implements io.Reader
+func (c *processConn) Read(p []byte) (int, error) {
+	return c.stdout.Read(p)
+}
+
+// Write implements io.Writer
+func (c *processConn) Write(p []byte) (int, error) {
+	return c.stdin.Write(p)
+}
+
+// Kill implements killer
+func (c *processConn) Kill() error {
+	if err := c.cmd.Process.Kill(); err != nil {
+		return err
+	}
+	// unblock the pending read, even if processes started by the plugin keep the output open
+	return c.stdout.Close()
+}
+
+// Close implements io.Closer
+func (c *processConn) Close() error {
+	if err := c.stdin.Close(); err != nil {
+		return err
+	}
+	return c.cmd.Wait()
+}
+
+// sessions are the plugins running in session mode, by path
+var sessions = struct {
+	sync.Mutex
+	m map[string]*session
+}{m: make(map[string]*session)}
+
+// CloseSessions stops the external plugins running in session mode. It is called once the CLI command is done.
+func CloseSessions() error {
+	sessions.Lock()
+	defer sessions.Unlock()
+
+	var errs []error
+	for path, s := range sessions.m {
+		if err := s.close(); err != nil {
+			errs = append(errs, fmt.Errorf("error closing the session of external plugin %q: %w", path, err))
+		}
+		delete(sessions.m, path)
+	}
+	return errors.Join(errs...)
+}
+
+// getSession returns the session of the plugin at path, starting it within limits if it is not running yet
+func getSession(path string, limits Limits) (*session, error) {
+	sessions.Lock()
+	defer sessions.Unlock()
+
+	if s, found := sessions.m[path]; found {
+		return s, nil
+	}
+
+	s, err := startSession(path, limits)
+	if err != nil {
+		return nil, fmt.Errorf("error starting the session of external plugin %q: %w", path, err)
+	}
+	sessions.m[path] = s
+	return s, nil
+}
+
+// killer is implemented by the connections to plugins that can be killed
+type killer interface {
+	// Kill stops the plugin immediately.
+	Kill() error
+}
+
+// session is a running plugin that exchanges JSON-RPC messages over its standard input and output
+type session struct {
+	conn   io.ReadWriteCloser
+	reader *bufio.Reader
+	nextID int
+	limits Limits
+	// err is set once a request timed out or the messages could not be exchanged, after which the plugin was
+	// killed
+	err error
+
+	// apiVersion is the APIVersion of PluginRequest and PluginResponse negotiated with the plugin
+	apiVersion string
+	// capabilities are the optional requests that the plugin answers
+	capabilities []string
+}
+
+// startSession starts the plugin at path within limits and negotiates the APIVersion and capabilities of the session
+func startSession(path string, limits Limits) (*session, error) {
+	conn, err := sessionStarter.StartSession(path, limits)
+	if err != nil {
+		return nil, err
+	}
+	s := &session{conn: conn, reader: bufio.NewReader(conn), limits: limits}
+
+	supported := []string{defaultAPIVersion}
+	var res external.HandshakeResponse
+	if err := s.call(external.HandshakeMethod, external.HandshakeRequest{
+		APIVersions:  supported,
+		Capabilities: []string{external.CapabilityFlags, external.CapabilityMetadata},
+	}, &res); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("handshake failed: %w", err)
+	}
+	if !slices.Contains(supported, res.APIVersion) {
+		_ = conn.Close()
+		return nil, fmt.Errorf("handshake failed: unsupported API version %q, expected one of %q",
+			res.APIVersion, supported)
+	}
+
+	s.apiVersion = res.APIVersion
+	s.capabilities = res.Capabilities
+	return s, nil
+}
+
+// supports checks if the plugin answers the requests of a command
+func (s *session) supports(command string) bool {
+	switch command {
+	case "flags":
+		return slices.Contains(s.capabilities, external.CapabilityFlags)
+	case "metadata":
+		return slices.Contains(s.capabilities, external.CapabilityMetadata)
+	default:
+		return true
+	}
+}
+
+// execute sends a PluginRequest to the plugin and returns its PluginResponse
+func (s *session) execute(req external.PluginRequest) (*external.PluginResponse, error) {
+	if !s.supports(req.Command) {
+		return nil, fmt.Errorf("external plugin does not support %q requests", req.Command)
+	}
+
+	req.APIVersion = s.apiVersion
+	res := external.PluginResponse{}
+	if err := s.call(external.ExecuteMethod, req, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// call sends a JSON-RPC request and decodes the result of its response into result, if not nil.
+// The plugin is killed if it doesn't answer within the timeout of its limits, which fails the following requests
+func (s *session) call(method string, params, result interface{}) error {
+	if s.err != nil {
+		return s.err
+	}
+
+	var timer *time.Timer
+	timeout := s.limits.timeout()
+	if timeout > 0 {
+		timer = time.AfterFunc(timeout, s.kill)
+	}
+	err := s.send(method, params, result)
+	if timer != nil && !timer.Stop() {
+		// The plugin was killed, but its response may have been read right before that
+		s.err = fmt.Errorf("external plugin timed out after %s answering %q request", timeout, method)
+		if err == nil {
+			return nil
+		}
+		return s.err
+	}
+	return err
+}
+
+// send sends a JSON-RPC request and decodes the result of its response into result, if not nil
+func (s *session) send(method string, params, result interface{}) error {
+	s.nextID++
+	req := external.RPCRequest{JSONRPC: external.JSONRPCVersion, ID: s.nextID, Method: method}
+	if params != nil {
+		b, err := json.Marshal(params)
+		if err != nil {
+			return err
+		}
+		req.Params = b
+	}
+	if err := external.WriteMessage(s.conn, req); err != nil {
+		// the request may have been partially written, so the plugin cannot read the following ones
+		s.kill()
+		s.err = fmt.Errorf("error sending %q request: %w", method, err)
+		return s.err
+	}
+
+	var res external.RPCResponse
+	if err := external.ReadLimitedMessage(s.reader, &res, s.limits.maxResponseSize()); err != nil {
+		// the rest of the output cannot be read anymore, and the plugin may be blocked writing it
+		s.kill()
+		s.err = fmt.Errorf("error reading %q response: %w", method, err)
+		return s.err
+	}
+	if res.ID != req.ID {
+		// the responses are out of sync with the requests, so none of the following ones can be trusted
+		s.kill()
+		s.err = fmt.Errorf("unexpected response id %d to %q request %d", res.ID, method, req.ID)
+		return s.err
+	}
+	if res.Error != nil {
+		return res.Error
+	}
+	if result != nil {
+		if err := json.Unmarshal(res.Result, result); err != nil {
+			return fmt.Errorf("error decoding %q response: %w", method, err)
+		}
+	}
+	return nil
+}
+
+// kill stops the plugin, so that the pending request fails
+func (s *session) kill() {
+	if k, isKiller := s.conn.(killer); isKiller {
+		_ = k.Kill()
+	} else {
+		_ = s.conn.Close()
+	}
+}
+
+// close asks the plugin to shut down and waits for it to exit
+func (s *session) close() error {
+	if s.err != nil {
+		// the plugin was killed
+		_ = s.conn.Close()
+		return nil
+	}
+
+	err := s.call(external.ShutdownMethod, nil, nil)
+	if closeErr := s.conn.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
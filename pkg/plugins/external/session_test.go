@@ -0,0 +1,280 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin/external"
+)
+
+// mockSessionStarter starts in-process plugins that answer the session requests
+type mockSessionStarter struct {
+	apiVersion   string
+	capabilities []string
+	// hang stops answering the execute requests
+	hang bool
+	// delay delays the responses to the execute requests
+	delay time.Duration
+	// wrongID answers the execute requests with the id of the next request
+	wrongID bool
+	// unkillable ignores the attempts to kill the plugin
+	unkillable bool
+
+	starts  int
+	methods []string
+}
+
+var _ SessionStarter = &mockSessionStarter{}
+
+func (m *mockSessionStarter) StartSession(string, Limits) (io.ReadWriteCloser, error) {
+	m.starts++
+
+	requestsReader, requestsWriter := io.Pipe()
+	responsesReader, responsesWriter := io.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer responsesWriter.Close()
+		m.serve(bufio.NewReader(requestsReader), responsesWriter)
+	}()
+
+	conn := &mockConn{responses: responsesReader, requests: requestsWriter, done: done}
+	if m.unkillable {
+		return unkillableConn{conn}, nil
+	}
+	return conn, nil
+}
+
+// serve answers the requests until the standard input is closed
+func (m *mockSessionStarter) serve(r *bufio.Reader, w io.Writer) {
+	for {
+		var req external.RPCRequest
+		if err := external.ReadMessage(r, &req); err != nil {
+			return
+		}
+		m.methods = append(m.methods, req.Method)
+
+		var result interface{}
+		switch req.Method {
+		case external.HandshakeMethod:
+			result = external.HandshakeResponse{APIVersion: m.apiVersion, Capabilities: m.capabilities}
+		case external.ExecuteMethod:
+			if m.hang {
+				continue
+			}
+			time.Sleep(m.delay)
+			if m.wrongID {
+				req.ID++
+			}
+			var pluginReq external.PluginRequest
+			_ = json.Unmarshal(req.Params, &pluginReq)
+			res := external.PluginResponse{APIVersion: pluginReq.APIVersion, Command: pluginReq.Command}
+			switch pluginReq.Command {
+			case "flags":
+				res.Flags = getFlags()
+			case "metadata":
+				res.Metadata = getMetadata()
+			default:
+				res.Universe = map[string]string{"LICENSE": "Apache 2.0 License\n"}
+			}
+			result = res
+		}
+
+		res := external.RPCResponse{JSONRPC: external.JSONRPCVersion, ID: req.ID}
+		if result != nil {
+			res.Result, _ = json.Marshal(result)
+		}
+		if err := external.WriteMessage(w, res); err != nil {
+			return
+		}
+	}
+}
+
+type mockConn struct {
+	responses io.ReadCloser
+	requests  io.WriteCloser
+
+	done chan struct{}
+}
+
+// Read implements io.Reader
+func (c *mockConn) Read(p []byte) (int, error) {
+	return c.responses.Read(p)
+}
+
+// Write implements io.Writer
+func (c *mockConn) Write(p []byte) (int, error) {
+	return c.requests.Write(p)
+}
+
+// Close implements io.Closer
+func (c *mockConn) Close() error {
+	err := c.requests.Close()
+	// unblock the plugin if it is writing a response that is not going to be read
+	_ = c.responses.Close()
+	<-c.done
+	return err
+}
+
+// unkillableConn is a connection to a plugin that ignores the attempts to kill it
+type unkillableConn struct {
+	*mockConn
+}
+
+// Kill implements killer
+func (unkillableConn) Kill() error {
+	return nil
+}
+
+var _ = Describe("Run external plugin in session mode", func() {
+	const pluginPath = "externalPlugin.sh"
+
+	var (
+		starter *mockSessionStarter
+		fs      machinery.Filesystem
+	)
+
+	BeforeEach(func() {
+		starter = &mockSessionStarter{
+			apiVersion:   defaultAPIVersion,
+			capabilities: []string{external.CapabilityFlags, external.CapabilityMetadata},
+		}
+		sessionStarter = starter
+		outputGetter = &mockInValidOutputGetter{}
+		currentDirGetter = &mockValidOsWdGetter{}
+		fs = machinery.Filesystem{FS: afero.NewMemMapFs()}
+	})
+
+	AfterEach(func() {
+		Expect(CloseSessions()).To(Succeed())
+	})
+
+	It("should start the plugin once for every request", func() {
+		sc := createAPISubcommand{Path: pluginPath, Args: []string{"--captain", "jack"}, Session: true}
+
+		sc.UpdateMetadata(plugin.CLIMetadata{}, &plugin.SubcommandMetadata{})
+		flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		sc.BindFlags(flagSet)
+		Expect(flagSet.Lookup("crew-count")).NotTo(BeNil())
+		Expect(sc.Scaffold(fs)).To(Succeed())
+
+		b, err := afero.ReadFile(fs.FS, filepath.Join("tmp", "externalPlugin", "LICENSE"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(Equal("Apache 2.0 License\n"))
+
+		Expect(CloseSessions()).To(Succeed())
+		Expect(starter.starts).To(Equal(1))
+		Expect(starter.methods).To(Equal([]string{
+			external.HandshakeMethod,
+			external.ExecuteMethod,
+			external.ExecuteMethod,
+			external.ExecuteMethod,
+			external.ShutdownMethod,
+		}))
+	})
+
+	It("should not send the requests the plugin does not support", func() {
+		starter.capabilities = nil
+		sc := initSubcommand{Path: pluginPath, Args: []string{"--captain", "jack"}, Session: true}
+
+		subcmdMeta := &plugin.SubcommandMetadata{}
+		sc.UpdateMetadata(plugin.CLIMetadata{}, subcmdMeta)
+		Expect(subcmdMeta.Description).NotTo(Equal(getMetadata().Description))
+		flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		sc.BindFlags(flagSet)
+		Expect(flagSet.Lookup("captain")).NotTo(BeNil())
+		Expect(flagSet.Lookup("crew-count")).To(BeNil())
+
+		Expect(starter.methods).To(Equal([]string{external.HandshakeMethod}))
+	})
+
+	It("should kill the plugin if it times out", func() {
+		starter.hang = true
+		sc := editSubcommand{Path: pluginPath, Session: true, Limits: Limits{Timeout: 100 * time.Millisecond}}
+
+		err := sc.Scaffold(fs)
+		Expect(err).To(MatchError(ContainSubstring(`timed out after 100ms answering "execute" request`)))
+		Expect(sc.Scaffold(fs)).To(MatchError(err))
+	})
+
+	It("should keep the response read before the plugin is killed for timing out", func() {
+		starter.delay = 100 * time.Millisecond
+		starter.unkillable = true
+		sc := editSubcommand{Path: pluginPath, Session: true, Limits: Limits{Timeout: 50 * time.Millisecond}}
+
+		Expect(sc.Scaffold(fs)).To(Succeed())
+		Expect(sc.Scaffold(fs)).To(MatchError(ContainSubstring(`timed out after 50ms answering "execute" request`)))
+	})
+
+	It("should fail the following requests if a response has an unexpected id", func() {
+		starter.wrongID = true
+		sc := editSubcommand{Path: pluginPath, Session: true}
+
+		err := sc.Scaffold(fs)
+		Expect(err).To(MatchError(ContainSubstring(`unexpected response id 3 to "execute" request 2`)))
+		Expect(sc.Scaffold(fs)).To(MatchError(err))
+		Expect(starter.methods).To(Equal([]string{external.HandshakeMethod, external.ExecuteMethod}))
+	})
+
+	It("should fail if a response is too large", func() {
+		sc := editSubcommand{Path: pluginPath, Session: true, Limits: Limits{MaxResponseSize: 16}}
+
+		Expect(sc.Scaffold(fs)).To(MatchError(ContainSubstring("exceeds the maximum size of 16 bytes")))
+	})
+
+	It("should fail if the plugin chooses an unsupported API version", func() {
+		starter.apiVersion = "v2"
+		sc := editSubcommand{Path: pluginPath, Session: true}
+
+		err := sc.Scaffold(fs)
+		Expect(err).To(MatchError(ContainSubstring("unsupported API version")))
+		Expect(starter.methods).To(Equal([]string{external.HandshakeMethod}))
+	})
+})
+
+var _ = Describe("Read session messages", func() {
+	read := func(message string) error {
+		var res external.RPCResponse
+		return external.ReadLimitedMessage(bufio.NewReader(strings.NewReader(message)), &res, DefaultMaxResponseSize)
+	}
+
+	It("should read a message framed with a Content-Length header", func() {
+		Expect(read("Content-Type: application/json\r\nContent-Length: 2\r\n\r\n{}")).To(Succeed())
+	})
+
+	DescribeTable("should fail if the headers are too large",
+		func(headers string) {
+			Expect(read(headers + "Content-Length: 2\r\n\r\n{}")).To(
+				MatchError("message headers exceed the maximum size of 4096 bytes"))
+		},
+		Entry("for a long header line", "X-Header: "+strings.Repeat("a", 8192)+"\r\n"),
+		Entry("for too many header lines", strings.Repeat("X-Header: a\r\n", 512)),
+	)
+})
@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -38,6 +39,8 @@ var _ plugin.CreateAPISubcommand = &createAPISubcommand{}
 
 type createAPISubcommand struct {
 	config config.Config
+	// out is where the messages and the output of the commands are printed
+	out io.Writer
 
 	options *goPlugin.Options
 
@@ -129,6 +132,11 @@ func (p *createAPISubcommand) InjectConfig(c config.Config) error {
 	return nil
 }
 
+func (p *createAPISubcommand) InjectOutput(out io.Writer) error {
+	p.out = out
+	return nil
+}
+
 func (p *createAPISubcommand) InjectResource(res *resource.Resource) error {
 	p.resource = res
 	p.options.DoAPI = true
@@ -221,25 +229,25 @@ func (p *createAPISubcommand) Scaffold(fs machinery.Filesystem) error {
 }
 
 func (p *createAPISubcommand) PostScaffold() error {
-	err := util.RunCmd("Update dependencies", "go", "mod", "tidy")
+	err := util.RunCmdWithOutput(p.out, "Update dependencies", "go", "mod", "tidy")
 	if err != nil {
 		return err
 	}
 	if p.runMake && p.resource.HasAPI() {
-		err = util.RunCmd("Running make", "make", "generate")
+		err = util.RunCmdWithOutput(p.out, "Running make", "make", "generate")
 		if err != nil {
 			return err
 		}
 	}
 
 	if p.runManifests && p.resource.HasAPI() {
-		err = util.RunCmd("Running make", "make", "manifests")
+		err = util.RunCmdWithOutput(p.out, "Running make", "make", "manifests")
 		if err != nil {
 			return err
 		}
 	}
 
-	fmt.Print("Next: check the implementation of your new API and controller. " +
+	_, _ = fmt.Fprint(p.out, "Next: check the implementation of your new API and controller. "+
 		"If you do changes in the API run the manifests with:\n$ make manifests\n")
 
 	return nil
@@ -0,0 +1,160 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin/util"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/deploy-image/v1alpha1/scaffolds"
+)
+
+var _ plugin.DeleteAPISubcommand = &deleteAPISubcommand{}
+
+type deleteAPISubcommand struct {
+	config config.Config
+	// out is where the messages and the output of the commands are printed
+	out io.Writer
+	// For help text.
+	commandName string
+
+	resource *resource.Resource
+
+	// tracked is the configuration the plugin tracked for the resource when creating its API
+	tracked ResourceData
+
+	// runMake indicates whether to run make or not after removing APIs
+	runMake bool
+
+	// force indicates whether to remove the files and code modified since they were scaffolded
+	force bool
+}
+
+func (p *deleteAPISubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, subcmdMeta *plugin.SubcommandMetadata) {
+	p.commandName = cliMeta.CommandName
+
+	subcmdMeta.Description = `Remove an API created by the deploy-image plugin, along with the code that wires it
+in the project, the environment variable with the image of its Operand in the manager
+and its configuration in the PROJECT file.
+
+Files and code that were modified since they were scaffolded are only removed with
+--force. After the scaffold is removed, make generate will be run.
+`
+	subcmdMeta.Examples = fmt.Sprintf(`  # Delete the Memcached API with Group: example.com, Version: v1alpha1 and Kind: Memcached
+  %[1]s delete api --group example.com --version v1alpha1 --kind Memcached \
+    --plugins="deploy-image/v1-alpha"
+`, cliMeta.CommandName)
+}
+
+func (p *deleteAPISubcommand) BindFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&p.runMake, "make", true, "if true, run `make generate` after removing files")
+	fs.BoolVar(&p.force, "force", false,
+		"remove the files and code even if they were modified since they were scaffolded")
+}
+
+func (p *deleteAPISubcommand) InjectConfig(c config.Config) error {
+	p.config = c
+	return nil
+}
+
+func (p *deleteAPISubcommand) InjectOutput(out io.Writer) error {
+	p.out = out
+	return nil
+}
+
+func (p *deleteAPISubcommand) InjectResource(res *resource.Resource) error {
+	stored, err := plugins.LookupResource(p.config, res.GVK)
+	if err != nil {
+		return fmt.Errorf("%s delete api requires a previously created API: %w", p.commandName, err)
+	}
+	if stored.Webhooks != nil && !stored.Webhooks.IsEmpty() {
+		return fmt.Errorf("the webhooks of the API have to be deleted first with `%s delete webhook`", p.commandName)
+	}
+
+	cfg := PluginConfig{}
+	if err := p.config.DecodePluginConfig(pluginKey, &cfg); err != nil &&
+		!errors.As(err, &config.PluginKeyNotFoundError{}) {
+		return err
+	}
+	found := false
+	for _, data := range cfg.Resources {
+		if data.Group == stored.Group && data.Domain == stored.Domain && data.Version == stored.Version &&
+			data.Kind == stored.Kind {
+			p.tracked, found = data, true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("the API was not created by the %s plugin, delete it with `%s delete api` instead",
+			pluginKey, p.commandName)
+	}
+
+	// Remove what was scaffolded for the resource, not what the flags describe
+	*res = stored
+	p.resource = res
+	return nil
+}
+
+func (p *deleteAPISubcommand) Scaffold(fs machinery.Filesystem) error {
+	scaffolder := scaffolds.NewDeleteAPIScaffolder(p.config, *p.resource,
+		p.tracked.Options.Image,
+		p.tracked.Options.ContainerCommand,
+		p.tracked.Options.ContainerPort,
+		p.tracked.Options.RunAsUser,
+		p.force)
+	scaffolder.InjectFS(fs)
+	if err := scaffolder.Scaffold(); err != nil {
+		return err
+	}
+
+	// Stop tracking the resource, removing the configuration of the plugin along with the last one
+	cfg := PluginConfig{}
+	if err := p.config.DecodePluginConfig(pluginKey, &cfg); err != nil {
+		return err
+	}
+	resources := make([]ResourceData, 0, len(cfg.Resources))
+	for _, data := range cfg.Resources {
+		if data != p.tracked {
+			resources = append(resources, data)
+		}
+	}
+	if len(resources) == 0 {
+		return p.config.RemovePluginConfig(pluginKey)
+	}
+	cfg.Resources = resources
+	return p.config.EncodePluginConfig(pluginKey, cfg)
+}
+
+func (p *deleteAPISubcommand) PostScaffold() error {
+	if p.runMake {
+		if err := util.RunCmdWithOutput(p.out, "Running make", "make", "generate"); err != nil {
+			return err
+		}
+	}
+
+	_, _ = fmt.Fprint(p.out, "Next: update the manifests with:\n$ make manifests\n")
+	return nil
+}
@@ -19,9 +19,13 @@ package v1alpha1
 import (
 	"sigs.k8s.io/kubebuilder/v4/pkg/config"
 	cfgv3 "sigs.k8s.io/kubebuilder/v4/pkg/config/v3"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
 	"sigs.k8s.io/kubebuilder/v4/pkg/model/stage"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins"
+	kustomizev2scaffolds "sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang"
+	golangv4scaffolds "sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds"
 )
 
 const pluginName = "deploy-image." + golang.DefaultNameQualifier
@@ -30,13 +34,25 @@ var (
 	pluginVersion            = plugin.Version{Number: 1, Stage: stage.Alpha}
 	supportedProjectVersions = []config.Version{cfgv3.Version}
 	pluginKey                = plugin.KeyFor(Plugin{})
+	// The plugin scaffolds on top of the go/v4 layout, updating the files scaffolded by go/v4 and kustomize/v2
+	requiredPlugins = []plugin.Constraint{
+		plugin.MustParseConstraint("base." + golang.DefaultNameQualifier + "/>=v4"),
+		plugin.MustParseConstraint("kustomize.common." + plugins.DefaultNameQualifier + "/>=v2"),
+	}
 )
 
-var _ plugin.CreateAPI = Plugin{}
+var (
+	_ plugin.CreateAPI          = Plugin{}
+	_ plugin.DeleteAPI          = Plugin{}
+	_ plugin.HasRequiredMarkers = Plugin{}
+	_ plugin.HasDependencies    = Plugin{}
+	_ plugin.HasOrder           = Plugin{}
+)
 
 // Plugin implements the plugin.Full interface
 type Plugin struct {
 	createAPISubcommand
+	deleteAPISubcommand
 }
 
 // Name returns the name of the plugin
@@ -48,9 +64,32 @@ func (Plugin) Version() plugin.Version { return pluginVersion }
 // SupportedProjectVersions returns an array with all project versions supported by the plugin
 func (Plugin) SupportedProjectVersions() []config.Version { return supportedProjectVersions }
 
+// RequiredPlugins returns the plugins that have to be used together with this one
+func (Plugin) RequiredPlugins() []plugin.Constraint { return requiredPlugins }
+
+// RunsAfter returns the plugins that have to run before this one, as it updates the files they scaffold
+func (Plugin) RunsAfter() []plugin.Constraint { return requiredPlugins }
+
 // GetCreateAPISubcommand will return the subcommand which is responsible for scaffolding apis
 func (p Plugin) GetCreateAPISubcommand() plugin.CreateAPISubcommand { return &p.createAPISubcommand }
 
+// GetDeleteAPISubcommand will return the subcommand which is responsible for removing the apis it scaffolded
+func (p Plugin) GetDeleteAPISubcommand() plugin.DeleteAPISubcommand { return &p.deleteAPISubcommand }
+
+// GetRequiredMarkers returns the markers of the files updated by the plugin, which reuses the scaffolds of the
+// go/v4 and kustomize/v2 plugins to create APIs
+func (Plugin) GetRequiredMarkers(cfg config.Config) ([]machinery.RequiredMarker, error) {
+	markers, err := golangv4scaffolds.RequiredMarkers(cfg)
+	if err != nil {
+		return nil, err
+	}
+	kustomizeMarkers, err := kustomizev2scaffolds.RequiredMarkers(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return append(markers, kustomizeMarkers...), nil
+}
+
 type PluginConfig struct {
 	Resources []ResourceData `json:"resources,omitempty"`
 }
@@ -31,6 +31,8 @@ import (
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins"
 	kustomizev2scaffolds "sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/deploy-image/v1alpha1/scaffolds/internal/templates/api"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/deploy-image/v1alpha1/scaffolds/internal/templates/cmd"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/deploy-image/v1alpha1/scaffolds/internal/templates/config/manager"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/deploy-image/v1alpha1/scaffolds/internal/templates/config/samples"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/deploy-image/v1alpha1/scaffolds/internal/templates/controllers"
 	golangv4scaffolds "sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds"
@@ -93,13 +95,13 @@ func (s *apiScaffolder) Scaffold() error {
 		machinery.WithResource(&s.resource),
 	)
 
-	if err := scaffold.Execute(
+	if _, err := scaffold.Execute(
 		&api.Types{Port: s.port},
 	); err != nil {
 		return fmt.Errorf("error updating APIs: %v", err)
 	}
 
-	if err := scaffold.Execute(
+	if _, err := scaffold.Execute(
 		&samples.CRDSample{Port: s.port},
 	); err != nil {
 		return fmt.Errorf("error updating config/samples: %v", err)
@@ -109,7 +111,7 @@ func (s *apiScaffolder) Scaffold() error {
 		ControllerRuntimeVersion: golangv4scaffolds.ControllerRuntimeVersion,
 	}
 
-	if err := scaffold.Execute(
+	if _, err := scaffold.Execute(
 		controller,
 	); err != nil {
 		return fmt.Errorf("error scaffolding controller: %v", err)
@@ -124,7 +126,7 @@ func (s *apiScaffolder) Scaffold() error {
 		return fmt.Errorf("error updating main.go: %v", err)
 	}
 
-	if err := scaffold.Execute(
+	if _, err := scaffold.Execute(
 		&controllers.ControllerTest{Port: s.port},
 	); err != nil {
 		return fmt.Errorf("error creating controller/**_controller_test.go: %v", err)
@@ -142,16 +144,16 @@ func (s *apiScaffolder) Scaffold() error {
 // controller to create the Pod for the Kind
 func (s *apiScaffolder) addEnvVarIntoManager() error {
 	managerPath := filepath.Join("config", "manager", "manager.yaml")
-	err := util.ReplaceInFile(managerPath, `env:`, `env:`)
+	err := util.ReplaceInFileFS(s.fs, managerPath, `env:`, `env:`)
 	if err != nil {
-		if err := util.InsertCode(managerPath, `name: manager`, `
+		if err := util.InsertCodeFS(s.fs, managerPath, `name: manager`, `
         env:`); err != nil {
 			return fmt.Errorf("error scaffolding env key in config/manager/manager.yaml")
 		}
 	}
 
-	if err = util.InsertCode(managerPath, `env:`,
-		fmt.Sprintf(envVarTemplate, strings.ToUpper(s.resource.Kind), s.image)); err != nil {
+	if err = util.InsertCodeFS(s.fs, managerPath, `env:`,
+		fmt.Sprintf(manager.EnvVarCodeFragment, strings.ToUpper(s.resource.Kind), s.image)); err != nil {
 		return fmt.Errorf("error scaffolding env key in config/manager/manager.yaml")
 	}
 
@@ -175,13 +177,14 @@ func (s *apiScaffolder) scaffoldCreateAPI() error {
 // which will have its own controller template which set the recorder so that we can use it
 // in the reconciliation to create an event inside for the finalizer
 func (s *apiScaffolder) updateMainByAddingEventRecorder(defaultMainPath string) error {
-	if err := util.InsertCode(
+	if err := util.InsertCodeFS(
+		s.fs,
 		defaultMainPath,
 		fmt.Sprintf(
 			`%sReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),`, s.resource.Kind),
-		fmt.Sprintf(recorderTemplate, strings.ToLower(s.resource.Kind)),
+		fmt.Sprintf(cmd.RecorderCodeFragment, strings.ToLower(s.resource.Kind)),
 	); err != nil {
 		return fmt.Errorf("error scaffolding event recorder in %s: %v", defaultMainPath, err)
 	}
@@ -191,7 +194,8 @@ func (s *apiScaffolder) updateMainByAddingEventRecorder(defaultMainPath string)
 
 // updateControllerCode will update the code generate on the template to add the Container information
 func (s *apiScaffolder) updateControllerCode(controller controllers.Controller) error {
-	if err := util.ReplaceInFile(
+	if err := util.ReplaceInFileFS(
+		s.fs,
 		controller.Path,
 		"//TODO: scaffold container",
 		fmt.Sprintf(containerTemplate, // value for the image
@@ -215,7 +219,7 @@ func (s *apiScaffolder) updateControllerCode(controller controllers.Controller)
 		// remove the first space to not fail in the go fmt ./...
 		res = strings.TrimLeft(res, " ")
 
-		if err := util.InsertCode(controller.Path, `SecurityContext: &corev1.SecurityContext{
+		if err := util.InsertCodeFS(s.fs, controller.Path, `SecurityContext: &corev1.SecurityContext{
 							RunAsNonRoot:             &[]bool{true}[0],
 							AllowPrivilegeEscalation: &[]bool{false}[0],
 							Capabilities: &corev1.Capabilities{
@@ -231,7 +235,8 @@ func (s *apiScaffolder) updateControllerCode(controller controllers.Controller)
 
 	// Scaffold the port if informed
 	if len(s.port) > 0 {
-		if err := util.InsertCode(
+		if err := util.InsertCodeFS(
+			s.fs,
 			controller.Path,
 			`SecurityContext: &corev1.SecurityContext{
 							RunAsNonRoot:             &[]bool{true}[0],
@@ -254,7 +259,8 @@ func (s *apiScaffolder) updateControllerCode(controller controllers.Controller)
 	}
 
 	if len(s.runAsUser) > 0 {
-		if err := util.InsertCode(
+		if err := util.InsertCodeFS(
+			s.fs,
 			controller.Path,
 			`RunAsNonRoot:             &[]bool{true}[0],`,
 			fmt.Sprintf(runAsUserTemplate, s.runAsUser),
@@ -318,10 +324,3 @@ const portTemplate = `
 							ContainerPort: %s.Spec.ContainerPort,
 							Name:          "%s",
 						}},`
-
-const recorderTemplate = `
-		Recorder: mgr.GetEventRecorderFor("%s-controller"),`
-
-const envVarTemplate = `
-        - name: %s_IMAGE
-          value: %s`
@@ -0,0 +1,173 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins"
+	kustomizev2scaffolds "sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/deploy-image/v1alpha1/scaffolds/internal/templates/cmd"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/deploy-image/v1alpha1/scaffolds/internal/templates/config/manager"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/deploy-image/v1alpha1/scaffolds/internal/templates/controllers"
+	golangv4scaffolds "sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds"
+)
+
+var _ plugins.Scaffolder = &deleteAPIScaffolder{}
+
+// deleteAPIScaffolder contains configuration for removing the scaffolding of an API created by the plugin, along
+// with the updates the plugin made to the files scaffolded by the go/v4 and kustomize/v2 plugins.
+type deleteAPIScaffolder struct {
+	config    config.Config
+	resource  resource.Resource
+	image     string
+	command   string
+	port      string
+	runAsUser string
+
+	// force indicates whether to remove the files and code modified since they were scaffolded
+	force bool
+
+	// fs is the filesystem that will be used by the scaffolder
+	fs machinery.Filesystem
+}
+
+// NewDeleteAPIScaffolder returns a new Scaffolder for the deletion of the APIs created by the plugin with the
+// provided options
+func NewDeleteAPIScaffolder(config config.Config, res resource.Resource, image, command, port, runAsUser string,
+	force bool,
+) plugins.Scaffolder {
+	return &deleteAPIScaffolder{
+		config:    config,
+		resource:  res,
+		image:     image,
+		command:   command,
+		port:      port,
+		runAsUser: runAsUser,
+		force:     force,
+	}
+}
+
+// InjectFS implements cmdutil.Scaffolder
+func (s *deleteAPIScaffolder) InjectFS(fs machinery.Filesystem) {
+	s.fs = fs
+}
+
+// Scaffold implements cmdutil.Scaffolder
+func (s *deleteAPIScaffolder) Scaffold() error {
+	log.Println("Removing the updates of the deploy-image/v1alpha1 plugin...")
+
+	scaffold := machinery.NewScaffold(s.fs,
+		machinery.WithConfig(s.config),
+		machinery.WithResource(&s.resource),
+	)
+
+	// The recorder has to be ejected before the go/v4 plugin ejects the setup of the reconciler that contains it
+	builders := []machinery.Builder{
+		machinery.Eject(&cmd.MainUpdater{}, s.force),
+		machinery.Eject(&manager.ManagerUpdater{Image: s.image}, s.force),
+	}
+
+	// The controller is updated after being scaffolded, so it doesn't match its recorded checksum. It is removed
+	// if it matches what the plugin scaffolds instead, and left to the go/v4 plugin otherwise.
+	controller, unmodified, err := s.isControllerUnmodified()
+	if err != nil {
+		return fmt.Errorf("error deleting controller: %w", err)
+	}
+	if unmodified {
+		remover := &machinery.RemoverMixin{}
+		remover.Path = controller
+		builders = append(builders, remover)
+	}
+
+	if _, err := scaffold.Execute(builders...); err != nil {
+		return fmt.Errorf("error deleting API: %w", err)
+	}
+
+	golangV4Scaffolder := golangv4scaffolds.NewDeleteAPIScaffolder(s.config, s.resource, s.force)
+	golangV4Scaffolder.InjectFS(s.fs)
+	if err := golangV4Scaffolder.Scaffold(); err != nil {
+		return fmt.Errorf("error deleting golang files of the API: %w", err)
+	}
+
+	kustomizeScaffolder := kustomizev2scaffolds.NewDeleteAPIScaffolder(s.config, s.resource, s.force)
+	kustomizeScaffolder.InjectFS(s.fs)
+	if err := kustomizeScaffolder.Scaffold(); err != nil {
+		return fmt.Errorf("error deleting kustomize manifests of the API: %w", err)
+	}
+
+	return nil
+}
+
+// isControllerUnmodified scaffolds the controller of the resource in memory, the same way it is created, and
+// checks whether it matches the current one
+func (s *deleteAPIScaffolder) isControllerUnmodified() (string, bool, error) {
+	boilerplate, err := afero.ReadFile(s.fs.FS, filepath.Join("hack", "boilerplate.go.txt"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("unable to load boilerplate: %w", err)
+	}
+
+	generator := &apiScaffolder{
+		config:    s.config,
+		resource:  s.resource,
+		image:     s.image,
+		command:   s.command,
+		port:      s.port,
+		runAsUser: s.runAsUser,
+		fs:        machinery.Filesystem{FS: afero.NewMemMapFs()},
+	}
+	scaffold := machinery.NewScaffold(generator.fs,
+		machinery.WithConfig(s.config),
+		machinery.WithBoilerplate(string(boilerplate)),
+		machinery.WithResource(&generator.resource),
+		machinery.WithChecksumsPath(""),
+	)
+	controller := &controllers.Controller{
+		ControllerRuntimeVersion: golangv4scaffolds.ControllerRuntimeVersion,
+	}
+	if _, err := scaffold.Execute(controller); err != nil {
+		return "", false, err
+	}
+	if err := generator.updateControllerCode(*controller); err != nil {
+		return "", false, err
+	}
+
+	scaffolded, err := afero.ReadFile(generator.fs.FS, controller.Path)
+	if err != nil {
+		return "", false, err
+	}
+	current, err := afero.ReadFile(s.fs.FS, controller.Path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	return controller.Path, string(current) == string(scaffolded), nil
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+const defaultMainPath = "cmd/main.go"
+
+// RecorderCodeFragment is the field that sets the event recorder of the reconciler of a resource
+const RecorderCodeFragment = `
+		Recorder: mgr.GetEventRecorderFor("%s-controller"),`
+
+var _ machinery.Inserter = &MainUpdater{}
+
+// MainUpdater describes the event recorder that the plugin sets in the reconciler of the resource in
+// cmd/main.go, so that it can be ejected
+type MainUpdater struct {
+	machinery.ResourceMixin
+}
+
+// GetPath implements file.Builder
+func (*MainUpdater) GetPath() string {
+	return defaultMainPath
+}
+
+// GetIfExistsAction implements file.Builder
+func (*MainUpdater) GetIfExistsAction() machinery.IfExistsAction {
+	return machinery.OverwriteFile
+}
+
+const setupMarker = "builder"
+
+// GetMarkers implements file.Inserter
+func (*MainUpdater) GetMarkers() []machinery.Marker {
+	return []machinery.Marker{machinery.NewMarkerFor(defaultMainPath, setupMarker)}
+}
+
+// GetCodeFragments implements file.Inserter
+func (f *MainUpdater) GetCodeFragments() machinery.CodeFragmentsMap {
+	return machinery.CodeFragmentsMap{
+		machinery.NewMarkerFor(defaultMainPath, setupMarker): {
+			fmt.Sprintf(RecorderCodeFragment, strings.ToLower(f.Resource.Kind)),
+		},
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+// EnvVarCodeFragment is the environment variable of the manager with the image of the Operand of a resource
+const EnvVarCodeFragment = `
+        - name: %s_IMAGE
+          value: %s`
+
+var _ machinery.Inserter = &ManagerUpdater{}
+
+// ManagerUpdater describes the environment variable that the plugin adds to the manager for the image of the
+// Operand of the resource in config/manager/manager.yaml, so that it can be ejected
+type ManagerUpdater struct {
+	machinery.ResourceMixin
+
+	// Image is the image of the Operand
+	Image string
+}
+
+// GetPath implements file.Builder
+func (*ManagerUpdater) GetPath() string {
+	return managerPath()
+}
+
+// GetIfExistsAction implements file.Builder
+func (*ManagerUpdater) GetIfExistsAction() machinery.IfExistsAction {
+	return machinery.OverwriteFile
+}
+
+const envMarker = "env"
+
+// GetMarkers implements file.Inserter
+func (*ManagerUpdater) GetMarkers() []machinery.Marker {
+	return []machinery.Marker{machinery.NewMarkerFor(managerPath(), envMarker)}
+}
+
+// GetCodeFragments implements file.Inserter
+func (f *ManagerUpdater) GetCodeFragments() machinery.CodeFragmentsMap {
+	return machinery.CodeFragmentsMap{
+		machinery.NewMarkerFor(managerPath(), envMarker): {
+			fmt.Sprintf(EnvVarCodeFragment, strings.ToUpper(f.Resource.Kind), f.Image),
+		},
+	}
+}
+
+// managerPath returns the path of the manifest of the manager
+func managerPath() string {
+	return filepath.Join("config", "manager", "manager.yaml")
+}
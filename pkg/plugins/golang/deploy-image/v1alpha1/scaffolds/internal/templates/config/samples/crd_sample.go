@@ -22,6 +22,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/deploy-image/v1alpha1/scaffolds/internal/templates"
 )
 
 var _ machinery.Template = &CRDSample{}
@@ -50,24 +51,11 @@ func (f *CRDSample) SetTemplateDefaults() error {
 
 	f.IfExistsAction = machinery.OverwriteFile
 
-	f.TemplateBody = crdSampleTemplate
+	body, err := machinery.ReadTemplateBody(templates.FS, "config/samples/crd_sample.yaml.tmpl")
+	if err != nil {
+		return err
+	}
+	f.TemplateBody = body
 
 	return nil
 }
-
-const crdSampleTemplate = `apiVersion: {{ .Resource.QualifiedGroup }}/{{ .Resource.Version }}
-kind: {{ .Resource.Kind }}
-metadata:
-  labels:
-    app.kubernetes.io/name: {{ .ProjectName }}
-    app.kubernetes.io/managed-by: kustomize
-  name: {{ lower .Resource.Kind }}-sample
-spec:
-  # TODO(user): edit the following value to ensure the number
-  # of Pods/Instances your Operand must have on cluster
-  size: 1
-{{ if not (isEmptyStr .Port) }}
-  # TODO(user): edit the following value to ensure the container has the right port to be initialized
-  containerPort: {{ .Port }}
-{{ end -}}
-`
@@ -0,0 +1,26 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import "embed"
+
+// FS contains the bodies of the templates of the plugin, in the directory of the package of each template
+//
+//go:embed api/*.tmpl
+//go:embed config/samples/*.tmpl
+//go:embed controllers/*.tmpl
+var FS embed.FS
@@ -20,6 +20,7 @@ import (
 	"bufio"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 
 	log "github.com/sirupsen/logrus"
@@ -41,6 +42,8 @@ var _ plugin.CreateAPISubcommand = &createAPISubcommand{}
 
 type createAPISubcommand struct {
 	config config.Config
+	// out is where the messages and the output of the commands are printed
+	out io.Writer
 
 	options *goPlugin.Options
 
@@ -124,6 +127,11 @@ func (p *createAPISubcommand) InjectConfig(c config.Config) error {
 	return nil
 }
 
+func (p *createAPISubcommand) InjectOutput(out io.Writer) error {
+	p.out = out
+	return nil
+}
+
 func (p *createAPISubcommand) InjectResource(res *resource.Resource) error {
 	p.resource = res
 
@@ -185,16 +193,17 @@ func (p *createAPISubcommand) Scaffold(fs machinery.Filesystem) error {
 }
 
 func (p *createAPISubcommand) PostScaffold() error {
-	err := util.RunCmd("Update dependencies", "go", "mod", "tidy")
+	err := util.RunCmdWithOutput(p.out, "Update dependencies", "go", "mod", "tidy")
 	if err != nil {
 		return err
 	}
 	if p.runMake && p.resource.HasAPI() {
-		err = util.RunCmd("Running make", "make", "generate")
+		err = util.RunCmdWithOutput(p.out, "Running make", "make", "generate")
 		if err != nil {
 			return err
 		}
-		fmt.Print("Next: implement your new API and generate the manifests (e.g. CRDs,CRs) with:\n$ make manifests\n")
+		_, _ = fmt.Fprint(p.out,
+			"Next: implement your new API and generate the manifests (e.g. CRDs,CRs) with:\n$ make manifests\n")
 	}
 
 	return nil
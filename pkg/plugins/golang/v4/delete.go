@@ -0,0 +1,179 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v4
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin/util"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds"
+)
+
+var (
+	_ plugin.DeleteAPISubcommand     = &deleteAPISubcommand{}
+	_ plugin.DeleteWebhookSubcommand = &deleteWebhookSubcommand{}
+)
+
+type deleteAPISubcommand struct {
+	config config.Config
+	// out is where the messages and the output of the commands are printed
+	out io.Writer
+	// For help text.
+	commandName string
+
+	resource *resource.Resource
+
+	// runMake indicates whether to run make or not after removing APIs
+	runMake bool
+
+	// force indicates whether to remove the files and code modified since they were scaffolded
+	force bool
+}
+
+func (p *deleteAPISubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, subcmdMeta *plugin.SubcommandMetadata) {
+	p.commandName = cliMeta.CommandName
+
+	subcmdMeta.Description = `Remove a Kubernetes API by deleting its Resource definition and Controller, and
+the code that wires them in the project.
+
+The webhooks of the API have to be deleted first. Files and code that were modified
+since they were scaffolded are only removed with --force. After the scaffold is
+removed, make generate will be run.
+`
+	subcmdMeta.Examples = fmt.Sprintf(`  # Delete the frigates API with Group: ship, Version: v1beta1 and Kind: Frigate
+  %[1]s delete api --group ship --version v1beta1 --kind Frigate
+`, cliMeta.CommandName)
+}
+
+func (p *deleteAPISubcommand) BindFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&p.runMake, "make", true, "if true, run `make generate` after removing files")
+	fs.BoolVar(&p.force, "force", false,
+		"remove the files and code even if they were modified since they were scaffolded")
+}
+
+func (p *deleteAPISubcommand) InjectConfig(c config.Config) error {
+	p.config = c
+	return nil
+}
+
+func (p *deleteAPISubcommand) InjectOutput(out io.Writer) error {
+	p.out = out
+	return nil
+}
+
+func (p *deleteAPISubcommand) InjectResource(res *resource.Resource) error {
+	stored, err := plugins.LookupResource(p.config, res.GVK)
+	if err != nil {
+		return fmt.Errorf("%s delete api requires a previously created API: %w", p.commandName, err)
+	}
+	if stored.Webhooks != nil && !stored.Webhooks.IsEmpty() {
+		return fmt.Errorf("the webhooks of the API have to be deleted first with `%s delete webhook`", p.commandName)
+	}
+
+	// Remove what was scaffolded for the resource, not what the flags describe
+	*res = stored
+	p.resource = res
+	return nil
+}
+
+func (p *deleteAPISubcommand) Scaffold(fs machinery.Filesystem) error {
+	scaffolder := scaffolds.NewDeleteAPIScaffolder(p.config, *p.resource, p.force)
+	scaffolder.InjectFS(fs)
+	return scaffolder.Scaffold()
+}
+
+func (p *deleteAPISubcommand) PostScaffold() error {
+	if p.runMake && p.resource.HasAPI() {
+		if err := util.RunCmdWithOutput(p.out, "Running make", "make", "generate"); err != nil {
+			return err
+		}
+	}
+
+	_, _ = fmt.Fprint(p.out, "Next: update the manifests with:\n$ make manifests\n")
+	return nil
+}
+
+type deleteWebhookSubcommand struct {
+	config config.Config
+	// out is where the messages and the output of the commands are printed
+	out io.Writer
+	// For help text.
+	commandName string
+
+	resource *resource.Resource
+
+	// force indicates whether to remove the files and code modified since they were scaffolded
+	force bool
+}
+
+func (p *deleteWebhookSubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, subcmdMeta *plugin.SubcommandMetadata) {
+	p.commandName = cliMeta.CommandName
+
+	subcmdMeta.Description = `Remove the webhooks of an API resource by deleting their implementation and tests,
+and the code that wires them in the project. Files and code that were modified since
+they were scaffolded are only removed with --force.
+`
+	subcmdMeta.Examples = fmt.Sprintf(`  # Delete the webhooks of Group: ship, Version: v1beta1 and Kind: Frigate
+  %[1]s delete webhook --group ship --version v1beta1 --kind Frigate
+`, cliMeta.CommandName)
+}
+
+func (p *deleteWebhookSubcommand) BindFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&p.force, "force", false,
+		"remove the files and code even if they were modified since they were scaffolded")
+}
+
+func (p *deleteWebhookSubcommand) InjectConfig(c config.Config) error {
+	p.config = c
+	return nil
+}
+
+func (p *deleteWebhookSubcommand) InjectOutput(out io.Writer) error {
+	p.out = out
+	return nil
+}
+
+func (p *deleteWebhookSubcommand) InjectResource(res *resource.Resource) error {
+	stored, err := plugins.LookupResource(p.config, res.GVK)
+	if err != nil || stored.Webhooks == nil || stored.Webhooks.IsEmpty() {
+		return fmt.Errorf("%s delete webhook requires a previously created webhook", p.commandName)
+	}
+
+	// Remove what was scaffolded for the resource, not what the flags describe
+	*res = stored
+	p.resource = res
+	return nil
+}
+
+func (p *deleteWebhookSubcommand) Scaffold(fs machinery.Filesystem) error {
+	scaffolder := scaffolds.NewDeleteWebhookScaffolder(p.config, *p.resource, p.force)
+	scaffolder.InjectFS(fs)
+	return scaffolder.Scaffold()
+}
+
+func (p *deleteWebhookSubcommand) PostScaffold() error {
+	_, _ = fmt.Fprint(p.out, "Next: update the manifests with:\n$ make manifests\n")
+	return nil
+}
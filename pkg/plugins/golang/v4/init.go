@@ -18,6 +18,7 @@ package v4
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -44,6 +45,8 @@ var _ plugin.InitSubcommand = &initSubcommand{}
 
 type initSubcommand struct {
 	config config.Config
+	// out is where the messages and the output of the commands are printed
+	out io.Writer
 	// For help text.
 	commandName string
 
@@ -109,6 +112,11 @@ func (p *initSubcommand) InjectConfig(c config.Config) error {
 	return p.config.SetRepository(p.repo)
 }
 
+func (p *initSubcommand) InjectOutput(out io.Writer) error {
+	p.out = out
+	return nil
+}
+
 func (p *initSubcommand) PreScaffold(machinery.Filesystem) error {
 	// Ensure Go version is in the allowed range if check not turned off.
 	if !p.skipGoVersionCheck {
@@ -124,34 +132,28 @@ func (p *initSubcommand) PreScaffold(machinery.Filesystem) error {
 func (p *initSubcommand) Scaffold(fs machinery.Filesystem) error {
 	scaffolder := scaffolds.NewInitScaffolder(p.config, p.license, p.owner)
 	scaffolder.InjectFS(fs)
-	err := scaffolder.Scaffold()
-	if err != nil {
-		return err
-	}
+	return scaffolder.Scaffold()
+}
 
+func (p *initSubcommand) PostScaffold() error {
 	if !p.fetchDeps {
 		log.Println("Skipping fetching dependencies.")
-		return nil
-	}
-
-	// Ensure that we are pinning controller-runtime version
-	// xref: https://github.com/nholuongut/kubebuilder/issues/997
-	err = util.RunCmd("Get controller runtime", "go", "get",
-		"sigs.k8s.io/controller-runtime@"+scaffolds.ControllerRuntimeVersion)
-	if err != nil {
-		return err
+	} else {
+		// Ensure that we are pinning controller-runtime version
+		// xref: https://github.com/nholuongut/kubebuilder/issues/997
+		err := util.RunCmdWithOutput(p.out, "Get controller runtime", "go", "get",
+			"sigs.k8s.io/controller-runtime@"+scaffolds.ControllerRuntimeVersion)
+		if err != nil {
+			return err
+		}
 	}
 
-	return nil
-}
-
-func (p *initSubcommand) PostScaffold() error {
-	err := util.RunCmd("Update dependencies", "go", "mod", "tidy")
+	err := util.RunCmdWithOutput(p.out, "Update dependencies", "go", "mod", "tidy")
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("Next: define a resource with:\n$ %s create api\n", p.commandName)
+	_, _ = fmt.Fprintf(p.out, "Next: define a resource with:\n$ %s create api\n", p.commandName)
 	return nil
 }
 
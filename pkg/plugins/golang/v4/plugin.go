@@ -19,9 +19,11 @@ package v4
 import (
 	"sigs.k8s.io/kubebuilder/v4/pkg/config"
 	cfgv3 "sigs.k8s.io/kubebuilder/v4/pkg/config/v3"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
 	"sigs.k8s.io/kubebuilder/v4/pkg/model/stage"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds"
 )
 
 const pluginName = "base." + golang.DefaultNameQualifier
@@ -29,9 +31,18 @@ const pluginName = "base." + golang.DefaultNameQualifier
 var (
 	pluginVersion            = plugin.Version{Number: 4, Stage: stage.Stable}
 	supportedProjectVersions = []config.Version{cfgv3.Version}
+	// Other versions of the plugin scaffold a different layout for the same files
+	conflictingPlugins = []plugin.Constraint{{Name: pluginName}}
 )
 
-var _ plugin.Full = Plugin{}
+var (
+	_ plugin.Full               = Plugin{}
+	_ plugin.DeleteAPI          = Plugin{}
+	_ plugin.DeleteWebhook      = Plugin{}
+	_ plugin.HasTemplates       = Plugin{}
+	_ plugin.HasRequiredMarkers = Plugin{}
+	_ plugin.HasConflicts       = Plugin{}
+)
 
 // Plugin implements the plugin.Full interface
 type Plugin struct {
@@ -39,6 +50,8 @@ type Plugin struct {
 	createAPISubcommand
 	createWebhookSubcommand
 	editSubcommand
+	deleteAPISubcommand
+	deleteWebhookSubcommand
 }
 
 // Name returns the name of the plugin
@@ -50,6 +63,9 @@ func (Plugin) Version() plugin.Version { return pluginVersion }
 // SupportedProjectVersions returns an array with all project versions supported by the plugin
 func (Plugin) SupportedProjectVersions() []config.Version { return supportedProjectVersions }
 
+// ConflictingPlugins returns the plugins that cannot be used together with this one
+func (Plugin) ConflictingPlugins() []plugin.Constraint { return conflictingPlugins }
+
 // GetInitSubcommand will return the subcommand which is responsible for initializing and common scaffolding
 func (p Plugin) GetInitSubcommand() plugin.InitSubcommand { return &p.initSubcommand }
 
@@ -64,6 +80,22 @@ func (p Plugin) GetCreateWebhookSubcommand() plugin.CreateWebhookSubcommand {
 // GetEditSubcommand will return the subcommand which is responsible for editing the scaffold of the project
 func (p Plugin) GetEditSubcommand() plugin.EditSubcommand { return &p.editSubcommand }
 
+// GetDeleteAPISubcommand will return the subcommand which is responsible for removing apis
+func (p Plugin) GetDeleteAPISubcommand() plugin.DeleteAPISubcommand { return &p.deleteAPISubcommand }
+
+// GetDeleteWebhookSubcommand will return the subcommand which is responsible for removing webhooks
+func (p Plugin) GetDeleteWebhookSubcommand() plugin.DeleteWebhookSubcommand {
+	return &p.deleteWebhookSubcommand
+}
+
+// GetTemplates returns the templates scaffolded by the plugin
+func (Plugin) GetTemplates() []machinery.Template { return scaffolds.Templates() }
+
+// GetRequiredMarkers returns the markers of the files updated by the plugin
+func (Plugin) GetRequiredMarkers(cfg config.Config) ([]machinery.RequiredMarker, error) {
+	return scaffolds.RequiredMarkers(cfg)
+}
+
 func (p Plugin) DeprecationWarning() string {
 	return ""
 }
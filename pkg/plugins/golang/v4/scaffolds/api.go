@@ -92,7 +92,7 @@ func (s *apiScaffolder) Scaffold() error {
 	}
 
 	if doAPI {
-		if err := scaffold.Execute(
+		if _, err := scaffold.Execute(
 			&api.Types{Force: s.force},
 			&api.Group{},
 		); err != nil {
@@ -101,7 +101,7 @@ func (s *apiScaffolder) Scaffold() error {
 	}
 
 	if doController {
-		if err := scaffold.Execute(
+		if _, err := scaffold.Execute(
 			&controllers.SuiteTest{Force: s.force, K8SVersion: EnvtestK8SVersion},
 			&controllers.Controller{ControllerRuntimeVersion: ControllerRuntimeVersion, Force: s.force},
 			&controllers.ControllerTest{Force: s.force, DoAPI: doAPI},
@@ -110,7 +110,7 @@ func (s *apiScaffolder) Scaffold() error {
 		}
 	}
 
-	if err := scaffold.Execute(
+	if _, err := scaffold.Execute(
 		&cmd.MainUpdater{WireResource: doAPI, WireController: doController},
 	); err != nil {
 		return fmt.Errorf("error updating cmd/main.go: %v", err)
@@ -0,0 +1,283 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates/api"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates/cmd"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates/controllers"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates/test/e2e"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates/webhooks"
+)
+
+// deepCopyFileName is the name of the file generated by controller-gen with the deep copy functions of the types
+const deepCopyFileName = "zz_generated.deepcopy.go"
+
+var (
+	_ plugins.Scaffolder = &deleteAPIScaffolder{}
+	_ plugins.Scaffolder = &deleteWebhookScaffolder{}
+)
+
+// deleteAPIScaffolder contains configuration for removing the scaffolding of the Go type representing the API
+// and of the controller that implements the behavior for the API.
+type deleteAPIScaffolder struct {
+	config   config.Config
+	resource resource.Resource
+
+	// force indicates whether to remove the files and code modified since they were scaffolded
+	force bool
+
+	// fs is the filesystem that will be used by the scaffolder
+	fs machinery.Filesystem
+}
+
+// NewDeleteAPIScaffolder returns a new Scaffolder for API/controller deletion operations
+func NewDeleteAPIScaffolder(config config.Config, res resource.Resource, force bool) plugins.Scaffolder {
+	return &deleteAPIScaffolder{
+		config:   config,
+		resource: res,
+		force:    force,
+	}
+}
+
+// InjectFS implements cmdutil.Scaffolder
+func (s *deleteAPIScaffolder) InjectFS(fs machinery.Filesystem) {
+	s.fs = fs
+}
+
+// Scaffold implements cmdutil.Scaffolder
+func (s *deleteAPIScaffolder) Scaffold() error {
+	log.Println("Removing scaffold...")
+
+	if err := removeResource(s.config, s.resource.GVK); err != nil {
+		return err
+	}
+	remaining, err := s.config.GetResources()
+	if err != nil {
+		return fmt.Errorf("error deleting API: %w", err)
+	}
+
+	scaffold := machinery.NewScaffold(s.fs,
+		machinery.WithConfig(s.config),
+		machinery.WithResource(&s.resource),
+	)
+
+	builders := make([]machinery.Builder, 0)
+	if s.resource.HasAPI() {
+		builders = append(builders, machinery.Remove(&api.Types{}, s.force))
+
+		// The files shared by the APIs of the group and version are only removed along with the last of them
+		if !anyResource(remaining, func(r resource.Resource) bool {
+			return r.HasAPI() && r.Group == s.resource.Group && r.Version == s.resource.Version
+		}) {
+			group := &api.Group{}
+			group.MultiGroup = s.config.IsMultiGroup()
+			group.Resource = &s.resource
+			if err := group.SetTemplateDefaults(); err != nil {
+				return fmt.Errorf("error deleting API: %w", err)
+			}
+
+			deepCopy := &machinery.RemoverMixin{Optional: true}
+			deepCopy.Path = filepath.Join(filepath.Dir(group.Path), deepCopyFileName)
+			builders = append(builders, machinery.Remove(group, s.force), deepCopy)
+		} else {
+			log.Println("Run `make generate` to regenerate the deep copy functions of the remaining APIs.")
+		}
+	}
+
+	if s.resource.HasController() {
+		builders = append(builders,
+			machinery.Remove(&controllers.Controller{}, s.force),
+			machinery.Remove(&controllers.ControllerTest{}, s.force),
+		)
+
+		// The suite is shared by the controllers of the group and only removed along with the last of them
+		suitePath := controllers.SuiteTestPath(s.config.IsMultiGroup(), &s.resource)
+		kept := make([]machinery.Builder, 0)
+		for i := range remaining {
+			res := &remaining[i]
+			if res.HasController() && controllers.SuiteTestPath(s.config.IsMultiGroup(), res) == suitePath {
+				kept = append(kept, &controllers.SuiteTest{ResourceMixin: machinery.ResourceMixin{Resource: res}})
+			}
+		}
+		if len(kept) == 0 {
+			builders = append(builders, machinery.Remove(&controllers.SuiteTest{}, s.force))
+		} else {
+			builders = append(builders, machinery.Eject(&controllers.SuiteTest{}, s.force, kept...))
+		}
+	}
+
+	builders = append(builders, machinery.Eject(
+		&cmd.MainUpdater{WireResource: s.resource.HasAPI(), WireController: s.resource.HasController()},
+		s.force,
+		mainUpdaters(remaining)...,
+	))
+
+	if _, err := scaffold.Execute(builders...); err != nil {
+		return fmt.Errorf("error deleting API: %w", err)
+	}
+
+	return nil
+}
+
+// deleteWebhookScaffolder contains configuration for removing the scaffolding of the webhooks of a resource.
+type deleteWebhookScaffolder struct {
+	config   config.Config
+	resource resource.Resource
+
+	// force indicates whether to remove the files and code modified since they were scaffolded
+	force bool
+
+	// fs is the filesystem that will be used by the scaffolder
+	fs machinery.Filesystem
+}
+
+// NewDeleteWebhookScaffolder returns a new Scaffolder for webhook deletion operations
+func NewDeleteWebhookScaffolder(config config.Config, res resource.Resource, force bool) plugins.Scaffolder {
+	return &deleteWebhookScaffolder{
+		config:   config,
+		resource: res,
+		force:    force,
+	}
+}
+
+// InjectFS implements cmdutil.Scaffolder
+func (s *deleteWebhookScaffolder) InjectFS(fs machinery.Filesystem) {
+	s.fs = fs
+}
+
+// Scaffold implements cmdutil.Scaffolder
+func (s *deleteWebhookScaffolder) Scaffold() error {
+	log.Println("Removing scaffold...")
+
+	// Resources that were only tracked because of their webhooks are removed
+	updated := s.resource.Copy()
+	updated.Webhooks = nil
+	if updated.HasAPI() || updated.HasController() {
+		if err := s.config.SetResource(updated); err != nil {
+			return fmt.Errorf("error updating resource: %w", err)
+		}
+	} else if err := removeResource(s.config, s.resource.GVK); err != nil {
+		return err
+	}
+	remaining, err := s.config.GetResources()
+	if err != nil {
+		return fmt.Errorf("error deleting webhook: %w", err)
+	}
+
+	scaffold := machinery.NewScaffold(s.fs,
+		machinery.WithConfig(s.config),
+		machinery.WithResource(&s.resource),
+	)
+
+	// Whether the webhook was scaffolded in the legacy path is not tracked, so both paths are reverted
+	builders := make([]machinery.Builder, 0)
+	for _, isLegacyPath := range []bool{false, true} {
+		builders = append(builders,
+			machinery.Remove(&webhooks.Webhook{IsLegacyPath: isLegacyPath}, s.force),
+			machinery.Remove(&webhooks.WebhookTest{IsLegacyPath: isLegacyPath}, s.force),
+			machinery.Eject(&cmd.MainUpdater{WireWebhook: true, IsLegacyPath: isLegacyPath}, s.force,
+				mainUpdaters(remaining)...),
+		)
+
+		// The suite is shared by the webhooks of the group and version and only removed along with the last of them
+		if s.resource.HasDefaultingWebhook() || s.resource.HasValidationWebhook() {
+			suitePath := webhooks.WebhookSuitePath(s.config.IsMultiGroup(), isLegacyPath, &s.resource)
+			kept := make([]machinery.Builder, 0)
+			for i := range remaining {
+				res := &remaining[i]
+				if (res.HasDefaultingWebhook() || res.HasValidationWebhook()) &&
+					webhooks.WebhookSuitePath(s.config.IsMultiGroup(), isLegacyPath, res) == suitePath {
+					kept = append(kept, &webhooks.WebhookSuite{
+						ResourceMixin: machinery.ResourceMixin{Resource: res},
+						IsLegacyPath:  isLegacyPath,
+					})
+				}
+			}
+			suite := &webhooks.WebhookSuite{IsLegacyPath: isLegacyPath}
+			if len(kept) == 0 {
+				builders = append(builders, machinery.Remove(suite, s.force))
+			} else {
+				builders = append(builders, machinery.Eject(suite, s.force, kept...))
+			}
+		}
+	}
+
+	kept := make([]machinery.Builder, 0)
+	for i := range remaining {
+		if res := &remaining[i]; res.Webhooks != nil && !res.Webhooks.IsEmpty() {
+			kept = append(kept, &e2e.WebhookTestUpdater{
+				ResourceMixin: machinery.ResourceMixin{Resource: res},
+				WireWebhook:   true,
+			})
+		}
+	}
+	builders = append(builders, machinery.Eject(&e2e.WebhookTestUpdater{WireWebhook: true}, s.force, kept...))
+
+	if _, err := scaffold.Execute(builders...); err != nil {
+		return fmt.Errorf("error deleting webhook: %w", err)
+	}
+
+	return nil
+}
+
+// removeResource removes a resource from the configuration, ignoring it if another plugin already removed it
+func removeResource(cfg config.Config, gvk resource.GVK) error {
+	if err := cfg.RemoveResource(gvk); err != nil && !errors.As(err, &config.ResourceNotFoundError{}) {
+		return fmt.Errorf("error removing resource: %w", err)
+	}
+	return nil
+}
+
+// mainUpdaters returns the updaters that wire the resources in cmd/main.go, so that the code shared with them
+// is kept when reverting the wiring of another resource
+func mainUpdaters(resources []resource.Resource) []machinery.Builder {
+	updaters := make([]machinery.Builder, 0, 2*len(resources))
+	for i := range resources {
+		res := &resources[i]
+		for _, isLegacyPath := range []bool{false, true} {
+			updaters = append(updaters, &cmd.MainUpdater{
+				ResourceMixin:  machinery.ResourceMixin{Resource: res},
+				WireResource:   res.HasAPI(),
+				WireController: res.HasController(),
+				WireWebhook:    res.Webhooks != nil && !res.Webhooks.IsEmpty(),
+				IsLegacyPath:   isLegacyPath,
+			})
+		}
+	}
+	return updaters
+}
+
+// anyResource returns true if any of the resources matches
+func anyResource(resources []resource.Resource, matches func(resource.Resource) bool) bool {
+	for _, r := range resources {
+		if matches(r) {
+			return true
+		}
+	}
+	return false
+}
@@ -106,7 +106,7 @@ func (s *initScaffolder) Scaffold() error {
 			Owner:   s.owner,
 		}
 		bpFile.Path = s.boilerplatePath
-		if err := scaffold.Execute(bpFile); err != nil {
+		if _, err := scaffold.Execute(bpFile); err != nil {
 			return err
 		}
 
@@ -141,7 +141,7 @@ func (s *initScaffolder) Scaffold() error {
 		}
 	}
 
-	return scaffold.Execute(
+	_, err := scaffold.Execute(
 		&cmd.Main{
 			ControllerRuntimeVersion: ControllerRuntimeVersion,
 		},
@@ -171,4 +171,5 @@ func (s *initScaffolder) Scaffold() error {
 		&templates.DevContainer{},
 		&templates.DevContainerPostInstallScript{},
 	)
+	return err
 }
@@ -22,6 +22,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates"
 )
 
 var _ machinery.Template = &Group{}
@@ -46,32 +47,11 @@ func (f *Group) SetTemplateDefaults() error {
 
 	f.Path = f.Resource.Replacer().Replace(f.Path)
 	log.Println(f.Path)
-	f.TemplateBody = groupTemplate
+	body, err := machinery.ReadTemplateBody(templates.FS, "api/group.go.tmpl")
+	if err != nil {
+		return err
+	}
+	f.TemplateBody = body
 
 	return nil
 }
-
-//nolint:lll
-const groupTemplate = `{{ .Boilerplate }}
-
-// Package {{ .Resource.Version }} contains API Schema definitions for the {{ .Resource.Group }} {{ .Resource.Version }} API group.
-// +kubebuilder:object:generate=true
-// +groupName={{ .Resource.QualifiedGroup }}
-package {{ .Resource.Version }}
-
-import (
-	"k8s.io/apimachinery/pkg/runtime/schema"
-	"sigs.k8s.io/controller-runtime/pkg/scheme"
-)
-
-var (
-	// GroupVersion is group version used to register these objects.
-	GroupVersion = schema.GroupVersion{Group: "{{ .Resource.QualifiedGroup }}", Version: "{{ .Resource.Version }}"}
-
-	// SchemeBuilder is used to add go types to the GroupVersionKind scheme.
-	SchemeBuilder = &scheme.Builder{GroupVersion: GroupVersion}
-
-	// AddToScheme adds the types in this group-version to the given scheme.
-	AddToScheme = SchemeBuilder.AddToScheme
-)
-`
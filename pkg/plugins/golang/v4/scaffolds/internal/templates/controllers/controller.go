@@ -22,6 +22,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates"
 )
 
 var _ machinery.Template = &Controller{}
@@ -52,74 +53,17 @@ func (f *Controller) SetTemplateDefaults() error {
 	f.Path = f.Resource.Replacer().Replace(f.Path)
 	log.Println(f.Path)
 
-	f.TemplateBody = controllerTemplate
+	body, err := machinery.ReadTemplateBody(templates.FS, "controllers/controller.go.tmpl")
+	if err != nil {
+		return err
+	}
+	f.TemplateBody = body
 
 	if f.Force {
-		f.IfExistsAction = machinery.OverwriteFile
+		f.IfExistsAction = machinery.WriteSidecarIfModified
 	} else {
 		f.IfExistsAction = machinery.Error
 	}
 
 	return nil
 }
-
-//nolint:lll
-const controllerTemplate = `{{ .Boilerplate }}
-
-package {{ if and .MultiGroup .Resource.Group }}{{ .Resource.PackageName }}{{ else }}controller{{ end }}
-
-import (
-	"context"
-	"k8s.io/apimachinery/pkg/runtime"
-	ctrl "sigs.k8s.io/controller-runtime"
-	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/log"
-	{{ if not (isEmptyStr .Resource.Path) -}}
-	{{ .Resource.ImportAlias }} "{{ .Resource.Path }}"
-	{{- end }}
-)
-
-// {{ .Resource.Kind }}Reconciler reconciles a {{ .Resource.Kind }} object
-type {{ .Resource.Kind }}Reconciler struct {
-	client.Client
-	Scheme *runtime.Scheme
-}
-
-// +kubebuilder:rbac:groups={{ .Resource.QualifiedGroup }},resources={{ .Resource.Plural }},verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups={{ .Resource.QualifiedGroup }},resources={{ .Resource.Plural }}/status,verbs=get;update;patch
-// +kubebuilder:rbac:groups={{ .Resource.QualifiedGroup }},resources={{ .Resource.Plural }}/finalizers,verbs=update
-
-// Reconcile is part of the main kubernetes reconciliation loop which aims to
-// move the current state of the cluster closer to the desired state.
-// TODO(user): Modify the Reconcile function to compare the state specified by
-// the {{ .Resource.Kind }} object against the actual cluster state, and then
-// perform operations to make the cluster state reflect the state specified by
-// the user.
-//
-// For more details, check Reconcile and its Result here:
-// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@{{ .ControllerRuntimeVersion }}/pkg/reconcile
-func (r *{{ .Resource.Kind }}Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	_ = log.FromContext(ctx)
-
-	// TODO(user): your logic here
-
-	return ctrl.Result{}, nil
-}
-
-// SetupWithManager sets up the controller with the Manager.
-func (r *{{ .Resource.Kind }}Reconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		{{ if not (isEmptyStr .Resource.Path) -}}
-		For(&{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}{}).
-		{{- else -}}
-		// Uncomment the following line adding a pointer to an instance of the controlled resource as an argument
-		// For().
-		{{- end }}
-		{{- if and (.MultiGroup) (not (isEmptyStr .Resource.Group)) }}
-		Named("{{ lower .Resource.Group }}-{{ lower .Resource.Kind }}").
-		{{- else }}
-		Named("{{ lower .Resource.Kind }}").
-		{{- end }}
-		Complete(r)
-}
-`
@@ -23,6 +23,8 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates"
 )
 
 var _ machinery.Template = &SuiteTest{}
@@ -43,26 +45,30 @@ type SuiteTest struct {
 	// CRDDirectoryRelativePath define the Path for the CRD
 	CRDDirectoryRelativePath string
 
+	// ImportsMarker and SchemeMarker are the markers where the SuiteTest inserts its code
+	ImportsMarker machinery.Marker
+	SchemeMarker  machinery.Marker
+
 	Force bool
 }
 
 // SetTemplateDefaults implements file.Template
 func (f *SuiteTest) SetTemplateDefaults() error {
 	if f.Path == "" {
-		if f.MultiGroup && f.Resource.Group != "" {
-			f.Path = filepath.Join("internal", "controller", "%[group]", "suite_test.go")
-		} else {
-			f.Path = filepath.Join("internal", "controller", "suite_test.go")
-		}
+		f.Path = SuiteTestPath(f.MultiGroup, f.Resource)
 	}
 
 	f.Path = f.Resource.Replacer().Replace(f.Path)
 	log.Println(f.Path)
 
-	f.TemplateBody = fmt.Sprintf(controllerSuiteTestTemplate,
-		machinery.NewMarkerFor(f.Path, importMarker),
-		machinery.NewMarkerFor(f.Path, addSchemeMarker),
-	)
+	body, err := machinery.ReadTemplateBody(templates.FS, "controllers/controller_suitetest.go.tmpl")
+	if err != nil {
+		return err
+	}
+	f.TemplateBody = body
+
+	f.ImportsMarker = machinery.NewMarkerFor(f.Path, importMarker)
+	f.SchemeMarker = machinery.NewMarkerFor(f.Path, addSchemeMarker)
 
 	// If is multigroup the path needs to be ../../ since it has
 	// the group dir.
@@ -72,12 +78,21 @@ func (f *SuiteTest) SetTemplateDefaults() error {
 	}
 
 	if f.Force {
-		f.IfExistsAction = machinery.OverwriteFile
+		f.IfExistsAction = machinery.WriteSidecarIfModified
 	}
 
 	return nil
 }
 
+// SuiteTestPath returns the path of the file that sets up the controller tests of the group of a resource
+func SuiteTestPath(multiGroup bool, res *resource.Resource) string {
+	path := filepath.Join("internal", "controller", "suite_test.go")
+	if multiGroup && res.Group != "" {
+		path = filepath.Join("internal", "controller", "%[group]", "suite_test.go")
+	}
+	return res.Replacer().Replace(path)
+}
+
 const (
 	importMarker    = "imports"
 	addSchemeMarker = "scheme"
@@ -126,86 +141,3 @@ func (f *SuiteTest) GetCodeFragments() machinery.CodeFragmentsMap {
 
 	return fragments
 }
-
-const controllerSuiteTestTemplate = `{{ .Boilerplate }}
-
-{{if and .MultiGroup .Resource.Group }}
-package {{ .Resource.PackageName }}
-{{else}}
-package controller
-{{end}}
-
-import (
-	"context"
-	"fmt"
-	"path/filepath"
-	"runtime"
-	"testing"
-
-    . "github.com/onsi/ginkgo/v2"
-    . "github.com/onsi/gomega"
-
-	"k8s.io/client-go/kubernetes/scheme"
-	"k8s.io/client-go/rest"
-	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/envtest"
-	logf "sigs.k8s.io/controller-runtime/pkg/log"
-	"sigs.k8s.io/controller-runtime/pkg/log/zap"
-	%s
-)
-
-// These tests use Ginkgo (BDD-style Go testing framework). Refer to
-// http://onsi.github.io/ginkgo/ to learn more about Ginkgo.
-
-var cfg *rest.Config
-var k8sClient client.Client
-var testEnv *envtest.Environment
-var ctx context.Context
-var cancel context.CancelFunc
-
-func TestControllers(t *testing.T) {
-	RegisterFailHandler(Fail)
-
-	RunSpecs(t, "Controller Suite")
-}
-
-var _ = BeforeSuite(func() {
-	logf.SetLogger(zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true)))
-
-	ctx, cancel = context.WithCancel(context.TODO())
-
-	By("bootstrapping test environment")
-	testEnv = &envtest.Environment{
-		CRDDirectoryPaths:     []string{filepath.Join({{ .CRDDirectoryRelativePath }}, "config", "crd", "bases")},
-		ErrorIfCRDPathMissing: {{ .Resource.HasAPI }},
-
-		// The BinaryAssetsDirectory is only required if you want to run the tests directly
-		// without call the makefile target test. If not informed it will look for the
-		// default path defined in controller-runtime which is /usr/local/kubebuilder/.
-		// Note that you must have the required binaries setup under the bin directory to perform
-		// the tests directly. When we run make test it will be setup and used automatically.
-		BinaryAssetsDirectory: filepath.Join({{ .CRDDirectoryRelativePath }}, "bin", "k8s",
-			fmt.Sprintf("{{ .K8SVersion }}-%%s-%%s", runtime.GOOS, runtime.GOARCH)),
-	}
-
-	var err error
-	// cfg is defined in this file globally.
-	cfg, err = testEnv.Start()
-	Expect(err).NotTo(HaveOccurred())
-	Expect(cfg).NotTo(BeNil())
-
-	%s
-
-	k8sClient, err = client.New(cfg, client.Options{Scheme: scheme.Scheme})
-	Expect(err).NotTo(HaveOccurred())
-	Expect(k8sClient).NotTo(BeNil())
-
-})
-
-var _ = AfterSuite(func() {
-	By("tearing down the test environment")
-	cancel()
-	err := testEnv.Stop()
-	Expect(err).NotTo(HaveOccurred())
-})
-`
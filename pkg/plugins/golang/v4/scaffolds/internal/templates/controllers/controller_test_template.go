@@ -22,6 +22,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates"
 )
 
 var _ machinery.Template = &ControllerTest{}
@@ -52,96 +53,15 @@ func (f *ControllerTest) SetTemplateDefaults() error {
 	f.Path = f.Resource.Replacer().Replace(f.Path)
 	log.Println(f.Path)
 
-	f.TemplateBody = controllerTestTemplate
+	body, err := machinery.ReadTemplateBody(templates.FS, "controllers/controller_test_template.go.tmpl")
+	if err != nil {
+		return err
+	}
+	f.TemplateBody = body
 
 	if f.Force {
-		f.IfExistsAction = machinery.OverwriteFile
+		f.IfExistsAction = machinery.WriteSidecarIfModified
 	}
 
 	return nil
 }
-
-const controllerTestTemplate = `{{ .Boilerplate }}
-
-{{if and .MultiGroup .Resource.Group }}
-package {{ .Resource.PackageName }}
-{{else}}
-package controller
-{{end}}
-
-import (
-	{{ if .DoAPI -}}
-	"context"
-	{{- end }}
-	. "github.com/onsi/ginkgo/v2"
-	{{ if .DoAPI -}}
-
-	. "github.com/onsi/gomega"
-	"k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/types"
-	"sigs.k8s.io/controller-runtime/pkg/reconcile"
-
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	{{ if not (isEmptyStr .Resource.Path) -}}
-	{{ .Resource.ImportAlias }} "{{ .Resource.Path }}"
-	{{- end }}
-	{{- end }}
-)
-
-var _ = Describe("{{ .Resource.Kind }} Controller", func() {
-	Context("When reconciling a resource", func() {
-		{{ if .DoAPI -}}
-		const resourceName = "test-resource"
-
-		ctx := context.Background()
-
-		typeNamespacedName := types.NamespacedName{
-			Name:      resourceName,
-			Namespace: "default",  // TODO(user):Modify as needed
-		}
-		{{ lower .Resource.Kind }} := &{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}{}
-
-		BeforeEach(func() {
-			By("creating the custom resource for the Kind {{ .Resource.Kind }}")
-			err := k8sClient.Get(ctx, typeNamespacedName, {{ lower .Resource.Kind }})
-			if err != nil && errors.IsNotFound(err) {
-				resource := &{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      resourceName,
-						Namespace: "default",
-					},
-					// TODO(user): Specify other spec details if needed.
-				}
-				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
-			}
-		})
-
-		AfterEach(func() {
-			// TODO(user): Cleanup logic after each test, like removing the resource instance.
-			resource := &{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}{}
-			err := k8sClient.Get(ctx, typeNamespacedName, resource)
-			Expect(err).NotTo(HaveOccurred())
-
-			By("Cleanup the specific resource instance {{ .Resource.Kind }}")
-			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
-		})
-		{{- end }}
-		It("should successfully reconcile the resource", func() {
-			{{ if .DoAPI -}}
-			By("Reconciling the created resource")
-			controllerReconciler := &{{ .Resource.Kind }}Reconciler{
-				Client: k8sClient,
-				Scheme: k8sClient.Scheme(),
-			}
-
-			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
-				NamespacedName: typeNamespacedName,
-			})
-			Expect(err).NotTo(HaveOccurred())
-			{{- end }}
-			// TODO(user): Add more specific assertions depending on your controller's reconciliation logic.
-			// Example: If you expect a certain status condition after reconciliation, verify it here.
-		})
-	})
-})
-`
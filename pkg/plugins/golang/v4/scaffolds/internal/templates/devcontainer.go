@@ -20,58 +20,6 @@ import (
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
 )
 
-const devContainerTemplate = `{
-  "name": "Kubebuilder DevContainer",
-  "image": "golang:1.22",
-  "features": {
-    "ghcr.io/devcontainers/features/docker-in-docker:2": {},
-    "ghcr.io/devcontainers/features/git:1": {}
-  },
-
-  "runArgs": ["--network=host"],
-
-  "customizations": {
-    "vscode": {
-      "settings": {
-        "terminal.integrated.shell.linux": "/bin/bash"
-      },
-      "extensions": [
-        "ms-kubernetes-tools.vscode-kubernetes-tools",
-        "ms-azuretools.vscode-docker"
-      ]
-    }
-  },
-
-  "onCreateCommand": "bash .devcontainer/post-install.sh"
-}
-
-`
-
-const postInstallScript = `#!/bin/bash
-set -x
-
-curl -Lo ./kind https://kind.sigs.k8s.io/dl/latest/kind-linux-amd64
-chmod +x ./kind
-mv ./kind /usr/local/bin/kind
-
-curl -L -o kubebuilder https://go.kubebuilder.io/dl/latest/linux/amd64
-chmod +x kubebuilder
-mv kubebuilder /usr/local/bin/
-
-KUBECTL_VERSION=$(curl -L -s https://dl.k8s.io/release/stable.txt)
-curl -LO "https://dl.k8s.io/release/$KUBECTL_VERSION/bin/linux/amd64/kubectl"
-chmod +x kubectl
-mv kubectl /usr/local/bin/kubectl
-
-docker network create -d=bridge --subnet=172.19.0.0/24 kind
-
-kind version
-kubebuilder version
-docker --version
-go version
-kubectl version --client
-`
-
 var _ machinery.Template = &DevContainer{}
 var _ machinery.Template = &DevContainerPostInstallScript{}
 
@@ -90,7 +38,11 @@ func (f *DevContainer) SetTemplateDefaults() error {
 		f.Path = ".devcontainer/devcontainer.json"
 	}
 
-	f.TemplateBody = devContainerTemplate
+	body, err := machinery.ReadTemplateBody(FS, "devcontainer.json.tmpl")
+	if err != nil {
+		return err
+	}
+	f.TemplateBody = body
 
 	return nil
 }
@@ -100,7 +52,11 @@ func (f *DevContainerPostInstallScript) SetTemplateDefaults() error {
 		f.Path = ".devcontainer/post-install.sh"
 	}
 
-	f.TemplateBody = postInstallScript
+	body, err := machinery.ReadTemplateBody(FS, "post-install.sh.tmpl")
+	if err != nil {
+		return err
+	}
+	f.TemplateBody = body
 
 	return nil
 }
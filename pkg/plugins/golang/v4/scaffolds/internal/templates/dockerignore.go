@@ -33,12 +33,11 @@ func (f *DockerIgnore) SetTemplateDefaults() error {
 		f.Path = ".dockerignore"
 	}
 
-	f.TemplateBody = dockerignorefileTemplate
+	body, err := machinery.ReadTemplateBody(FS, "dockerignore.tmpl")
+	if err != nil {
+		return err
+	}
+	f.TemplateBody = body
 
 	return nil
 }
-
-const dockerignorefileTemplate = `# More info: https://docs.docker.com/engine/reference/builder/#dockerignore-file
-# Ignore build and test binaries.
-bin/
-`
@@ -20,6 +20,7 @@ import (
 	"path/filepath"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates"
 )
 
 var _ machinery.Template = &TestCi{}
@@ -36,32 +37,11 @@ func (f *LintCi) SetTemplateDefaults() error {
 		f.Path = filepath.Join(".github", "workflows", "lint.yml")
 	}
 
-	f.TemplateBody = lintCiTemplate
+	body, err := machinery.ReadTemplateBody(templates.FS, "github/lint.yml.tmpl")
+	if err != nil {
+		return err
+	}
+	f.TemplateBody = body
 
 	return nil
 }
-
-const lintCiTemplate = `name: Lint
-
-on:
-  push:
-  pull_request:
-
-jobs:
-  lint:
-    name: Run on Ubuntu
-    runs-on: ubuntu-latest
-    steps:
-      - name: Clone the code
-        uses: actions/checkout@v4
-
-      - name: Setup Go
-        uses: actions/setup-go@v5
-        with:
-          go-version: '~1.22'
-
-      - name: Run linter
-        uses: golangci/golangci-lint-action@v6
-        with:
-          version: v1.61
-`
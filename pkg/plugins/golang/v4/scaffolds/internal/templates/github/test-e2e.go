@@ -20,6 +20,7 @@ import (
 	"path/filepath"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates"
 )
 
 var _ machinery.Template = &E2eTestCi{}
@@ -36,44 +37,11 @@ func (f *E2eTestCi) SetTemplateDefaults() error {
 		f.Path = filepath.Join(".github", "workflows", "test-e2e.yml")
 	}
 
-	f.TemplateBody = e2eTestCiTemplate
+	body, err := machinery.ReadTemplateBody(templates.FS, "github/test-e2e.yml.tmpl")
+	if err != nil {
+		return err
+	}
+	f.TemplateBody = body
 
 	return nil
 }
-
-const e2eTestCiTemplate = `name: E2E Tests
-
-on:
-  push:
-  pull_request:
-
-jobs:
-  test-e2e:
-    name: Run on Ubuntu
-    runs-on: ubuntu-latest
-    steps:
-      - name: Clone the code
-        uses: actions/checkout@v4
-
-      - name: Setup Go
-        uses: actions/setup-go@v5
-        with:
-          go-version: '~1.22'
-
-      - name: Install the latest version of kind
-        run: |
-          curl -Lo ./kind https://kind.sigs.k8s.io/dl/latest/kind-linux-amd64
-          chmod +x ./kind
-          sudo mv ./kind /usr/local/bin/kind
-
-      - name: Verify kind installation
-        run: kind version
-
-      - name: Create kind cluster
-        run: kind create cluster
-
-      - name: Running Test e2e
-        run: |
-          go mod tidy
-          make test-e2e
-`
@@ -20,6 +20,7 @@ import (
 	"path/filepath"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates"
 )
 
 var _ machinery.Template = &TestCi{}
@@ -36,32 +37,11 @@ func (f *TestCi) SetTemplateDefaults() error {
 		f.Path = filepath.Join(".github", "workflows", "test.yml")
 	}
 
-	f.TemplateBody = testCiTemplate
+	body, err := machinery.ReadTemplateBody(templates.FS, "github/test.yml.tmpl")
+	if err != nil {
+		return err
+	}
+	f.TemplateBody = body
 
 	return nil
 }
-
-const testCiTemplate = `name: Tests
-
-on:
-  push:
-  pull_request:
-
-jobs:
-  test:
-    name: Run on Ubuntu
-    runs-on: ubuntu-latest
-    steps:
-      - name: Clone the code
-        uses: actions/checkout@v4
-
-      - name: Setup Go
-        uses: actions/setup-go@v5
-        with:
-          go-version: '~1.22'
-
-      - name: Running Tests
-        run: |
-          go mod tidy
-          make test
-`
@@ -33,36 +33,11 @@ func (f *GitIgnore) SetTemplateDefaults() error {
 		f.Path = ".gitignore"
 	}
 
-	f.TemplateBody = gitignoreTemplate
+	body, err := machinery.ReadTemplateBody(FS, "gitignore.tmpl")
+	if err != nil {
+		return err
+	}
+	f.TemplateBody = body
 
 	return nil
 }
-
-const gitignoreTemplate = `# Binaries for programs and plugins
-*.exe
-*.exe~
-*.dll
-*.so
-*.dylib
-bin/*
-Dockerfile.cross
-
-# Test binary, built with ` + "`go test -c`" + `
-*.test
-
-# Output of the go coverage tool, specifically when used with LiteIDE
-*.out
-
-# Go workspace file
-go.work
-
-# Kubernetes Generated files - skip generated files, except for vendored files
-!vendor/**/zz_generated.*
-
-# editor and IDE paraphernalia
-.idea
-.vscode
-*.swp
-*.swo
-*~
-`
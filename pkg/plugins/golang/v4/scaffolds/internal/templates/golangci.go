@@ -34,59 +34,13 @@ func (f *Golangci) SetTemplateDefaults() error {
 		f.Path = ".golangci.yml"
 	}
 
-	f.TemplateBody = golangciTemplate
+	body, err := machinery.ReadTemplateBody(FS, "golangci.yml.tmpl")
+	if err != nil {
+		return err
+	}
+	f.TemplateBody = body
 
 	f.IfExistsAction = machinery.SkipFile
 
 	return nil
 }
-
-//nolint:lll
-const golangciTemplate = `run:
-  timeout: 5m
-  allow-parallel-runners: true
-
-issues:
-  # don't skip warning about doc comments
-  # don't exclude the default set of lint
-  exclude-use-default: false
-  # restore some of the defaults
-  # (fill in the rest as needed)
-  exclude-rules:
-    - path: "api/*"
-      linters:
-        - lll
-    - path: "internal/*"
-      linters:
-        - dupl
-        - lll
-linters:
-  disable-all: true
-  enable:
-    - dupl
-    - errcheck
-    - copyloopvar
-    - ginkgolinter
-    - goconst
-    - gocyclo
-    - gofmt
-    - goimports
-    - gosimple
-    - govet
-    - ineffassign
-    - lll
-    - misspell
-    - nakedret
-    - prealloc
-    - revive
-    - staticcheck
-    - typecheck
-    - unconvert
-    - unparam
-    - unused
-
-linters-settings:
-  revive:
-    rules:
-      - name: comment-spacings
-`
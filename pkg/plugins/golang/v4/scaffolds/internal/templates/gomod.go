@@ -36,18 +36,13 @@ func (f *GoMod) SetTemplateDefaults() error {
 		f.Path = "go.mod"
 	}
 
-	f.TemplateBody = goModTemplate
+	body, err := machinery.ReadTemplateBody(FS, "go.mod.tmpl")
+	if err != nil {
+		return err
+	}
+	f.TemplateBody = body
 
 	f.IfExistsAction = machinery.OverwriteFile
 
 	return nil
 }
-
-const goModTemplate = `module {{ .Repo }}
-
-go 1.22.0
-
-require (
-	sigs.k8s.io/controller-runtime {{ .ControllerRuntimeVersion }}
-)
-`
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates"
 )
 
 // DefaultBoilerplatePath is the default path to the boilerplate file
@@ -92,19 +93,15 @@ func (f *Boilerplate) SetTemplateDefaults() error {
 		return nil
 	}
 
-	f.TemplateBody = boilerplateTemplate
+	body, err := machinery.ReadTemplateBody(templates.FS, "hack/boilerplate.go.txt.tmpl")
+	if err != nil {
+		return err
+	}
+	f.TemplateBody = body
 
 	return nil
 }
 
-const boilerplateTemplate = `/*
-{{ if .Owner -}}
-Copyright {{ .Year }} {{ .Owner }}.
-{{- else -}}
-Copyright {{ .Year }}.
-{{- end }}
-{{ index .Licenses .License }}*/`
-
 var knownLicenses = map[string]string{
 	"apache2":   apache2,
 	"copyright": "",
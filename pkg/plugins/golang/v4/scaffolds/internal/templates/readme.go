@@ -17,7 +17,6 @@ limitations under the License.
 package templates
 
 import (
-	"fmt"
 	"strings"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
@@ -44,107 +43,11 @@ func (f *Readme) SetTemplateDefaults() error {
 		strings.Replace(f.Boilerplate, "/*", "", 1),
 		"*/", "", 1)
 
-	f.TemplateBody = fmt.Sprintf(readmeFileTemplate,
-		codeFence("make docker-build docker-push IMG=<some-registry>/{{ .ProjectName }}:tag"),
-		codeFence("make install"),
-		codeFence("make deploy IMG=<some-registry>/{{ .ProjectName }}:tag"),
-		codeFence("kubectl apply -k config/samples/"),
-		codeFence("kubectl delete -k config/samples/"),
-		codeFence("make uninstall"),
-		codeFence("make undeploy"),
-		codeFence("make build-installer IMG=<some-registry>/{{ .ProjectName }}:tag"),
-		codeFence("kubectl apply -f https://raw.githubusercontent.com/<org>/{{ .ProjectName }}/"+
-			"<tag or branch>/dist/install.yaml"),
-	)
+	body, err := machinery.ReadTemplateBody(FS, "readme.md.tmpl")
+	if err != nil {
+		return err
+	}
+	f.TemplateBody = body
 
 	return nil
 }
-
-//nolint:lll
-const readmeFileTemplate = `# {{ .ProjectName }}
-// TODO(user): Add simple overview of use/purpose
-
-## Description
-// TODO(user): An in-depth paragraph about your project and overview of use
-
-## Getting Started
-
-### Prerequisites
-- go version v1.22.0+
-- docker version 17.03+.
-- kubectl version v1.11.3+.
-- Access to a Kubernetes v1.11.3+ cluster.
-
-### To Deploy on the cluster
-**Build and push your image to the location specified by ` + "`IMG`" + `:**
-
-%s
-
-**NOTE:** This image ought to be published in the personal registry you specified.
-And it is required to have access to pull the image from the working environment.
-Make sure you have the proper permission to the registry if the above commands don’t work.
-
-**Install the CRDs into the cluster:**
-
-%s
-
-**Deploy the Manager to the cluster with the image specified by ` + "`IMG`" + `:**
-
-%s
-
-> **NOTE**: If you encounter RBAC errors, you may need to grant yourself cluster-admin
-privileges or be logged in as admin.
-
-**Create instances of your solution**
-You can apply the samples (examples) from the config/sample:
-
-%s
-
->**NOTE**: Ensure that the samples has default values to test it out.
-
-### To Uninstall
-**Delete the instances (CRs) from the cluster:**
-
-%s
-
-**Delete the APIs(CRDs) from the cluster:**
-
-%s
-
-**UnDeploy the controller from the cluster:**
-
-%s
-
-## Project Distribution
-
-Following are the steps to build the installer and distribute this project to users.
-
-1. Build the installer for the image built and published in the registry:
-
-%s
-
-NOTE: The makefile target mentioned above generates an 'install.yaml'
-file in the dist directory. This file contains all the resources built
-with Kustomize, which are necessary to install this project without
-its dependencies.
-
-2. Using the installer
-
-Users can just run kubectl apply -f <URL for YAML BUNDLE> to install the project, i.e.:
-
-%s
-
-## Contributing
-// TODO(user): Add detailed information on how you would like others to contribute to this project
-
-**NOTE:** Run ` + "`make help`" + ` for more information on all potential ` + "`make`" + ` targets
-
-More information can be found via the [Kubebuilder Documentation](https://book.kubebuilder.io/introduction.html)
-
-## License
-{{ .License }}
-`
-
-func codeFence(code string) string {
-	return "```sh" + "\n" + code + "\n" + "```"
-}
@@ -23,6 +23,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates"
 )
 
 var _ machinery.Template = &Webhook{}
@@ -69,7 +70,10 @@ func (f *Webhook) SetTemplateDefaults() error {
 	f.Path = f.Resource.Replacer().Replace(f.Path)
 	log.Println(f.Path)
 
-	webhookTemplate := webhookTemplate
+	webhookTemplate, err := machinery.ReadTemplateBody(templates.FS, "webhooks/webhook.go.tmpl")
+	if err != nil {
+		return err
+	}
 	if f.Resource.HasDefaultingWebhook() {
 		webhookTemplate = webhookTemplate + defaultingWebhookTemplate
 	}
@@ -79,7 +83,7 @@ func (f *Webhook) SetTemplateDefaults() error {
 	f.TemplateBody = webhookTemplate
 
 	if f.Force {
-		f.IfExistsAction = machinery.OverwriteFile
+		f.IfExistsAction = machinery.WriteSidecarIfModified
 	} else {
 		f.IfExistsAction = machinery.Error
 	}
@@ -91,71 +95,6 @@ func (f *Webhook) SetTemplateDefaults() error {
 }
 
 const (
-	webhookTemplate = `{{ .Boilerplate }}
-
-package {{ .Resource.Version }}
-
-import (
-	{{- if or .Resource.HasValidationWebhook .Resource.HasDefaultingWebhook }}
-	"context"
-	"fmt"
-	{{- end }}
-
-	ctrl "sigs.k8s.io/controller-runtime"
-	logf "sigs.k8s.io/controller-runtime/pkg/log"
-	{{- if or .Resource.HasValidationWebhook .Resource.HasDefaultingWebhook }}
-	"k8s.io/apimachinery/pkg/runtime"
-	"sigs.k8s.io/controller-runtime/pkg/webhook"
-	{{- end }}
-	{{- if .Resource.HasValidationWebhook }}
-	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
-	{{- end }}
-	{{ if not .IsLegacyPath -}}
-	{{ if not (isEmptyStr .Resource.Path) -}}
-	{{ .Resource.ImportAlias }} "{{ .Resource.Path }}"
-	{{- end }}
-	{{- end }}
-)
-
-// nolint:unused
-// log is for logging in this package.
-var {{ lower .Resource.Kind }}log = logf.Log.WithName("{{ lower .Resource.Kind }}-resource")
-
-{{- if .IsLegacyPath -}}
-// SetupWebhookWithManager will setup the manager to manage the webhooks.
-func (r *{{ .Resource.Kind }}) SetupWebhookWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewWebhookManagedBy(mgr).
-		For(r).
-		{{- if .Resource.HasValidationWebhook }}
-		WithValidator(&{{ .Resource.Kind }}CustomValidator{}).
-		{{- end }}
-		{{- if .Resource.HasDefaultingWebhook }}
-		WithDefaulter(&{{ .Resource.Kind }}CustomDefaulter{}).
-		{{- end }}
-		Complete()
-}
-{{- else }}
-// Setup{{ .Resource.Kind }}WebhookWithManager registers the webhook for {{ .Resource.Kind }} in the manager.
-func Setup{{ .Resource.Kind }}WebhookWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewWebhookManagedBy(mgr).
-		{{- if not (isEmptyStr .Resource.ImportAlias) -}}
-		For(&{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}{}).
-		{{- else -}}
-		For(&{{ .Resource.Kind }}{}).
-		{{- end }}
-		{{- if .Resource.HasValidationWebhook }}
-		WithValidator(&{{ .Resource.Kind }}CustomValidator{}).
-		{{- end }}
-		{{- if .Resource.HasDefaultingWebhook }}
-		WithDefaulter(&{{ .Resource.Kind }}CustomDefaulter{}).
-		{{- end }}
-		Complete()
-}
-{{- end }}
-
-// TODO(user): EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
-`
-
 	//nolint:lll
 	defaultingWebhookTemplate = `
 // +kubebuilder:webhook:{{ if ne .Resource.Webhooks.WebhookVersion "v1" }}webhookVersions={{"{"}}{{ .Resource.Webhooks.WebhookVersion }}{{"}"}},{{ end }}path=/mutate-{{ if .Resource.Core }}-{{ .Resource.Version }}-{{ lower .Resource.Kind }}{{ else }}{{ .QualifiedGroupWithDash }}-{{ .Resource.Version }}-{{ lower .Resource.Kind }}{{ end }},mutating=true,failurePolicy=fail,sideEffects=None,groups={{ if .Resource.Core }}""{{ else }}{{ .Resource.QualifiedGroup }}{{ end }},resources={{ .Resource.Plural }},verbs=create;update,versions={{ .Resource.Version }},name=m{{ lower .Resource.Kind }}-{{ .Resource.Version }}.kb.io,admissionReviewVersions={{ .AdmissionReviewVersions }}
@@ -23,6 +23,8 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates"
 )
 
 var _ machinery.Template = &WebhookSuite{}
@@ -45,6 +47,14 @@ type WebhookSuite struct { //nolint:maligned
 	// BaseDirectoryRelativePath define the Path for the base directory when it is multigroup
 	BaseDirectoryRelativePath string
 
+	// AdmissionImportAlias is the alias of the import of the admission API
+	AdmissionImportAlias string
+
+	// ImportsMarker, SchemeMarker and WebhookMarker are the markers where the WebhookSuite inserts its code
+	ImportsMarker machinery.Marker
+	SchemeMarker  machinery.Marker
+	WebhookMarker machinery.Marker
+
 	// Deprecated - The flag should be removed from go/v5
 	// IsLegacyPath indicates if webhooks should be scaffolded under the API.
 	// Webhooks are now decoupled from APIs based on controller-runtime updates and community feedback.
@@ -55,42 +65,26 @@ type WebhookSuite struct { //nolint:maligned
 // SetTemplateDefaults implements file.Template
 func (f *WebhookSuite) SetTemplateDefaults() error {
 	if f.Path == "" {
-		// Deprecated: Remove me when remove go/v4
-		// nolint:goconst
-		baseDir := "api"
-		if !f.IsLegacyPath {
-			baseDir = filepath.Join("internal", "webhook")
-		}
-
-		if f.MultiGroup && f.Resource.Group != "" {
-			f.Path = filepath.Join(baseDir, "%[group]", "%[version]", "webhook_suite_test.go")
-		} else {
-			f.Path = filepath.Join(baseDir, "%[version]", "webhook_suite_test.go")
-		}
+		f.Path = WebhookSuitePath(f.MultiGroup, f.IsLegacyPath, f.Resource)
 	}
 
 	f.Path = f.Resource.Replacer().Replace(f.Path)
 	log.Println(f.Path)
 
+	name := "webhooks/webhook_suitetest.go.tmpl"
 	if f.IsLegacyPath {
-		f.TemplateBody = fmt.Sprintf(webhookTestSuiteTemplateLegacy,
-			machinery.NewMarkerFor(f.Path, importMarker),
-			admissionImportAlias,
-			machinery.NewMarkerFor(f.Path, addSchemeMarker),
-			machinery.NewMarkerFor(f.Path, addWebhookManagerMarker),
-			"%s",
-			"%d",
-		)
-	} else {
-		f.TemplateBody = fmt.Sprintf(webhookTestSuiteTemplate,
-			machinery.NewMarkerFor(f.Path, importMarker),
-			f.Resource.ImportAlias(), admissionImportAlias,
-			machinery.NewMarkerFor(f.Path, addSchemeMarker),
-			machinery.NewMarkerFor(f.Path, addWebhookManagerMarker),
-			"%s",
-			"%d",
-		)
+		name = "webhooks/webhook_suitetest_legacy.go.tmpl"
+	}
+	body, err := machinery.ReadTemplateBody(templates.FS, name)
+	if err != nil {
+		return err
 	}
+	f.TemplateBody = body
+
+	f.AdmissionImportAlias = admissionImportAlias
+	f.ImportsMarker = machinery.NewMarkerFor(f.Path, importMarker)
+	f.SchemeMarker = machinery.NewMarkerFor(f.Path, addSchemeMarker)
+	f.WebhookMarker = machinery.NewMarkerFor(f.Path, addWebhookManagerMarker)
 
 	if f.IsLegacyPath {
 		// If is multigroup the path needs to be ../../../ since it has the group dir.
@@ -109,6 +103,22 @@ func (f *WebhookSuite) SetTemplateDefaults() error {
 	return nil
 }
 
+// WebhookSuitePath returns the path of the file that sets up the webhook tests of the group and version of a resource
+func WebhookSuitePath(multiGroup, isLegacyPath bool, res *resource.Resource) string {
+	// Deprecated: Remove me when remove go/v4
+	// nolint:goconst
+	baseDir := "api"
+	if !isLegacyPath {
+		baseDir = filepath.Join("internal", "webhook")
+	}
+
+	path := filepath.Join(baseDir, "%[version]", "webhook_suite_test.go")
+	if multiGroup && res.Group != "" {
+		path = filepath.Join(baseDir, "%[group]", "%[version]", "webhook_suite_test.go")
+	}
+	return res.Replacer().Replace(path)
+}
+
 const (
 	admissionImportAlias    = "admissionv1"
 	admissionPath           = "k8s.io/api/admission/v1"
@@ -178,265 +188,3 @@ func (f *WebhookSuite) GetCodeFragments() machinery.CodeFragmentsMap {
 
 	return fragments
 }
-
-const webhookTestSuiteTemplate = `{{ .Boilerplate }}
-
-package {{ .Resource.Version }}
-
-import (
-	"context"
-	"crypto/tls"
-	"fmt"
-	"net"
-	"path/filepath"
-	"testing"
-	"time"
-	"runtime"
-
-    . "github.com/onsi/ginkgo/v2"
-    . "github.com/onsi/gomega"
-	%s
-	"k8s.io/client-go/kubernetes/scheme"
-	"k8s.io/client-go/rest"
-	apimachineryruntime "k8s.io/apimachinery/pkg/runtime"
-	ctrl "sigs.k8s.io/controller-runtime"
-	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/envtest"
-	logf "sigs.k8s.io/controller-runtime/pkg/log"
-	"sigs.k8s.io/controller-runtime/pkg/log/zap"
-	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
-)
-
-// These tests use Ginkgo (BDD-style Go testing framework). Refer to
-// http://onsi.github.io/ginkgo/ to learn more about Ginkgo.
-
-var (
-	cancel context.CancelFunc
-	cfg *rest.Config
-	ctx context.Context
-	k8sClient client.Client
-	testEnv *envtest.Environment
-)
-
-func TestAPIs(t *testing.T) {
-	RegisterFailHandler(Fail)
-
-	RunSpecs(t, "Webhook Suite")
-}
-
-var _ = BeforeSuite(func() {
-	logf.SetLogger(zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true)))
-
-	ctx, cancel = context.WithCancel(context.TODO())
-
-	By("bootstrapping test environment")
-	testEnv = &envtest.Environment{
-		CRDDirectoryPaths:     []string{filepath.Join({{ .BaseDirectoryRelativePath }}, "config", "crd", "bases")},
-		ErrorIfCRDPathMissing: {{ .WireResource }},
-
-		// The BinaryAssetsDirectory is only required if you want to run the tests directly
-		// without call the makefile target test. If not informed it will look for the
-		// default path defined in controller-runtime which is /usr/local/kubebuilder/.
-		// Note that you must have the required binaries setup under the bin directory to perform
-		// the tests directly. When we run make test it will be setup and used automatically.
-		BinaryAssetsDirectory: filepath.Join({{ .BaseDirectoryRelativePath }}, "bin", "k8s",
-			fmt.Sprintf("{{ .K8SVersion }}-%%s-%%s", runtime.GOOS, runtime.GOARCH)),
-
-		WebhookInstallOptions: envtest.WebhookInstallOptions{
-			Paths: []string{filepath.Join({{ .BaseDirectoryRelativePath }}, "config", "webhook")},
-		},
-	}
-
-	var err error
-	// cfg is defined in this file globally.
-	cfg, err = testEnv.Start()
-	Expect(err).NotTo(HaveOccurred())
-	Expect(cfg).NotTo(BeNil())
-
-	scheme := apimachineryruntime.NewScheme()
-	err = %s.AddToScheme(scheme)
-	Expect(err).NotTo(HaveOccurred())
-
-	err = %s.AddToScheme(scheme)
-	Expect(err).NotTo(HaveOccurred())
-
-	%s
-
-	k8sClient, err = client.New(cfg, client.Options{Scheme: scheme})
-	Expect(err).NotTo(HaveOccurred())
-	Expect(k8sClient).NotTo(BeNil())
-
-	// start webhook server using Manager.
-	webhookInstallOptions := &testEnv.WebhookInstallOptions
-	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
-		Scheme:             scheme,
-		WebhookServer: webhook.NewServer(webhook.Options{
-			Host:               webhookInstallOptions.LocalServingHost,
-			Port:               webhookInstallOptions.LocalServingPort,
-			CertDir:            webhookInstallOptions.LocalServingCertDir,
-		}),
-		LeaderElection:     false,
-		Metrics:            metricsserver.Options{BindAddress: "0"},
-
-	})
-	Expect(err).NotTo(HaveOccurred())
-
-	%s
-
-	go func() {
-		defer GinkgoRecover()
-		err = mgr.Start(ctx)
-		Expect(err).NotTo(HaveOccurred())
-	}()
-
-	// wait for the webhook server to get ready.
-	dialer := &net.Dialer{Timeout: time.Second}
-	addrPort := fmt.Sprintf("%s:%s", webhookInstallOptions.LocalServingHost, webhookInstallOptions.LocalServingPort)
-	Eventually(func() error {
-		conn, err := tls.DialWithDialer(dialer, "tcp", addrPort, &tls.Config{InsecureSkipVerify: true})
-		if err != nil {
-			return err
-		}
-
-		return conn.Close();
-	}).Should(Succeed())
-})
-
-var _ = AfterSuite(func() {
-	By("tearing down the test environment")
-	cancel()
-	err := testEnv.Stop()
-	Expect(err).NotTo(HaveOccurred())
-})
-`
-
-const webhookTestSuiteTemplateLegacy = `{{ .Boilerplate }}
-
-package {{ .Resource.Version }}
-
-import (
-	"context"
-	"crypto/tls"
-	"fmt"
-	"net"
-	"path/filepath"
-	"testing"
-	"time"
-	"runtime"
-
-    . "github.com/onsi/ginkgo/v2"
-    . "github.com/onsi/gomega"
-	%s
-	"k8s.io/client-go/kubernetes/scheme"
-	"k8s.io/client-go/rest"
-	apimachineryruntime "k8s.io/apimachinery/pkg/runtime"
-	ctrl "sigs.k8s.io/controller-runtime"
-	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/envtest"
-	logf "sigs.k8s.io/controller-runtime/pkg/log"
-	"sigs.k8s.io/controller-runtime/pkg/log/zap"
-	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
-)
-
-// These tests use Ginkgo (BDD-style Go testing framework). Refer to
-// http://onsi.github.io/ginkgo/ to learn more about Ginkgo.
-
-var (
-	cancel context.CancelFunc
-	cfg *rest.Config
-	ctx context.Context
-	k8sClient client.Client
-	testEnv *envtest.Environment
-)
-
-func TestAPIs(t *testing.T) {
-	RegisterFailHandler(Fail)
-
-	RunSpecs(t, "Webhook Suite")
-}
-
-var _ = BeforeSuite(func() {
-	logf.SetLogger(zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true)))
-
-	ctx, cancel = context.WithCancel(context.TODO())
-
-	By("bootstrapping test environment")
-	testEnv = &envtest.Environment{
-		CRDDirectoryPaths:     []string{filepath.Join({{ .BaseDirectoryRelativePath }}, "config", "crd", "bases")},
-		ErrorIfCRDPathMissing: {{ .WireResource }},
-
-		// The BinaryAssetsDirectory is only required if you want to run the tests directly
-		// without call the makefile target test. If not informed it will look for the
-		// default path defined in controller-runtime which is /usr/local/kubebuilder/.
-		// Note that you must have the required binaries setup under the bin directory to perform
-		// the tests directly. When we run make test it will be setup and used automatically.
-		BinaryAssetsDirectory: filepath.Join({{ .BaseDirectoryRelativePath }}, "bin", "k8s",
-			fmt.Sprintf("{{ .K8SVersion }}-%%s-%%s", runtime.GOOS, runtime.GOARCH)),
-
-		WebhookInstallOptions: envtest.WebhookInstallOptions{
-			Paths: []string{filepath.Join({{ .BaseDirectoryRelativePath }}, "config", "webhook")},
-		},
-	}
-
-	var err error
-	// cfg is defined in this file globally.
-	cfg, err = testEnv.Start()
-	Expect(err).NotTo(HaveOccurred())
-	Expect(cfg).NotTo(BeNil())
-
-	scheme := apimachineryruntime.NewScheme()
-	err = AddToScheme(scheme)
-	Expect(err).NotTo(HaveOccurred())
-
-	err = %s.AddToScheme(scheme)
-	Expect(err).NotTo(HaveOccurred())
-
-	%s
-
-	k8sClient, err = client.New(cfg, client.Options{Scheme: scheme})
-	Expect(err).NotTo(HaveOccurred())
-	Expect(k8sClient).NotTo(BeNil())
-
-	// start webhook server using Manager.
-	webhookInstallOptions := &testEnv.WebhookInstallOptions
-	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
-		Scheme:             scheme,
-		WebhookServer: webhook.NewServer(webhook.Options{
-			Host:               webhookInstallOptions.LocalServingHost,
-			Port:               webhookInstallOptions.LocalServingPort,
-			CertDir:            webhookInstallOptions.LocalServingCertDir,
-		}),
-		LeaderElection:     false,
-		Metrics:            metricsserver.Options{BindAddress: "0"},
-
-	})
-	Expect(err).NotTo(HaveOccurred())
-
-	%s
-
-	go func() {
-		defer GinkgoRecover()
-		err = mgr.Start(ctx)
-		Expect(err).NotTo(HaveOccurred())
-	}()
-
-	// wait for the webhook server to get ready.
-	dialer := &net.Dialer{Timeout: time.Second}
-	addrPort := fmt.Sprintf("%s:%s", webhookInstallOptions.LocalServingHost, webhookInstallOptions.LocalServingPort)
-	Eventually(func() error {
-		conn, err := tls.DialWithDialer(dialer, "tcp", addrPort, &tls.Config{InsecureSkipVerify: true})
-		if err != nil {
-			return err
-		}
-
-		return conn.Close();
-	}).Should(Succeed())
-})
-
-var _ = AfterSuite(func() {
-	By("tearing down the test environment")
-	cancel()
-	err := testEnv.Stop()
-	Expect(err).NotTo(HaveOccurred())
-})
-`
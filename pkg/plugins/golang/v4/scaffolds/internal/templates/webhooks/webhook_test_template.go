@@ -24,6 +24,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates"
 )
 
 var _ machinery.Template = &WebhookTest{}
@@ -63,86 +64,29 @@ func (f *WebhookTest) SetTemplateDefaults() error {
 	f.Path = f.Resource.Replacer().Replace(f.Path)
 	log.Println(f.Path)
 
-	webhookTestTemplate := webhookTestTemplate
-	templates := make([]string, 0)
+	testTemplates := make([]string, 0)
 	if f.Resource.HasDefaultingWebhook() {
-		templates = append(templates, defaultWebhookTestTemplate)
+		testTemplates = append(testTemplates, defaultWebhookTestTemplate)
 	}
 	if f.Resource.HasValidationWebhook() {
-		templates = append(templates, validateWebhookTestTemplate)
+		testTemplates = append(testTemplates, validateWebhookTestTemplate)
 	}
 	if f.Resource.HasConversionWebhook() {
-		templates = append(templates, conversionWebhookTestTemplate)
+		testTemplates = append(testTemplates, conversionWebhookTestTemplate)
 	}
-	f.TemplateBody = fmt.Sprintf(webhookTestTemplate, strings.Join(templates, "\n"))
+	webhookTestTemplate, err := machinery.ReadTemplateBody(templates.FS, "webhooks/webhook_test_template.go.tmpl")
+	if err != nil {
+		return err
+	}
+	f.TemplateBody = fmt.Sprintf(webhookTestTemplate, strings.Join(testTemplates, "\n"))
 
 	if f.Force {
-		f.IfExistsAction = machinery.OverwriteFile
+		f.IfExistsAction = machinery.WriteSidecarIfModified
 	}
 
 	return nil
 }
 
-const webhookTestTemplate = `{{ .Boilerplate }}
-
-package {{ .Resource.Version }}
-
-import (
-	. "github.com/onsi/ginkgo/v2"
-	. "github.com/onsi/gomega"
-
-	{{ if not .IsLegacyPath -}}
-	{{ if not (isEmptyStr .Resource.Path) -}}
-	{{ .Resource.ImportAlias }} "{{ .Resource.Path }}"
-	{{- end }}
-	{{- end }}
-	// TODO (user): Add any additional imports if needed
-)
-
-var _ = Describe("{{ .Resource.Kind }} Webhook", func() {
-	var (
-		{{- if .IsLegacyPath -}}
-		obj *{{ .Resource.Kind }}
-		{{- else }}
-		obj *{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}
-		oldObj *{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}
-		{{- if .Resource.HasValidationWebhook }}
-		validator {{ .Resource.Kind }}CustomValidator
-		{{- end }}
-		{{- if .Resource.HasDefaultingWebhook }}
-		defaulter {{ .Resource.Kind }}CustomDefaulter
-		{{- end }}
-		{{- end }}
-	)
-
-	BeforeEach(func() {
-		{{- if .IsLegacyPath -}}
-		obj = &{{ .Resource.Kind }}{}
-		{{- else }}
-		obj = &{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}{}
-		oldObj = &{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}{}
-		{{- if .Resource.HasValidationWebhook }}
-		validator = {{ .Resource.Kind }}CustomValidator{}
-		Expect(validator).NotTo(BeNil(), "Expected validator to be initialized")
-		{{- end }}
-		{{- if .Resource.HasDefaultingWebhook }}
-		defaulter = {{ .Resource.Kind }}CustomDefaulter{}
-		Expect(defaulter).NotTo(BeNil(), "Expected defaulter to be initialized")
-		{{- end }}
-		Expect(oldObj).NotTo(BeNil(), "Expected oldObj to be initialized")
-		{{- end }}
-		Expect(obj).NotTo(BeNil(), "Expected obj to be initialized")
-		// TODO (user): Add any setup logic common to all tests
-	})
-
-	AfterEach(func() {
-		// TODO (user): Add any teardown logic common to all tests
-	})
-
-	%s
-})
-`
-
 const conversionWebhookTestTemplate = `
 Context("When creating {{ .Resource.Kind }} under Conversion Webhook", func() {
 	// TODO (user): Add logic to convert the object to the desired version and verify the conversion
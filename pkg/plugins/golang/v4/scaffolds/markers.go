@@ -0,0 +1,60 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates/cmd"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates/controllers"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates/test/e2e"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates/webhooks"
+)
+
+// RequiredMarkers returns the markers of the files that the plugin updates when creating APIs and webhooks
+func RequiredMarkers(cfg config.Config) ([]machinery.RequiredMarker, error) {
+	inserters := []machinery.Inserter{
+		&cmd.MainUpdater{},
+		&e2e.WebhookTestUpdater{},
+	}
+
+	resources, err := cfg.GetResources()
+	if err != nil {
+		return nil, err
+	}
+	for i := range resources {
+		res := &resources[i]
+
+		if res.HasController() {
+			suite := &controllers.SuiteTest{}
+			suite.Path = controllers.SuiteTestPath(cfg.IsMultiGroup(), res)
+			inserters = append(inserters, suite)
+		}
+
+		// The webhook suites are only scaffolded for defaulting and validation webhooks, either in the
+		// current or the legacy path
+		if res.HasDefaultingWebhook() || res.HasValidationWebhook() {
+			for _, isLegacyPath := range []bool{false, true} {
+				suite := &webhooks.WebhookSuite{}
+				suite.Path = webhooks.WebhookSuitePath(cfg.IsMultiGroup(), isLegacyPath, res)
+				inserters = append(inserters, suite)
+			}
+		}
+	}
+
+	return machinery.RequiredMarkersOf(inserters...), nil
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates/api"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates/cmd"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates/controllers"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates/github"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates/test/e2e"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates/test/utils"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates/webhooks"
+)
+
+// Templates returns the templates scaffolded by the plugin whose body can be overridden
+func Templates() []machinery.Template {
+	return []machinery.Template{
+		&cmd.Main{},
+		&templates.GoMod{},
+		&templates.GitIgnore{},
+		&templates.Makefile{},
+		&templates.Dockerfile{},
+		&templates.DockerIgnore{},
+		&templates.Readme{},
+		&templates.Golangci{},
+		&templates.DevContainer{},
+		&templates.DevContainerPostInstallScript{},
+		&e2e.Test{},
+		&e2e.SuiteTest{},
+		&github.E2eTestCi{},
+		&github.TestCi{},
+		&github.LintCi{},
+		&utils.Utils{},
+		&api.Types{},
+		&api.Group{},
+		&controllers.Controller{},
+		&controllers.ControllerTest{},
+		&controllers.SuiteTest{},
+		&webhooks.Webhook{},
+		&webhooks.WebhookTest{},
+		&webhooks.WebhookSuite{},
+	}
+}
@@ -92,7 +92,7 @@ func (s *webhookScaffolder) Scaffold() error {
 		return fmt.Errorf("error updating resource: %w", err)
 	}
 
-	if err := scaffold.Execute(
+	if _, err := scaffold.Execute(
 		&webhooks.Webhook{Force: s.force, IsLegacyPath: s.isLegacy},
 		&e2e.WebhookTestUpdater{WireWebhook: true},
 		&cmd.MainUpdater{WireWebhook: true, IsLegacyPath: s.isLegacy},
@@ -108,7 +108,7 @@ You need to implement the conversion.Hub and conversion.Convertible interfaces f
 
 	// TODO: Add test suite for conversion webhook after #1664 has been merged & conversion tests supported in envtest.
 	if doDefaulting || doValidation {
-		if err := scaffold.Execute(
+		if _, err := scaffold.Execute(
 			&webhooks.WebhookSuite{K8SVersion: EnvtestK8SVersion, IsLegacyPath: s.isLegacy},
 		); err != nil {
 			return err
@@ -117,13 +117,14 @@ You need to implement the conversion.Hub and conversion.Convertible interfaces f
 
 	// TODO: remove for go/v5
 	if !s.isLegacy {
-		if hasInternalController, err := pluginutil.HasFileContentWith("Dockerfile", "internal/controller"); err != nil {
+		hasInternalController, err := pluginutil.HasFileContentWithFS(s.fs, "Dockerfile", "internal/controller")
+		if err != nil {
 			log.Error("Unable to read Dockerfile to check if webhook(s) will be properly copied: ", err)
 		} else if hasInternalController {
 			log.Warning("Dockerfile is copying internal/controller. To allow copying webhooks, " +
 				"it will be edited, and `internal/controller` will be replaced by `internal/`.")
 
-			if err := pluginutil.ReplaceInFile("Dockerfile", "internal/controller", "internal/"); err != nil {
+			if err := pluginutil.ReplaceInFileFS(s.fs, "Dockerfile", "internal/controller", "internal/"); err != nil {
 				log.Error("Unable to replace \"internal/controller\" with \"internal/\" in the Dockerfile: ", err)
 			}
 		}
@@ -19,6 +19,7 @@ package v4
 import (
 	"errors"
 	"fmt"
+	"io"
 
 	"github.com/spf13/pflag"
 
@@ -35,6 +36,8 @@ var _ plugin.CreateWebhookSubcommand = &createWebhookSubcommand{}
 
 type createWebhookSubcommand struct {
 	config config.Config
+	// out is where the messages and the output of the commands are printed
+	out io.Writer
 	// For help text.
 	commandName string
 
@@ -105,6 +108,11 @@ func (p *createWebhookSubcommand) InjectConfig(c config.Config) error {
 	return nil
 }
 
+func (p *createWebhookSubcommand) InjectOutput(out io.Writer) error {
+	p.out = out
+	return nil
+}
+
 func (p *createWebhookSubcommand) InjectResource(res *resource.Resource) error {
 	p.resource = res
 
@@ -145,19 +153,20 @@ func (p *createWebhookSubcommand) Scaffold(fs machinery.Filesystem) error {
 }
 
 func (p *createWebhookSubcommand) PostScaffold() error {
-	err := pluginutil.RunCmd("Update dependencies", "go", "mod", "tidy")
+	err := pluginutil.RunCmdWithOutput(p.out, "Update dependencies", "go", "mod", "tidy")
 	if err != nil {
 		return err
 	}
 
 	if p.runMake {
-		err = pluginutil.RunCmd("Running make", "make", "generate")
+		err = pluginutil.RunCmdWithOutput(p.out, "Running make", "make", "generate")
 		if err != nil {
 			return err
 		}
 	}
 
-	fmt.Print("Next: implement your new Webhook and generate the manifests with:\n$ make manifests\n")
+	_, _ = fmt.Fprint(p.out,
+		"Next: implement your new Webhook and generate the manifests with:\n$ make manifests\n")
 
 	return nil
 }
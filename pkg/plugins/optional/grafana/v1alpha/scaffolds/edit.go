@@ -182,5 +182,6 @@ func (s *editScaffolder) Scaffold() error {
 		_, _ = fmt.Fprintf(os.Stderr, "Error on scaffolding manifest for custom metris:\n%v", err)
 	}
 
-	return scaffold.Execute(templatesBuilder...)
+	_, err = scaffold.Execute(templatesBuilder...)
+	return err
 }
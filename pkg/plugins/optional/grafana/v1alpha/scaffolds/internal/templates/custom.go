@@ -32,27 +32,13 @@ type CustomMetricsConfigManifest struct {
 func (f *CustomMetricsConfigManifest) SetTemplateDefaults() error {
 	f.Path = f.ConfigPath
 
-	f.TemplateBody = customMetricsConfigTemplate
+	body, err := machinery.ReadTemplateBody(templatesFS, "custom.yaml.tmpl")
+	if err != nil {
+		return err
+	}
+	f.TemplateBody = body
 
 	f.IfExistsAction = machinery.SkipFile
 
 	return nil
 }
-
-// nolint: lll
-const customMetricsConfigTemplate = `---
-customMetrics:
-#  - metric: # Raw custom metric (required)
-#    type:   # Metric type: counter/gauge/histogram (required)
-#    expr:   # Prom_ql for the metric (optional)
-#    unit:   # Unit of measurement, examples: s,none,bytes,percent,etc. (optional)
-#
-#
-# Example:
-# ---
-# customMetrics:
-#   - metric: foo_bar
-#     unit: none
-#     type: histogram
-#   	expr: histogram_quantile(0.90, sum by(instance, le) (rate(foo_bar{job=\"$job\", namespace=\"$namespace\"}[5m])))
-`
@@ -0,0 +1,43 @@
+/*
+Copyright 2026 The Nho Luong DevOps.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugins
+
+import (
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
+)
+
+// LookupResource returns the tracked resource with the provided GVK. Core and external resources are tracked
+// with their own domain instead of the project one, so they are matched by group, version and kind.
+func LookupResource(cfg config.Config, gvk resource.GVK) (resource.Resource, error) {
+	res, err := cfg.GetResource(gvk)
+	if err == nil {
+		return res, nil
+	}
+
+	resources, listErr := cfg.GetResources()
+	if listErr != nil {
+		return resource.Resource{}, listErr
+	}
+	for _, r := range resources {
+		if (r.Core || r.External) && r.Group == gvk.Group && r.Version == gvk.Version && r.Kind == gvk.Kind {
+			return r, nil
+		}
+	}
+
+	return resource.Resource{}, err
+}